@@ -18,13 +18,22 @@ package api
 
 import (
 	v1 "github.com/cloudspannerecosystem/dynamodb-adapter/api/v1"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
 
 	"github.com/gin-gonic/gin"
 )
 
 // InitAPI - initialize api
 func InitAPI(g *gin.Engine) {
+	if config.ConfigurationMap.CORSEnabled {
+		g.Use(v1.CORSMiddleware())
+	}
+	g.Use(v1.GzipMiddleware())
+	g.Use(v1.RequestBodyLimit())
+	g.Use(v1.ReadYourWrites())
+	g.GET("/readyz", v1.Readyz)
 	r := g.Group("/v1")
 	v1.InitDBAPI(r)
+	v1.InitInternalAPI(r)
 
 }
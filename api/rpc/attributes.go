@@ -0,0 +1,169 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	v1 "github.com/cloudspannerecosystem/dynamodb-adapter/api/v1"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+)
+
+// dynamoValue converts an AttributeValue of a request to the
+// dynamodb.AttributeValue the /v1 operations take
+func dynamoValue(in *AttributeValue) *dynamodb.AttributeValue {
+	if in == nil {
+		return nil
+	}
+	out := &dynamodb.AttributeValue{}
+	switch v := in.Value.(type) {
+	case *AttributeValue_S:
+		out.S = aws.String(v.S)
+	case *AttributeValue_N:
+		out.N = aws.String(v.N)
+	case *AttributeValue_B:
+		out.B = v.B
+	case *AttributeValue_Ss:
+		out.SS = aws.StringSlice(v.Ss.GetValues())
+	case *AttributeValue_Ns:
+		out.NS = aws.StringSlice(v.Ns.GetValues())
+	case *AttributeValue_Bs:
+		out.BS = v.Bs.GetValues()
+	case *AttributeValue_M:
+		out.M = dynamoMap(v.M.GetFields())
+		if out.M == nil {
+			out.M = map[string]*dynamodb.AttributeValue{}
+		}
+	case *AttributeValue_L:
+		out.L = make([]*dynamodb.AttributeValue, len(v.L.GetValues()))
+		for i, elem := range v.L.GetValues() {
+			out.L[i] = dynamoValue(elem)
+		}
+	case *AttributeValue_Null:
+		out.NULL = aws.Bool(v.Null)
+	case *AttributeValue_Bool:
+		out.BOOL = aws.Bool(v.Bool)
+	}
+	return out
+}
+
+// dynamoMap converts an item, a key or the expression attribute values of a
+// request, nil when it has no attributes
+func dynamoMap(in map[string]*AttributeValue) map[string]*dynamodb.AttributeValue {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]*dynamodb.AttributeValue, len(in))
+	for name, value := range in {
+		out[name] = dynamoValue(value)
+	}
+	return out
+}
+
+// dynamoMaps converts a list of items or keys of a request
+func dynamoMaps(in []*AttributeValueMap) []map[string]*dynamodb.AttributeValue {
+	out := make([]map[string]*dynamodb.AttributeValue, len(in))
+	for i, m := range in {
+		out[i] = dynamoMap(m.GetFields())
+	}
+	return out
+}
+
+// attributeMap converts an item or a key the /v1 operations return to the
+// AttributeValues of a response, nil for a nil item
+func attributeMap(item map[string]interface{}) (map[string]*AttributeValue, error) {
+	if item == nil {
+		return nil, nil
+	}
+	dynamoItem, err := v1.ChangeMaptoDynamoMap(item)
+	if err != nil {
+		return nil, err
+	}
+	return attributeFields(dynamoItem)
+}
+
+// attributeMaps converts a list of items the /v1 operations return
+func attributeMaps(items []map[string]interface{}) ([]*AttributeValueMap, error) {
+	out := make([]*AttributeValueMap, len(items))
+	for i, item := range items {
+		fields, err := attributeMap(item)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = &AttributeValueMap{Fields: fields}
+	}
+	return out, nil
+}
+
+// attributeFields converts the attributes of a DynamoDB map, as returned by
+// ChangeMaptoDynamoMap
+func attributeFields(in map[string]interface{}) (map[string]*AttributeValue, error) {
+	out := make(map[string]*AttributeValue, len(in))
+	for name, value := range in {
+		v, err := attributeValue(value)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = v
+	}
+	return out, nil
+}
+
+// attributeValue converts an attribute value of ChangeMaptoDynamoMap to an
+// AttributeValue. A value ChangeMaptoDynamoMap converts to nothing, like an
+// empty binary, is NULL.
+func attributeValue(in interface{}) (*AttributeValue, error) {
+	m, _ := in.(map[string]interface{})
+	if len(m) == 0 {
+		return &AttributeValue{Value: &AttributeValue_Null{Null: true}}, nil
+	}
+	for typ, value := range m {
+		switch v := value.(type) {
+		case string:
+			switch typ {
+			case "S":
+				return &AttributeValue{Value: &AttributeValue_S{S: v}}, nil
+			case "N":
+				return &AttributeValue{Value: &AttributeValue_N{N: v}}, nil
+			}
+		case []byte:
+			return &AttributeValue{Value: &AttributeValue_B{B: v}}, nil
+		case bool:
+			switch typ {
+			case "BOOL":
+				return &AttributeValue{Value: &AttributeValue_Bool{Bool: v}}, nil
+			case "NULL":
+				return &AttributeValue{Value: &AttributeValue_Null{Null: v}}, nil
+			}
+		case map[string]interface{}:
+			fields, err := attributeFields(v)
+			if err != nil {
+				return nil, err
+			}
+			return &AttributeValue{Value: &AttributeValue_M{M: &AttributeValueMap{Fields: fields}}}, nil
+		case []map[string]interface{}:
+			list := &AttributeValueList{Values: make([]*AttributeValue, len(v))}
+			for i, elem := range v {
+				elemValue, err := attributeValue(elem)
+				if err != nil {
+					return nil, err
+				}
+				list.Values[i] = elemValue
+			}
+			return &AttributeValue{Value: &AttributeValue_L{L: list}}, nil
+		}
+	}
+	return nil, errors.New("ServerInternalError", "unsupported attribute value", in)
+}
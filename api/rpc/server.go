@@ -0,0 +1,447 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rpc serves the DynamoDBAdapter gRPC service of adapter.proto. Every
+// call runs the operation of the /v1 api of the same name, so both surfaces
+// share their validation and service logic.
+package rpc
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. adapter.proto
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/cloudspannerecosystem/dynamodb-adapter/api/v1"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/service/services"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// sessionKey is the metadata holding the session token of a client, like the
+// X-Dynamodb-Adapter-Session header of the /v1 api
+const sessionKey = "x-dynamodb-adapter-session"
+
+// readTimestampKey is the header metadata set to the timestamp the reads of a
+// call read at, like the X-Adapter-Read-Timestamp header of the /v1 api
+const readTimestampKey = "x-adapter-read-timestamp"
+
+// errorCodes are the gRPC codes of the DynamoDB errors which have a closer
+// match than the one of their http status
+var errorCodes = map[string]codes.Code{
+	"ConditionalCheckFailedException":        codes.FailedPrecondition,
+	"ResourceNotFoundException":              codes.NotFound,
+	"ProvisionedThroughputExceededException": codes.ResourceExhausted,
+	"AccessDeniedException":                  codes.PermissionDenied,
+}
+
+// httpCodes are the gRPC codes of the http statuses of the /v1 api
+var httpCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusRequestEntityTooLarge: codes.InvalidArgument,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusServiceUnavailable:    codes.Unavailable,
+}
+
+// server implements the DynamoDBAdapter service with the operations of the
+// /v1 api
+type server struct{}
+
+// Register registers the DynamoDBAdapter service on s
+func Register(s *grpc.Server) {
+	RegisterDynamoDBAdapterServer(s, server{})
+}
+
+// NewServer returns a gRPC server of the DynamoDBAdapter service
+func NewServer() *grpc.Server {
+	s := grpc.NewServer(grpc.UnaryInterceptor(recoverPanic))
+	Register(s)
+	return s
+}
+
+// Serve serves s on addr until the listener fails or s is stopped
+func Serve(s *grpc.Server, addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(lis)
+}
+
+// recoverPanic answers the calls which panic with an Internal error, as
+// PanicHandler does for the /v1 api
+func recoverPanic(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			errors.New("ServerInternalError", e, string(debug.Stack()))
+			err = status.Error(codes.Internal, fmt.Sprint("ServerInternalError: ", e))
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func (server) GetItem(ctx context.Context, in *GetItemRequest) (*GetItemResponse, error) {
+	ctx = readContext(ctx)
+	defer sendReadTimestamp(ctx)
+	item, notModified, err := v1.DoGetItem(ctx, models.GetItemMeta{
+		TableName:                in.TableName,
+		Key:                      dynamoMap(in.Key),
+		ProjectionExpression:     in.ProjectionExpression,
+		ExpressionAttributeNames: in.ExpressionAttributeNames,
+		ConsistentRead:           optionalBool(in.ConsistentRead.GetValue(), in.ConsistentRead != nil),
+		IfVersionNotEqual:        dynamoValue(in.IfVersionNotEqual),
+	})
+	if err != nil {
+		return &GetItemResponse{}, statusError(err, in)
+	}
+	out := &GetItemResponse{NotModified: notModified}
+	if out.Item, err = attributeMap(item); err != nil {
+		return nil, statusError(err, in)
+	}
+	return out, nil
+}
+
+func (server) BatchGetItem(ctx context.Context, in *BatchGetItemRequest) (*BatchGetItemResponse, error) {
+	ctx = readContext(ctx)
+	defer sendReadTimestamp(ctx)
+	batchGetMeta := models.BatchGetMeta{
+		RequestItems:           make(map[string]models.BatchGetWithProjectionMeta, len(in.RequestItems)),
+		ReturnConsumedCapacity: in.ReturnConsumedCapacity,
+	}
+	for table, keys := range in.RequestItems {
+		batchGetMeta.RequestItems[table] = models.BatchGetWithProjectionMeta{
+			Keys:                     dynamoMaps(keys.GetKeys()),
+			ProjectionExpression:     keys.GetProjectionExpression(),
+			ExpressionAttributeNames: keys.GetExpressionAttributeNames(),
+			ConsistentRead:           optionalBool(keys.GetConsistentRead().GetValue(), keys.GetConsistentRead() != nil),
+		}
+	}
+	responses, capacityUnits, err := v1.DoBatchGetItem(ctx, batchGetMeta)
+	if err != nil {
+		return &BatchGetItemResponse{}, statusError(err, in)
+	}
+	out := &BatchGetItemResponse{
+		Responses:        make(map[string]*ItemList, len(responses)),
+		ConsumedCapacity: consumedCapacity(in.ReturnConsumedCapacity, capacityUnits),
+	}
+	for table, items := range responses {
+		list := &ItemList{}
+		if list.Items, err = attributeMaps(items); err != nil {
+			return nil, statusError(err, in)
+		}
+		out.Responses[table] = list
+	}
+	return out, nil
+}
+
+func (server) Query(ctx context.Context, in *QueryRequest) (*QueryResponse, error) {
+	ctx = readContext(ctx)
+	defer sendReadTimestamp(ctx)
+	res, err := v1.DoQuery(ctx, query(in))
+	if err != nil {
+		return &QueryResponse{}, statusError(err, in)
+	}
+	out, err := queryResponse(res)
+	if err != nil {
+		return nil, statusError(err, in)
+	}
+	return out, nil
+}
+
+func (server) BatchQuery(ctx context.Context, in *BatchQueryRequest) (*BatchQueryResponse, error) {
+	ctx = readContext(ctx)
+	defer sendReadTimestamp(ctx)
+	batchQuery := models.BatchQuery{Queries: make([]models.Query, len(in.Queries))}
+	for i, q := range in.Queries {
+		batchQuery.Queries[i] = query(q)
+	}
+	results, err := v1.DoBatchQuery(ctx, batchQuery)
+	if err != nil {
+		return &BatchQueryResponse{}, statusError(err, in)
+	}
+	out := &BatchQueryResponse{Responses: make([]*QueryResponse, len(results))}
+	for i, res := range results {
+		if out.Responses[i], err = queryResponse(res); err != nil {
+			return nil, statusError(err, in)
+		}
+	}
+	return out, nil
+}
+
+func (server) Scan(ctx context.Context, in *ScanRequest) (*ScanResponse, error) {
+	ctx = readContext(ctx)
+	defer sendReadTimestamp(ctx)
+	res, err := v1.DoScan(ctx, models.ScanMeta{
+		TableName:                 in.TableName,
+		IndexName:                 in.IndexName,
+		FilterExpression:          in.FilterExpression,
+		ProjectionExpression:      in.ProjectionExpression,
+		ExpressionAttributeNames:  in.ExpressionAttributeNames,
+		ExpressionAttributeValues: dynamoMap(in.ExpressionAttributeValues),
+		ExclusiveStartKey:         dynamoMap(in.ExclusiveStartKey),
+		Limit:                     in.Limit,
+		Select:                    in.Select,
+		AllowFullScan:             in.AllowFullScan,
+		Segment:                   in.Segment,
+		TotalSegments:             in.TotalSegments,
+	})
+	if err != nil {
+		return &ScanResponse{}, statusError(err, in)
+	}
+	page, err := queryResponse(res)
+	if err != nil {
+		return nil, statusError(err, in)
+	}
+	return &ScanResponse{Items: page.Items, Count: page.Count, LastEvaluatedKey: page.LastEvaluatedKey}, nil
+}
+
+func (server) PutItem(ctx context.Context, in *PutItemRequest) (*PutItemResponse, error) {
+	if err := checkWritable(in); err != nil {
+		return nil, err
+	}
+	attributes, err := v1.DoPutItem(ctx, models.Meta{
+		TableName:                 in.TableName,
+		Item:                      dynamoMap(in.Item),
+		ConditionExpression:       in.ConditionExpression,
+		ExpressionAttributeNames:  in.ExpressionAttributeNames,
+		ExpressionAttributeValues: dynamoMap(in.ExpressionAttributeValues),
+		ReturnValues:              in.ReturnValues,
+		ExpectedVersion:           dynamoValue(in.ExpectedVersion),
+	})
+	if err != nil {
+		return &PutItemResponse{}, statusError(err, in)
+	}
+	wrote(ctx)
+	out := &PutItemResponse{}
+	if out.Attributes, err = attributeMap(attributes); err != nil {
+		return nil, statusError(err, in)
+	}
+	return out, nil
+}
+
+func (server) UpdateItem(ctx context.Context, in *UpdateItemRequest) (*UpdateItemResponse, error) {
+	if err := checkWritable(in); err != nil {
+		return nil, err
+	}
+	attributes, err := v1.DoUpdateItem(ctx, models.UpdateAttr{
+		TableName:                 in.TableName,
+		Key:                       dynamoMap(in.Key),
+		UpdateExpression:          in.UpdateExpression,
+		ConditionExpression:       in.ConditionExpression,
+		ExpressionAttributeNames:  in.ExpressionAttributeNames,
+		ExpressionAttributeValues: dynamoMap(in.ExpressionAttributeValues),
+		ReturnValues:              in.ReturnValues,
+		ExpectedVersion:           dynamoValue(in.ExpectedVersion),
+	})
+	if err != nil {
+		return &UpdateItemResponse{}, statusError(err, in)
+	}
+	wrote(ctx)
+	out := &UpdateItemResponse{}
+	if out.Attributes, err = attributeMap(attributes); err != nil {
+		return nil, statusError(err, in)
+	}
+	return out, nil
+}
+
+func (server) DeleteItem(ctx context.Context, in *DeleteItemRequest) (*DeleteItemResponse, error) {
+	if err := checkWritable(in); err != nil {
+		return nil, err
+	}
+	attributes, err := v1.DoDeleteItem(ctx, models.Delete{
+		TableName:                 in.TableName,
+		Key:                       dynamoMap(in.Key),
+		ConditionExpression:       in.ConditionExpression,
+		ExpressionAttributeNames:  in.ExpressionAttributeNames,
+		ExpressionAttributeValues: dynamoMap(in.ExpressionAttributeValues),
+	})
+	if err != nil {
+		return &DeleteItemResponse{}, statusError(err, in)
+	}
+	wrote(ctx)
+	out := &DeleteItemResponse{}
+	if out.Attributes, err = attributeMap(attributes); err != nil {
+		return nil, statusError(err, in)
+	}
+	return out, nil
+}
+
+func (server) BatchWriteItem(ctx context.Context, in *BatchWriteItemRequest) (*BatchWriteItemResponse, error) {
+	if err := checkWritable(in); err != nil {
+		return nil, err
+	}
+	batchWriteItem := models.BatchWriteItem{
+		RequestItems:           make(map[string][]models.BatchWriteSubItems, len(in.RequestItems)),
+		ReturnConsumedCapacity: in.ReturnConsumedCapacity,
+	}
+	for table, requests := range in.RequestItems {
+		writes := make([]models.BatchWriteSubItems, len(requests.GetRequests()))
+		for i, request := range requests.GetRequests() {
+			writes[i].PutReq.Item = dynamoMap(request.GetPutRequest().GetItem())
+			writes[i].DelReq.Key = dynamoMap(request.GetDeleteRequest().GetKey())
+		}
+		batchWriteItem.RequestItems[table] = writes
+	}
+	capacityUnits, err := v1.DoBatchWriteItem(ctx, batchWriteItem)
+	if err != nil {
+		return &BatchWriteItemResponse{}, statusError(err, in)
+	}
+	wrote(ctx)
+	return &BatchWriteItemResponse{ConsumedCapacity: consumedCapacity(in.ReturnConsumedCapacity, capacityUnits)}, nil
+}
+
+// query converts a QueryRequest to the models.Query of the /v1 api
+func query(in *QueryRequest) models.Query {
+	return models.Query{
+		TableName:                 in.GetTableName(),
+		IndexName:                 in.GetIndexName(),
+		RangeExp:                  in.GetKeyConditionExpression(),
+		FilterExp:                 in.GetFilterExpression(),
+		ProjectionExpression:      in.GetProjectionExpression(),
+		ExpressionAttributeNames:  in.GetExpressionAttributeNames(),
+		ExpressionAttributeValues: dynamoMap(in.GetExpressionAttributeValues()),
+		ExclusiveStartKey:         dynamoMap(in.GetExclusiveStartKey()),
+		Limit:                     in.GetLimit(),
+		SortAscending:             in.GetScanIndexForward(),
+		Select:                    in.GetSelect(),
+		AllowFullScan:             in.GetAllowFullScan(),
+	}
+}
+
+// queryResponse converts the result of a query or a scan to a QueryResponse
+func queryResponse(res map[string]interface{}) (*QueryResponse, error) {
+	out := &QueryResponse{}
+	switch count := res["Count"].(type) {
+	case int:
+		out.Count = int64(count)
+	case int64:
+		out.Count = count
+	}
+	items, _ := res["Items"].([]map[string]interface{})
+	lastEvaluatedKey, _ := res["LastEvaluatedKey"].(map[string]interface{})
+	var err error
+	if out.Items, err = attributeMaps(items); err != nil {
+		return nil, err
+	}
+	if out.LastEvaluatedKey, err = attributeMap(lastEvaluatedKey); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// consumedCapacity returns the ConsumedCapacity of a batch, in the order of
+// the tables, or nil unless returnConsumedCapacity is TOTAL or INDEXES
+func consumedCapacity(returnConsumedCapacity string, units map[string]float64) []*ConsumedCapacity {
+	if returnConsumedCapacity != "TOTAL" && returnConsumedCapacity != "INDEXES" {
+		return nil
+	}
+	capacity := make([]*ConsumedCapacity, 0, len(units))
+	for table, tableUnits := range units {
+		capacity = append(capacity, &ConsumedCapacity{TableName: table, CapacityUnits: tableUnits})
+	}
+	sort.Slice(capacity, func(i, j int) bool { return capacity[i].TableName < capacity[j].TableName })
+	return capacity
+}
+
+// optionalBool returns a pointer to value when it is set
+func optionalBool(value, set bool) *bool {
+	if !set {
+		return nil
+	}
+	return &value
+}
+
+// sessionToken returns the session token sent with the call
+func sessionToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if tokens := md.Get(sessionKey); len(tokens) > 0 {
+		return tokens[0]
+	}
+	return ""
+}
+
+// readContext makes the reads of a call strongly consistent right after a
+// write of its session, and records the timestamp they read at
+func readContext(ctx context.Context) context.Context {
+	return storage.WithReadTimestamp(v1.SessionContext(ctx, sessionToken(ctx)))
+}
+
+// sendReadTimestamp sends the timestamp the reads of a call read at in the
+// header of its response
+func sendReadTimestamp(ctx context.Context) {
+	if ts, ok := storage.ReadTimestamp(ctx); ok {
+		grpc.SetHeader(ctx, metadata.Pairs(readTimestampKey, ts.UTC().Format(time.RFC3339Nano)))
+	}
+}
+
+// checkWritable rejects writes with Unavailable while the read-only mode is
+// on, like RejectWritesWhenReadOnly does on the /v1 api
+func checkWritable(in interface{}) error {
+	if services.IsReadOnly() {
+		return statusError(errors.New("ServiceUnavailable", "writes are disabled while the adapter is in read-only mode"), in)
+	}
+	return nil
+}
+
+// wrote records a successful write of the session of a call
+func wrote(ctx context.Context) {
+	v1.SessionWrote(sessionToken(ctx))
+}
+
+// statusError converts an error of the /v1 operations to a gRPC status with
+// the DynamoDB error code at the start of its message. Retryable errors are
+// Unavailable, which gRPC clients retry. v1.ErrNotAllowed is no error, the
+// call gets an empty response as on the /v1 api.
+func statusError(err error, in interface{}) error {
+	if err == v1.ErrNotAllowed {
+		return nil
+	}
+	httpStatus, body := errors.HTTPResponse(err, in)
+	e, _ := body.(map[string]interface{})
+	code, _ := e["code"].(string)
+	message, _ := e["message"].(string)
+	retryable, _ := e["retryable"].(bool)
+	grpcCode, ok := errorCodes[code]
+	if !ok && retryable {
+		grpcCode = codes.Unavailable
+	} else if !ok {
+		grpcCode = httpCode(httpStatus)
+	}
+	return status.Error(grpcCode, code+": "+strings.TrimSpace(message))
+}
+
+// httpCode returns the gRPC code of an http error status
+func httpCode(httpStatus int) codes.Code {
+	if code, ok := httpCodes[httpStatus]; ok {
+		return code
+	}
+	if httpStatus >= http.StatusInternalServerError {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
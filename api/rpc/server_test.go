@@ -0,0 +1,144 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	v1 "github.com/cloudspannerecosystem/dynamodb-adapter/api/v1"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/service/services"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+// dial serves the service and connects a client to it
+func dial(t *testing.T) (DynamoDBAdapterClient, func()) {
+	lis := bufconn.Listen(1 << 20)
+	s := NewServer()
+	go s.Serve(lis)
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	assert.Equal(t, err, nil)
+	return NewDynamoDBAdapterClient(conn), func() {
+		conn.Close()
+		s.GracefulStop()
+	}
+}
+
+func TestDynamoValue(t *testing.T) {
+	tests := []struct {
+		testName string
+		in       *AttributeValue
+		want     *dynamodb.AttributeValue
+	}{
+		{"nil", nil, nil},
+		{"string", &AttributeValue{Value: &AttributeValue_S{S: "a"}}, &dynamodb.AttributeValue{S: aws.String("a")}},
+		{"number", &AttributeValue{Value: &AttributeValue_N{N: "1.50"}}, &dynamodb.AttributeValue{N: aws.String("1.50")}},
+		{"number set", &AttributeValue{Value: &AttributeValue_Ns{Ns: &StringList{Values: []string{"1", "2"}}}}, &dynamodb.AttributeValue{NS: aws.StringSlice([]string{"1", "2"})}},
+		{"empty map", &AttributeValue{Value: &AttributeValue_M{M: &AttributeValueMap{}}}, &dynamodb.AttributeValue{M: map[string]*dynamodb.AttributeValue{}}},
+		{
+			"list",
+			&AttributeValue{Value: &AttributeValue_L{L: &AttributeValueList{Values: []*AttributeValue{{Value: &AttributeValue_Bool{Bool: true}}, {Value: &AttributeValue_Null{Null: true}}}}}},
+			&dynamodb.AttributeValue{L: []*dynamodb.AttributeValue{{BOOL: aws.Bool(true)}, {NULL: aws.Bool(true)}}},
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, dynamoValue(tc.in), tc.want)
+	}
+}
+
+func TestAttributeMap(t *testing.T) {
+	tests := []struct {
+		testName string
+		in       map[string]interface{}
+		want     map[string]*AttributeValue
+	}{
+		{"nil item", nil, nil},
+		{
+			"scalars",
+			map[string]interface{}{"name": "a", "age": int64(3), "active": true, "photo": []byte("b")},
+			map[string]*AttributeValue{
+				"name":   {Value: &AttributeValue_S{S: "a"}},
+				"age":    {Value: &AttributeValue_N{N: "3"}},
+				"active": {Value: &AttributeValue_Bool{Bool: true}},
+				"photo":  {Value: &AttributeValue_B{B: []byte("b")}},
+			},
+		},
+		{
+			"nested",
+			map[string]interface{}{"address": map[string]interface{}{"tags": []interface{}{"x", 1.5}}},
+			map[string]*AttributeValue{
+				"address": {Value: &AttributeValue_M{M: &AttributeValueMap{Fields: map[string]*AttributeValue{
+					"tags": {Value: &AttributeValue_L{L: &AttributeValueList{Values: []*AttributeValue{
+						{Value: &AttributeValue_S{S: "x"}},
+						{Value: &AttributeValue_N{N: "1.5"}},
+					}}}},
+				}}}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		got, err := attributeMap(tc.in)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, got, tc.want)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		testName    string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{"conditional check failed", errors.New("ConditionalCheckFailedException", "The conditional request failed"), codes.FailedPrecondition, "ConditionalCheckFailedException: The conditional request failed"},
+		{"retryable error", errors.New("InternalServerError", "deadline exceeded"), codes.Unavailable, "InternalServerError: deadline exceeded"},
+		{"validation error", errors.New("ValidationException", "missing key"), codes.InvalidArgument, "ValidationException: missing key"},
+	}
+	for _, tc := range tests {
+		err := statusError(tc.err, nil)
+		assert.Equal(t, status.Code(err), tc.wantCode)
+		assert.Equal(t, status.Convert(err).Message(), tc.wantMessage)
+	}
+	assert.Equal(t, statusError(v1.ErrNotAllowed, nil), nil)
+}
+
+func TestServer(t *testing.T) {
+	client, stop := dial(t)
+	defer stop()
+	ctx := context.Background()
+
+	_, err := client.BatchQuery(ctx, &BatchQueryRequest{})
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+	assert.Equal(t, status.Convert(err).Message(), "ValidationException: Queries must have between 1 and 100 queries")
+
+	_, err = client.Query(ctx, &QueryRequest{TableName: "employee", KeyConditionExpression: "emp_id = :v"})
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+
+	services.SetReadOnly(true)
+	defer services.SetReadOnly(false)
+	_, err = client.PutItem(ctx, &PutItemRequest{TableName: "employee"})
+	assert.Equal(t, status.Code(err), codes.Unavailable)
+	assert.Equal(t, status.Convert(err).Message(), "ServiceUnavailable: writes are disabled while the adapter is in read-only mode")
+}
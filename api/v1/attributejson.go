@@ -0,0 +1,223 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/utils"
+)
+
+// directEncoder is the AttributeValueEncoder which writes the DynamoDB JSON
+// of the responses without converting them to maps first
+const directEncoder = "direct"
+
+// attributeValuesJSON converts an item, a list of items or a key of a
+// response to DynamoDB JSON, like ChangeMaptoDynamoMap. With the direct
+// AttributeValueEncoder it is encoded right away, to the same bytes
+// encoding/json writes for the converted maps.
+func attributeValuesJSON(in interface{}) (interface{}, error) {
+	if in == nil {
+		return nil, nil
+	}
+	if config.ConfigurationMap.AttributeValueEncoder != directEncoder {
+		return ChangeMaptoDynamoMap(in)
+	}
+	b, err := appendObjectJSON(nil, reflect.ValueOf(in))
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(b), nil
+}
+
+// itemListJSON converts a list of items to the list of their DynamoDB JSON,
+// the L of the attributeValuesJSON of the list
+func itemListJSON(items interface{}) (interface{}, error) {
+	if config.ConfigurationMap.AttributeValueEncoder != directEncoder {
+		output, err := ChangeMaptoDynamoMap(items)
+		return output["L"], err
+	}
+	v := valueElem(reflect.ValueOf(items))
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array || v.Type().Elem().Kind() == reflect.Uint8 || v.Len() == 0 && v.Kind() == reflect.Array {
+		return nil, nil
+	}
+	b, err := appendListJSON(nil, v, appendObjectJSON)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(b), nil
+}
+
+// appendObjectJSON appends the DynamoDB JSON of a value the way
+// convertMapToDynamoObject converts it: maps and the items of a list stay
+// unwrapped
+func appendObjectJSON(b []byte, v reflect.Value) ([]byte, error) {
+	v = valueElem(v)
+	switch v.Kind() {
+	case reflect.Map:
+		return appendMapJSON(b, v)
+	case reflect.Slice, reflect.Array:
+		return appendSliceJSON(b, v, appendObjectJSON)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return append(b, "{}"...), nil
+	}
+	return appendSingleJSON(b, v), nil
+}
+
+// appendAttributeJSON appends the DynamoDB JSON of an attribute the way
+// convertAttribute converts it, wrapping maps in M
+func appendAttributeJSON(b []byte, v reflect.Value) ([]byte, error) {
+	v = valueElem(v)
+	switch v.Kind() {
+	case reflect.Map:
+		b = append(b, `{"M":`...)
+		b, err := appendMapJSON(b, v)
+		return append(b, '}'), err
+	case reflect.Slice, reflect.Array:
+		return appendSliceJSON(b, v, appendAttributeJSON)
+	}
+	return appendObjectJSON(b, v)
+}
+
+// appendMapJSON appends the attributes of a map in the order of their names,
+// the order of encoding/json
+func appendMapJSON(b []byte, v reflect.Value) ([]byte, error) {
+	keys := make([]string, 0, v.Len())
+	values := make(map[string]reflect.Value, v.Len())
+	if m, ok := v.Interface().(map[string]interface{}); ok {
+		// items and keys skip the reflection on their attribute names
+		for k, elem := range m {
+			keys = append(keys, k)
+			values[k] = reflect.ValueOf(elem)
+		}
+	} else {
+		for _, key := range v.MapKeys() {
+			k := fmt.Sprint(key.Interface())
+			keys = append(keys, k)
+			values[k] = v.MapIndex(key)
+		}
+	}
+	sort.Strings(keys)
+	b = append(b, '{')
+	for i, k := range keys {
+		if k == "" {
+			return b, errors.New("Key name cannot be empty")
+		}
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendStringJSON(b, k)
+		b = append(b, ':')
+		var err error
+		if b, err = appendAttributeJSON(b, values[k]); err != nil {
+			return b, err
+		}
+	}
+	return append(b, '}'), nil
+}
+
+// appendSliceJSON appends a binary value as B and any other list as L
+func appendSliceJSON(b []byte, v reflect.Value, appendElem func([]byte, reflect.Value) ([]byte, error)) ([]byte, error) {
+	if v.Kind() == reflect.Array && v.Len() == 0 {
+		return append(b, "{}"...), nil
+	}
+	if v.Type().Elem().Kind() == reflect.Uint8 {
+		if v.Len() == 0 {
+			return append(b, "{}"...), nil
+		}
+		bytes := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(bytes), v)
+		b = append(b, `{"B":"`...)
+		b = append(b, base64.StdEncoding.EncodeToString(bytes)...)
+		return append(b, `"}`...), nil
+	}
+	b = append(b, `{"L":`...)
+	b, err := appendListJSON(b, v, appendElem)
+	return append(b, '}'), err
+}
+
+// appendListJSON appends the elements of a list as a JSON array
+func appendListJSON(b []byte, v reflect.Value, appendElem func([]byte, reflect.Value) ([]byte, error)) ([]byte, error) {
+	b = append(b, '[')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		var err error
+		if b, err = appendElem(b, v.Index(i)); err != nil {
+			return b, err
+		}
+	}
+	return append(b, ']'), nil
+}
+
+// appendSingleJSON appends a scalar the way convertSingle converts it, with
+// numbers as strings under N
+func appendSingleJSON(b []byte, v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.Bool:
+		return append(b, `{"BOOL":`+strconv.FormatBool(v.Bool())+`}`...)
+	case reflect.String:
+		if v.Type() == jsonNumberType {
+			b = append(b, `{"N":`...)
+			b = appendStringJSON(b, utils.CanonicalNumber(v.String()))
+			return append(b, '}')
+		}
+		b = append(b, `{"S":`...)
+		b = appendStringJSON(b, v.String())
+		return append(b, '}')
+	}
+	var n string
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32:
+		n = strconv.FormatFloat(v.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		n = strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	b = append(b, `{"N":`...)
+	b = appendStringJSON(b, n)
+	return append(b, '}')
+}
+
+// appendStringJSON appends a JSON string. Strings which encoding/json would
+// escape, with HTML characters, control characters or invalid UTF-8, are left
+// to encoding/json so that the output stays the same.
+func appendStringJSON(b []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 0x20 || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' || c >= utf8.RuneSelf {
+			return appendEscapedJSON(b, s)
+		}
+	}
+	b = append(b, '"')
+	b = append(b, s...)
+	return append(b, '"')
+}
+
+func appendEscapedJSON(b []byte, s string) []byte {
+	encoded, _ := json.Marshal(s)
+	return append(b, encoded...)
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/gin-gonic/gin"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+// withEncoder sets the AttributeValueEncoder until the returned func is called
+func withEncoder(encoder string) func() {
+	config.ConfigurationMap.AttributeValueEncoder = encoder
+	return func() { config.ConfigurationMap.AttributeValueEncoder = "" }
+}
+
+// encodeJSON encodes a response like gin does
+func encodeJSON(v interface{}) string {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.JSON(http.StatusOK, v)
+	return w.Body.String()
+}
+
+func TestAttributeValuesJSON(t *testing.T) {
+	tests := []struct {
+		testName string
+		in       interface{}
+	}{
+		{"item", map[string]interface{}{"emp_id": int64(1), "first_name": "Marc", "age": float64(10.5), "verified": true}},
+		{"empty string", map[string]interface{}{"address": ""}},
+		{"escaped strings", map[string]interface{}{"html": "<b>&</b>", "quote": `say "hi"\`, "control": "a\nb\tc\x01", "unicode": "h\u00e9llo \u2028 \u4e16\u754c", "invalid": "\xff"}},
+		{"numbers", map[string]interface{}{"big": json.Number("12345678901234567890"), "exp": json.Number("1.50E+3"), "f32": float32(0.1), "neg": int32(-7), "u": uint16(3)}},
+		{"binary", map[string]interface{}{"b": []byte("bytes"), "empty": []byte{}}},
+		{"nested", map[string]interface{}{"m": map[string]interface{}{"z": "last", "a": []interface{}{"x", float64(2), map[string]interface{}{"k": false}}}, "ss": []string{"b", "a"}}},
+		{"null", map[string]interface{}{"missing": nil}},
+		{"typed map", map[string]string{"b": "2", "a": "1"}},
+		{"empty item", map[string]interface{}{}},
+		{"items", []map[string]interface{}{{"emp_id": int64(1)}, {"emp_id": int64(2), "tags": []interface{}{}}}},
+		{"no items", []map[string]interface{}{}},
+	}
+	for _, tc := range tests {
+		want, err := ChangeMaptoDynamoMap(tc.in)
+		assert.Equal(t, err, nil)
+		restore := withEncoder(directEncoder)
+		got, err := attributeValuesJSON(tc.in)
+		restore()
+		assert.Equal(t, err, nil)
+		assert.Equal(t, encodeJSON(gin.H{"Item": got}), encodeJSON(gin.H{"Item": want}))
+	}
+}
+
+func TestItemListJSON(t *testing.T) {
+	items := []map[string]interface{}{{"emp_id": int64(1), "first_name": "Marc"}, {"emp_id": int64(2), "address": ""}}
+	for _, in := range []interface{}{items, []map[string]interface{}{}, nil} {
+		want, err := itemListJSON(in)
+		assert.Equal(t, err, nil)
+		restore := withEncoder(directEncoder)
+		got, err := itemListJSON(in)
+		restore()
+		assert.Equal(t, err, nil)
+		assert.Equal(t, encodeJSON(gin.H{"Items": got}), encodeJSON(gin.H{"Items": want}))
+	}
+}
+
+func TestDirectEncoderQueryOutput(t *testing.T) {
+	defer withEncoder(directEncoder)()
+	res := map[string]interface{}{
+		"Count": 1,
+		"Items": []map[string]interface{}{
+			{"last_name": "Lamberti", "emp_id": float64(1), "verified": true, "address": "Shamli", "age": float64(10)},
+		},
+		"LastEvaluatedKey": map[string]interface{}{"emp_id": float64(1)},
+	}
+	output, err := queryOutput(res, false)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, encodeJSON(output), `{"Count":1,"Items":{"L":[{"address":{"S":"Shamli"},"age":{"N":"10"},"emp_id":{"N":"1"},"last_name":{"S":"Lamberti"},"verified":{"BOOL":true}}]},"LastEvaluatedKey":{"emp_id":{"N":"1"}}}`)
+
+	_, err = attributeValuesJSON(map[string]interface{}{"": "empty name"})
+	assert.NotEqual(t, err, nil)
+}
+
+// benchmarkItems are the Items of a page of 100 items with nested attributes
+func benchmarkItems() []map[string]interface{} {
+	items := make([]map[string]interface{}, 100)
+	for i := range items {
+		items[i] = map[string]interface{}{
+			"emp_id":     int64(i),
+			"first_name": "Marc",
+			"last_name":  "Richards",
+			"address":    "Shamli",
+			"age":        float64(30 + i%40),
+			"verified":   i%2 == 0,
+			"tags":       []interface{}{"a", "b", "c"},
+			"profile":    map[string]interface{}{"team": "core", "level": int64(i % 5), "id": strconv.Itoa(i)},
+		}
+	}
+	return items
+}
+
+func BenchmarkAttributeValuesJSON(b *testing.B) {
+	items := benchmarkItems()
+	for _, encoder := range []string{"", directEncoder} {
+		name := encoder
+		if name == "" {
+			name = "json"
+		}
+		b.Run(name, func(b *testing.B) {
+			defer withEncoder(encoder)()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				output, err := itemListJSON(items)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := json.Marshal(gin.H{"Items": output}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -31,10 +31,12 @@ import (
 	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/service/services"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/utils"
 )
 
 var operations = []string{" SET ", " DELETE ", " ADD ", " REMOVE "}
 var byteSliceType = reflect.TypeOf([]byte(nil))
+var jsonNumberType = reflect.TypeOf(json.Number(""))
 
 func between(value string, a string, b string) string {
 	// Get substring between two strings.
@@ -238,7 +240,7 @@ func performOperation(ctx context.Context, action string, actionValue string, up
 	case action == "ADD":
 		// Add data in table
 		m, expr := parseActionValue(actionValue, updateAtrr, true)
-		res, err := services.Add(ctx, updateAtrr.TableName, updateAtrr.PrimaryKeyMap, updateAtrr.ConditionExpression, m, updateAtrr.ExpressionAttributeMap, expr, oldRes)
+		res, err := services.Add(ctx, updateAtrr.TableName, updateAtrr.PrimaryKeyMap, updateAtrr.ConditionExpression, m, updateAtrr.ExpressionAttributeMap, expr)
 		return res, m, err
 
 	case action == "REMOVE":
@@ -249,8 +251,9 @@ func performOperation(ctx context.Context, action string, actionValue string, up
 	return nil, nil, nil
 }
 
-// UpdateExpression performs an expression
-func UpdateExpression(ctx context.Context, updateAtrr models.UpdateAttr) (interface{}, error) {
+// UpdateExpression performs an expression and returns the attributes of its
+// ReturnValues with the original column names, nil when there are none
+func UpdateExpression(ctx context.Context, updateAtrr models.UpdateAttr) (map[string]interface{}, error) {
 	updateAtrr.ExpressionAttributeNames = ChangeColumnToSpannerExpressionName(updateAtrr.TableName, updateAtrr.ExpressionAttributeNames)
 	var oldRes map[string]interface{}
 	if updateAtrr.ReturnValues != "NONE" {
@@ -259,58 +262,68 @@ func UpdateExpression(ctx context.Context, updateAtrr models.UpdateAttr) (interf
 	var resp map[string]interface{}
 	var actVal = make(map[string]interface{})
 	var er error
+	updateAtrr.UpdateExpression = utils.NormalizeKeywords(updateAtrr.UpdateExpression)
+	updateAtrr.ConditionExpression = utils.NormalizeKeywords(updateAtrr.ConditionExpression)
 	for k, v := range updateAtrr.ExpressionAttributeNames {
 		updateAtrr.UpdateExpression = strings.ReplaceAll(updateAtrr.UpdateExpression, k, v)
 		updateAtrr.ConditionExpression = strings.ReplaceAll(updateAtrr.ConditionExpression, k, v)
 	}
 	m := extractOperations(updateAtrr.UpdateExpression)
-	for k, v := range m {
-		res, acVal, err := performOperation(ctx, k, v, updateAtrr, oldRes)
-		resp = res
-		er = err
-		for k, v := range acVal {
-			actVal[k] = v
+	if len(m) > 1 {
+		actions, acVal, err := parseUpdateActions(updateAtrr, m)
+		if err != nil {
+			return nil, err
+		}
+		resp, er = services.Update(ctx, updateAtrr.TableName, updateAtrr.PrimaryKeyMap, updateAtrr.ConditionExpression, updateAtrr.ExpressionAttributeMap, actions)
+		actVal = acVal
+	} else {
+		for k, v := range m {
+			res, acVal, err := performOperation(ctx, k, v, updateAtrr, oldRes)
+			resp = res
+			er = err
+			for k, v := range acVal {
+				actVal[k] = v
+			}
 		}
 	}
 	if er == nil {
-		go services.StreamDataToThirdParty(oldRes, resp, updateAtrr.TableName)
+		services.StreamDataToThirdParty(oldRes, resp, updateAtrr.TableName)
 	} else {
 		return nil, er
 	}
 	logger.LogDebug(updateAtrr.ReturnValues, resp, oldRes)
 
-	var output map[string]interface{}
-	var errOutput error
 	switch updateAtrr.ReturnValues {
 	case "NONE":
-		return nil, er
+		return nil, nil
 	case "ALL_NEW":
-		output, errOutput = ChangeMaptoDynamoMap(ChangeResponseToOriginalColumns(updateAtrr.TableName, resp))
+		return ChangeResponseToOriginalColumns(updateAtrr.TableName, resp), nil
 	case "ALL_OLD":
 		if oldRes == nil || len(oldRes) == 0 {
-			return nil, er
+			return nil, nil
 		}
-		output, errOutput = ChangeMaptoDynamoMap(ChangeResponseToOriginalColumns(updateAtrr.TableName, oldRes))
+		return ChangeResponseToOriginalColumns(updateAtrr.TableName, oldRes), nil
 	case "UPDATED_NEW":
 		var resVal = make(map[string]interface{})
 		for k := range actVal {
-			resVal[k] = resp[k]
+			if v, ok := resp[k]; ok {
+				resVal[k] = v
+			}
 		}
-		output, errOutput = ChangeMaptoDynamoMap(ChangeResponseToOriginalColumns(updateAtrr.TableName, resVal))
+		return ChangeResponseToOriginalColumns(updateAtrr.TableName, resVal), nil
 	case "UPDATED_OLD":
 		if oldRes == nil || len(oldRes) == 0 {
-			return nil, er
+			return nil, nil
 		}
 		var resVal = make(map[string]interface{})
 		for k := range actVal {
-			resVal[k] = oldRes[k]
+			if v, ok := oldRes[k]; ok {
+				resVal[k] = v
+			}
 		}
-		output, errOutput = ChangeMaptoDynamoMap(ChangeResponseToOriginalColumns(updateAtrr.TableName, resVal))
-
-	default:
-		output, errOutput = ChangeMaptoDynamoMap(ChangeResponseToOriginalColumns(updateAtrr.TableName, resp))
+		return ChangeResponseToOriginalColumns(updateAtrr.TableName, resVal), nil
 	}
-	return map[string]interface{}{"Attributes": output}, errOutput
+	return ChangeResponseToOriginalColumns(updateAtrr.TableName, resp), nil
 }
 
 func extractOperations(updateExpression string) map[string]string {
@@ -338,8 +351,63 @@ func extractOperations(updateExpression string) map[string]string {
 	return ops
 }
 
+// parseUpdateActions parses the clauses of an update expression in the order
+// they appear. It returns the actions along with the attributes they update.
+// Like DynamoDB, an attribute can only be updated by one of the clauses.
+func parseUpdateActions(updateAtrr models.UpdateAttr, ops map[string]string) ([]models.UpdateAction, map[string]interface{}, error) {
+	actionOrder := make([]string, 0, len(ops))
+	for k := range ops {
+		actionOrder = append(actionOrder, k)
+	}
+	updateExpression := " " + strings.TrimSpace(updateAtrr.UpdateExpression) + " "
+	sort.Slice(actionOrder, func(i, j int) bool {
+		return strings.Index(updateExpression, " "+actionOrder[i]+" ") < strings.Index(updateExpression, " "+actionOrder[j]+" ")
+	})
+
+	actions := make([]models.UpdateAction, 0, len(actionOrder))
+	actVal := map[string]interface{}{}
+	paths := map[string]bool{}
+	for _, action := range actionOrder {
+		updateAction := models.UpdateAction{Action: action}
+		switch action {
+		case "SET":
+			updateAction.Attributes, updateAction.Expr = parseActionValue(ops[action], updateAtrr, false)
+		case "ADD", "DELETE":
+			updateAction.Attributes, updateAction.Expr = parseActionValue(ops[action], updateAtrr, true)
+		case "REMOVE":
+			updateAction.ColsToRemove = strings.Split(strings.ReplaceAll(ops[action], " ", ""), ",")
+			updateAction.Attributes = map[string]interface{}{}
+			for k, v := range updateAtrr.PrimaryKeyMap {
+				updateAction.Attributes[k] = v
+			}
+		}
+		updated := []string{}
+		for k, v := range updateAction.Attributes {
+			if _, ok := updateAtrr.PrimaryKeyMap[k]; ok {
+				continue
+			}
+			updated = append(updated, k)
+			actVal[k] = v
+		}
+		for _, col := range updateAction.ColsToRemove {
+			updated = append(updated, col)
+			actVal[col] = nil
+		}
+		for _, path := range updated {
+			if paths[path] {
+				return nil, nil, errors.New("ValidationException", "Invalid UpdateExpression: Two document paths overlap with each other; must remove or rewrite one of these paths; path one: ["+path+"], path two: ["+path+"]")
+			}
+			paths[path] = true
+		}
+		actions = append(actions, updateAction)
+	}
+	return actions, actVal, nil
+}
+
 // ReplaceHashRangeExpr replaces the attribute names from Filter Expression and Range Expression
 func ReplaceHashRangeExpr(query models.Query) models.Query {
+	query.FilterExp = utils.NormalizeKeywords(query.FilterExp)
+	query.RangeExp = utils.NormalizeKeywords(query.RangeExp)
 	for k, v := range query.ExpressionAttributeNames {
 		query.FilterExp = strings.ReplaceAll(query.FilterExp, k, v)
 		query.RangeExp = strings.ReplaceAll(query.RangeExp, k, v)
@@ -404,6 +472,15 @@ func ChangeColumnToSpannerExpressionName(tableName string, expressNameMap map[st
 	return rs
 }
 
+// applyAttributeNames replaces the ExpressionAttributeNames placeholders of
+// the expression with the Spanner columns of the attributes they name
+func applyAttributeNames(tableName, expression string, names map[string]string) string {
+	for k, v := range ChangeColumnToSpannerExpressionName(tableName, names) {
+		expression = strings.ReplaceAll(expression, k, v)
+	}
+	return expression
+}
+
 // ChangesArrayResponseToOriginalColumns changes the spanner column names to original column names
 func ChangesArrayResponseToOriginalColumns(tableName string, obj []map[string]interface{}) []map[string]interface{} {
 	_, ok := models.TableColChangeMap[tableName]
@@ -474,6 +551,17 @@ func ChangeColumnToSpanner(obj map[string]interface{}) map[string]interface{} {
 	return rs
 }
 
+// convertNested converts the value inside a Map or List attribute. Numbers
+// which float64 cannot hold are kept as json.Number so that they reach a JSON
+// column with all their digits.
+func convertNested(a *dynamodb.AttributeValue, tableName string) interface{} {
+	v := convertFrom(a, tableName)
+	if a.N != nil {
+		return utils.PreciseNumber(*a.N)
+	}
+	return v
+}
+
 func convertFrom(a *dynamodb.AttributeValue, tableName string) interface{} {
 	if a.S != nil {
 		return *a.S
@@ -509,7 +597,7 @@ func convertFrom(a *dynamodb.AttributeValue, tableName string) interface{} {
 	if a.M != nil {
 		m := make(map[string]interface{})
 		for k, v := range a.M {
-			m[k] = convertFrom(v, tableName)
+			m[k] = convertNested(v, tableName)
 		}
 		return m
 	}
@@ -517,7 +605,7 @@ func convertFrom(a *dynamodb.AttributeValue, tableName string) interface{} {
 	if a.L != nil {
 		l := make([]interface{}, len(a.L))
 		for index, v := range a.L {
-			l[index] = convertFrom(v, tableName)
+			l[index] = convertNested(v, tableName)
 		}
 		return l
 	}
@@ -654,7 +742,7 @@ func convertMapToDynamoObject(output map[string]interface{}, v reflect.Value) er
 	case reflect.Map:
 		return convertMap(output, v)
 	case reflect.Slice, reflect.Array:
-		return convertSlice(output, v)
+		return convertSlice(output, v, convertMapToDynamoObject)
 	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
 		// unsupported
 	default:
@@ -664,6 +752,23 @@ func convertMapToDynamoObject(output map[string]interface{}, v reflect.Value) er
 	return nil
 }
 
+// convertAttribute converts the value of an attribute, or of an element of a
+// Map or List attribute, wrapping maps in M. The items of a list at the top
+// level, like the Items of a query, stay unwrapped.
+func convertAttribute(output map[string]interface{}, v reflect.Value) error {
+	v = valueElem(v)
+	switch v.Kind() {
+	case reflect.Map:
+		m := make(map[string]interface{})
+		err := convertMap(m, v)
+		output["M"] = m
+		return err
+	case reflect.Slice, reflect.Array:
+		return convertSlice(output, v, convertAttribute)
+	}
+	return convertMapToDynamoObject(output, v)
+}
+
 func valueElem(v reflect.Value) reflect.Value {
 	switch v.Kind() {
 	case reflect.Interface, reflect.Ptr:
@@ -684,14 +789,14 @@ func convertMap(output map[string]interface{}, v reflect.Value) error {
 
 		elemVal := v.MapIndex(key)
 		elem := make(map[string]interface{})
-		_ = convertMapToDynamoObject(elem, elemVal)
+		_ = convertAttribute(elem, elemVal)
 
 		output[keyName] = elem
 	}
 	return nil
 }
 
-func convertSlice(output map[string]interface{}, v reflect.Value) error {
+func convertSlice(output map[string]interface{}, v reflect.Value, convertElem func(map[string]interface{}, reflect.Value) error) error {
 	if v.Kind() == reflect.Array && v.Len() == 0 {
 		return nil
 	}
@@ -712,7 +817,7 @@ func convertSlice(output map[string]interface{}, v reflect.Value) error {
 		count := 0
 		for i := 0; i < v.Len(); i++ {
 			elem := make(map[string]interface{})
-			err := convertMapToDynamoObject(elem, v.Index(i))
+			err := convertElem(elem, v.Index(i))
 			if err != nil {
 				return err
 			}
@@ -732,6 +837,10 @@ func convertSingle(output map[string]interface{}, v reflect.Value) error {
 		output["BOOL"] = new(bool)
 		output["BOOL"] = v.Bool()
 	case reflect.String:
+		if v.Type() == jsonNumberType {
+			output["N"] = utils.CanonicalNumber(v.String())
+			break
+		}
 		s := v.String()
 		output["S"] = s
 	default:
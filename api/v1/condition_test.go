@@ -15,6 +15,7 @@
 package v1
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -231,6 +232,84 @@ func Test_extractOperations(t *testing.T) {
 	}
 }
 
+func Test_parseUpdateActions(t *testing.T) {
+	attrMap := map[string]interface{}{":a": "value", ":n": float64(2)}
+	keyMap := map[string]interface{}{"id": "1"}
+	tests := []struct {
+		testName         string
+		updateExpression string
+		want             []models.UpdateAction
+		wantActVal       map[string]interface{}
+		wantErr          bool
+	}{
+		{
+			"SET, REMOVE and ADD in one expression",
+			"SET a = :a REMOVE b ADD c :n",
+			[]models.UpdateAction{
+				{
+					Action:     "SET",
+					Attributes: map[string]interface{}{"id": "1", "a": "value"},
+					Expr:       &models.UpdateExpressionCondition{ActionVal: "a = :a", AddValues: map[string]float64{}},
+				},
+				{
+					Action:       "REMOVE",
+					Attributes:   map[string]interface{}{"id": "1"},
+					ColsToRemove: []string{"b"},
+				},
+				{
+					Action:     "ADD",
+					Attributes: map[string]interface{}{"id": "1", "c": float64(2)},
+					Expr:       &models.UpdateExpressionCondition{ActionVal: "c :n", AddValues: map[string]float64{}},
+				},
+			},
+			map[string]interface{}{"a": "value", "b": nil, "c": float64(2)},
+			false,
+		},
+		{
+			"clauses in a different order",
+			"ADD c :n REMOVE b SET a = :a",
+			[]models.UpdateAction{
+				{
+					Action:     "ADD",
+					Attributes: map[string]interface{}{"id": "1", "c": float64(2)},
+					Expr:       &models.UpdateExpressionCondition{ActionVal: "c :n", AddValues: map[string]float64{}},
+				},
+				{
+					Action:       "REMOVE",
+					Attributes:   map[string]interface{}{"id": "1"},
+					ColsToRemove: []string{"b"},
+				},
+				{
+					Action:     "SET",
+					Attributes: map[string]interface{}{"id": "1", "a": "value"},
+					Expr:       &models.UpdateExpressionCondition{ActionVal: "a = :a", AddValues: map[string]float64{}},
+				},
+			},
+			map[string]interface{}{"a": "value", "b": nil, "c": float64(2)},
+			false,
+		},
+		{
+			"overlapping paths",
+			"SET a = :a REMOVE a",
+			nil,
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range tests {
+		updateAtrr := models.UpdateAttr{
+			UpdateExpression:       tc.updateExpression,
+			ExpressionAttributeMap: attrMap,
+			PrimaryKeyMap:          keyMap,
+		}
+		got, actVal, err := parseUpdateActions(updateAtrr, extractOperations(tc.updateExpression))
+		assert.Equal(t, err != nil, tc.wantErr)
+		assert.Equal(t, got, tc.want)
+		assert.Equal(t, actVal, tc.wantActVal)
+	}
+}
+
 func TestReplaceHashRangeExpr(t *testing.T) {
 	tests := []struct {
 		testName string
@@ -274,6 +353,25 @@ func TestReplaceHashRangeExpr(t *testing.T) {
 				FilterExp: "age > :val2",
 			},
 		},
+		{
+			"mixed case keywords",
+			models.Query{
+				ExpressionAttributeNames: map[string]string{
+					"#e": "emp_id",
+					"#n": "Contains",
+				},
+				RangeExp:  "#e = :val1 and Begins_With(#n, :val2)",
+				FilterExp: "age between :val3 And :val4",
+			},
+			models.Query{
+				ExpressionAttributeNames: map[string]string{
+					"#e": "emp_id",
+					"#n": "Contains",
+				},
+				RangeExp:  "emp_id = :val1 AND begins_with(Contains, :val2)",
+				FilterExp: "age BETWEEN :val3 AND :val4",
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -329,6 +427,19 @@ func TestConvertDynamoToMap(t *testing.T) {
 				"subjects":   []interface{}{"Maths", "Physics", "Chemistry"},
 			},
 		},
+		{
+			"dynamodbObject with BOOL present",
+			map[string]*dynamodb.AttributeValue{
+				"id":                {S: aws.String("n1")},
+				"notification_read": {BOOL: aws.Bool(true)},
+				"archived":          {BOOL: aws.Bool(false)},
+			},
+			map[string]interface{}{
+				"id":                "n1",
+				"notification_read": true,
+				"archived":          false,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -337,6 +448,42 @@ func TestConvertDynamoToMap(t *testing.T) {
 	}
 }
 
+func TestSanitizedAttributeNames(t *testing.T) {
+	models.TableColChangeMap["orders"] = struct{}{}
+	sanitized := map[string]string{"customer.name": "customer_name", "items[0]": "items_0_"}
+	for original, col := range sanitized {
+		models.ColumnToOriginalCol[original] = col
+		models.OriginalColResponse[col] = original
+	}
+	defer func() {
+		delete(models.TableColChangeMap, "orders")
+		for original, col := range sanitized {
+			delete(models.ColumnToOriginalCol, original)
+			delete(models.OriginalColResponse, col)
+		}
+	}()
+
+	item, err := ConvertDynamoToMap("orders", map[string]*dynamodb.AttributeValue{
+		"id":            {S: aws.String("o1")},
+		"customer.name": {S: aws.String("Marc")},
+		"items[0]":      {N: aws.String("2")},
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, item, map[string]interface{}{"id": "o1", "customer_name": "Marc", "items_0_": float64(2)})
+
+	expression := applyAttributeNames("orders", "attribute_exists(#c) AND #i > :v", map[string]string{"#c": "customer.name", "#i": "items[0]"})
+	assert.Equal(t, expression, "attribute_exists(customer_name) AND items_0_ > :v")
+
+	assert.Equal(t, ChangeResponseToOriginalColumns("orders", item), map[string]interface{}{"id": "o1", "customer.name": "Marc", "items[0]": float64(2)})
+
+	res := ChangeQueryResponseColumn("orders", map[string]interface{}{
+		"Items":            []map[string]interface{}{item},
+		"LastEvaluatedKey": map[string]interface{}{"id": "o1", "customer_name": "Marc"},
+	})
+	assert.Equal(t, res["Items"], []map[string]interface{}{{"id": "o1", "customer.name": "Marc", "items[0]": float64(2)}})
+	assert.Equal(t, res["LastEvaluatedKey"], map[string]interface{}{"id": "o1", "customer.name": "Marc"})
+}
+
 func TestChangeMaptoDynamoMap(t *testing.T) {
 	tests := []struct {
 		testName string
@@ -382,6 +529,38 @@ func TestChangeMaptoDynamoMap(t *testing.T) {
 				},
 			},
 		},
+		{
+			"BOOL values for input",
+			map[string]interface{}{
+				"id":                "n1",
+				"notification_read": true,
+				"archived":          false,
+			},
+			map[string]interface{}{
+				"id":                map[string]interface{}{"S": "n1"},
+				"notification_read": map[string]interface{}{"BOOL": true},
+				"archived":          map[string]interface{}{"BOOL": false},
+			},
+		},
+		{
+			"Nested map values for input",
+			map[string]interface{}{
+				"id": "u1",
+				"profile": map[string]interface{}{
+					"address": map[string]interface{}{"city": "London"},
+					"phones":  []interface{}{map[string]interface{}{"home": "123"}},
+				},
+			},
+			map[string]interface{}{
+				"id": map[string]interface{}{"S": "u1"},
+				"profile": map[string]interface{}{"M": map[string]interface{}{
+					"address": map[string]interface{}{"M": map[string]interface{}{"city": map[string]interface{}{"S": "London"}}},
+					"phones": map[string]interface{}{"L": []map[string]interface{}{
+						{"M": map[string]interface{}{"home": map[string]interface{}{"S": "123"}}},
+					}},
+				}},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -389,3 +568,46 @@ func TestChangeMaptoDynamoMap(t *testing.T) {
 		assert.Equal(t, got, tc.want)
 	}
 }
+
+func TestNumberFormatting(t *testing.T) {
+	got, err := ChangeMaptoDynamoMap(map[string]interface{}{
+		"age":     float64(10),
+		"salary":  2.5,
+		"rating":  float32(4),
+		"balance": json.Number("12345678901234567890.50"),
+		"scores":  []float64{10, 0.25},
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, got, map[string]interface{}{
+		"age":     map[string]interface{}{"N": "10"},
+		"salary":  map[string]interface{}{"N": "2.5"},
+		"rating":  map[string]interface{}{"N": "4"},
+		"balance": map[string]interface{}{"N": "12345678901234567890.5"},
+		"scores":  map[string]interface{}{"L": []map[string]interface{}{{"N": "10"}, {"N": "0.25"}}},
+	})
+}
+
+func TestNestedNumberRoundTrip(t *testing.T) {
+	profile := &dynamodb.AttributeValue{M: map[string]*dynamodb.AttributeValue{
+		"id":     {N: aws.String("9007199254740993")},
+		"score":  {N: aws.String("1.5")},
+		"counts": {L: []*dynamodb.AttributeValue{{N: aws.String("12345678901234567890")}, {N: aws.String("2")}}},
+	}}
+	value := convertFrom(profile, "users")
+	assert.Equal(t, value, map[string]interface{}{
+		"id":     json.Number("9007199254740993"),
+		"score":  1.5,
+		"counts": []interface{}{json.Number("12345678901234567890"), float64(2)},
+	})
+
+	got, err := ChangeMaptoDynamoMap(map[string]interface{}{"profile": value})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, got, map[string]interface{}{"profile": map[string]interface{}{"M": map[string]interface{}{
+		"id":    map[string]interface{}{"N": "9007199254740993"},
+		"score": map[string]interface{}{"N": "1.5"},
+		"counts": map[string]interface{}{"L": []map[string]interface{}{
+			{"N": "12345678901234567890"},
+			{"N": "2"},
+		}},
+	}}})
+}
@@ -17,8 +17,11 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -28,27 +31,45 @@ import (
 	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/service/services"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/opentracing/opentracing-go"
 )
 
+// notModifiedHeader is set on GetItem responses skipped by IfVersionNotEqual
+const notModifiedHeader = "X-Dynamodb-Adapter-Not-Modified"
+
+// maxBatchQueries bounds the number of queries of a BatchQuery request
+const maxBatchQueries = 100
+
+// maxConditionalDeletes bounds the number of keys of a BatchConditionalDelete
+// request
+const maxConditionalDeletes = 1000
+
+// ErrNotAllowed is returned by the operations on tables MayIReadOrWrite does
+// not allow, which are answered with an empty response
+var ErrNotAllowed = &errors.Error{ErrorCode: "NotAllowed", ErrorMessage: "the operation is not allowed on the table"}
+
 // InitDBAPI - routes for apis
 func InitDBAPI(g *gin.RouterGroup) {
 
 	r := g.Group("/")
-	r.POST("/GetItem", GetItemMeta)
-	r.POST("/BatchGetItem", BatchGetItem)
+	r.POST("/GetItem", ReadTimestamp, GetItemMeta)
+	r.POST("/BatchGetItem", ReadTimestamp, BatchGetItem)
 
-	r.POST("/Query", QueryTable)
+	r.POST("/Query", ReadTimestamp, QueryTable)
+	r.POST("/BatchQuery", ReadTimestamp, BatchQuery)
+	r.POST("/QueryStream", ReadTimestamp, QueryStream)
 
-	r.POST("/PutItem", UpdateMeta)
-	r.POST("/DeleteItem", DeleteItem)
+	r.POST("/PutItem", RejectWritesWhenReadOnly, UpdateMeta)
+	r.POST("/DeleteItem", RejectWritesWhenReadOnly, DeleteItem)
+	r.POST("/BatchConditionalDelete", RejectWritesWhenReadOnly, BatchConditionalDelete)
 
-	r.POST("/Scan", Scan)
+	r.POST("/Scan", ReadTimestamp, Scan)
 
-	r.POST("/UpdateItem", Update)
+	r.POST("/UpdateItem", RejectWritesWhenReadOnly, Update)
 
-	r.POST("/BatchWriteItem", BatchWriteItem)
+	r.POST("/BatchWriteItem", RejectWritesWhenReadOnly, BatchWriteItem)
 
 }
 
@@ -58,6 +79,76 @@ func enrichSpan(c *gin.Context, span opentracing.Span, query models.Query) opent
 	return span
 }
 
+// flatItemsResponse checks if Items should be returned as a plain array, the
+// way DynamoDB does, instead of the {"L":[...]} wrapper used by /v1 clients
+func flatItemsResponse(c *gin.Context) bool {
+	if c.GetHeader("X-Amz-Target") != "" {
+		return true
+	}
+	return config.ConfigurationMap.FlatItems
+}
+
+// isCountSelect checks for Select COUNT, which DynamoDB matches case-insensitively
+func isCountSelect(selectValue string) bool {
+	return strings.EqualFold(selectValue, "COUNT")
+}
+
+// consumedCapacity returns the ConsumedCapacity of a batch from the capacity
+// units of every table, or nil unless ReturnConsumedCapacity is TOTAL or
+// INDEXES. Batches only touch the tables, so INDEXES adds their Table units.
+func consumedCapacity(returnConsumedCapacity string, units map[string]float64) []gin.H {
+	if returnConsumedCapacity != "TOTAL" && returnConsumedCapacity != "INDEXES" {
+		return nil
+	}
+	tables := make([]string, 0, len(units))
+	for table := range units {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	capacity := make([]gin.H, 0, len(tables))
+	for _, table := range tables {
+		tableCapacity := gin.H{"TableName": table, "CapacityUnits": units[table]}
+		if returnConsumedCapacity == "INDEXES" {
+			tableCapacity["Table"] = gin.H{"CapacityUnits": units[table]}
+		}
+		capacity = append(capacity, tableCapacity)
+	}
+	return capacity
+}
+
+// throttled answers a ProvisionedThroughputExceededException when the read or
+// write capacity of the table is used up and reports if it did
+func throttled(c *gin.Context, table string, write bool, request interface{}) bool {
+	if err := services.CheckCapacity(table, write); err != nil {
+		c.JSON(errors.HTTPResponse(err, request))
+		return true
+	}
+	return false
+}
+
+// resultSize returns the size of the Items of a Query or Scan result
+func resultSize(res map[string]interface{}) int {
+	items, _ := res["Items"].([]map[string]interface{})
+	size := 0
+	for _, item := range items {
+		size += services.ItemSize(item)
+	}
+	return size
+}
+
+// updateCapacityUnits estimates the write units of an update from its key
+// and values, since the updated item is not read back
+func updateCapacityUnits(updateAttr models.UpdateAttr) float64 {
+	item := map[string]interface{}{}
+	for k, v := range updateAttr.PrimaryKeyMap {
+		item[k] = v
+	}
+	for k, v := range updateAttr.ExpressionAttributeMap {
+		item[k] = v
+	}
+	return services.WriteCapacityUnits([]map[string]interface{}{item})
+}
+
 func addParentSpanID(c *gin.Context, span opentracing.Span) opentracing.Span {
 	parentSpanID := c.Request.Header.Get("X-B3-Spanid")
 	traceID := c.Request.Header.Get("X-B3-Traceid")
@@ -94,36 +185,16 @@ func UpdateMeta(c *gin.Context) {
 	if err := c.ShouldBindJSON(&meta); err != nil {
 		c.JSON(errors.New("ValidationException", err).HTTPResponse(meta))
 	} else {
-		if allow := services.MayIReadOrWrite(meta.TableName, true, "UpdateMeta"); !allow {
+		res, err := DoPutItem(c.Request.Context(), meta)
+		if err == ErrNotAllowed {
 			c.JSON(http.StatusOK, gin.H{})
-			return
-		}
-		logger.LogDebug(meta)
-		meta.AttrMap, err = ConvertDynamoToMap(meta.TableName, meta.Item)
-		if err != nil {
-			c.JSON(errors.New("ValidationException", err).HTTPResponse(meta))
-			return
-		}
-		meta.ExpressionAttributeMap, err = ConvertDynamoToMap(meta.TableName, meta.ExpressionAttributeValues)
-		if err != nil {
-			c.JSON(errors.New("ValidationException", err).HTTPResponse(meta))
-			return
-		}
-
-		for k, v := range meta.ExpressionAttributeNames {
-			meta.ConditionExpression = strings.ReplaceAll(meta.ConditionExpression, k, v)
-		}
-
-		res, err := put(c.Request.Context(), meta.TableName, meta.AttrMap, nil, meta.ConditionExpression, meta.ExpressionAttributeMap)
-		if err != nil {
+		} else if err != nil {
 			c.JSON(errors.HTTPResponse(err, meta))
 		} else {
 			var output map[string]interface{}
-			if meta.ReturnValues == "NONE" {
-				output = nil
-			} else {
-				output, _ = ChangeMaptoDynamoMap(ChangeResponseToOriginalColumns(meta.TableName, res))
-				output = map[string]interface{}{"Attributes": output}
+			if meta.ReturnValues != "NONE" {
+				attributes, _ := attributeValuesJSON(res)
+				output = map[string]interface{}{"Attributes": attributes}
 			}
 
 			c.JSON(http.StatusOK, output)
@@ -131,24 +202,70 @@ func UpdateMeta(c *gin.Context) {
 	}
 }
 
-func put(ctx context.Context, tableName string, putObj map[string]interface{}, expr *models.UpdateExpressionCondition, conditionExp string, expressionAttr map[string]interface{}) (map[string]interface{}, error) {
-	tableConf, err := config.GetTableConf(tableName)
+// DoPutItem writes the item of a PutItem request and returns the item it
+// replaced, nil when ReturnValues is NONE
+func DoPutItem(ctx context.Context, meta models.Meta) (map[string]interface{}, error) {
+	if allow := services.MayIReadOrWrite(meta.TableName, true, "UpdateMeta"); !allow {
+		return nil, ErrNotAllowed
+	}
+	if err := services.CheckCapacity(meta.TableName, true); err != nil {
+		return nil, err
+	}
+	if err := validateExpressionAttributes(meta.ExpressionAttributeNames, meta.ExpressionAttributeValues, meta.ConditionExpression); err != nil {
+		return nil, err
+	}
+	if meta.ReturnValues != "" && meta.ReturnValues != "NONE" && meta.ReturnValues != "ALL_OLD" {
+		return nil, errors.New("ValidationException", "Return values set to invalid value").WithParameter("ReturnValues")
+	}
+	logger.LogDebug(meta)
+	var err error
+	var versionAttr string
+	var version int64
+	if meta.ExpectedVersion != nil {
+		versionAttr, version, err = expectedVersion(meta.TableName, meta.ExpectedVersion)
+		if err != nil {
+			return nil, err
+		}
+		meta.Item = setNextVersion(meta.Item, versionAttr, version)
+	}
+	meta.AttrMap, err = ConvertDynamoToMap(meta.TableName, meta.Item)
+	if err != nil {
+		return nil, errors.New("ValidationException", err).WithParameter("Item")
+	}
+	meta.ExpressionAttributeMap, err = ConvertDynamoToMap(meta.TableName, meta.ExpressionAttributeValues)
+	if err != nil {
+		return nil, errors.New("ValidationException", err).WithParameter("ExpressionAttributeValues")
+	}
+
+	meta.ConditionExpression, meta.ExpressionAttributeMap, err = applyLegacyExpected(meta.TableName, meta.Expected, meta.ConditionalOperator, meta.ConditionExpression, meta.ExpressionAttributeMap)
 	if err != nil {
 		return nil, err
 	}
-	sKey := tableConf.SortKey
-	pKey := tableConf.PartitionKey
-	var oldResp = map[string]interface{}{}
+	if meta.ExpectedVersion != nil {
+		meta.ConditionExpression, meta.ExpressionAttributeNames, meta.ExpressionAttributeMap, err = applyExpectedVersion(versionAttr, version, meta.ConditionExpression, meta.ExpressionAttributeNames, meta.ExpressionAttributeMap)
+		if err != nil {
+			return nil, err
+		}
+	}
+	meta.ConditionExpression = applyAttributeNames(meta.TableName, utils.NormalizeKeywords(meta.ConditionExpression), meta.ExpressionAttributeNames)
 
-	oldResp, err = storage.GetStorageInstance().SpannerGet(ctx, tableName, putObj[pKey], putObj[sKey], nil)
+	res, err := put(ctx, meta.TableName, meta.AttrMap, meta.ConditionExpression, meta.ExpressionAttributeMap)
 	if err != nil {
 		return nil, err
 	}
-	res, err := services.Put(ctx, tableName, putObj, nil, conditionExp, expressionAttr, oldResp)
+	services.ConsumeCapacity(meta.TableName, true, services.WriteCapacityUnits([]map[string]interface{}{meta.AttrMap}))
+	if meta.ReturnValues == "NONE" {
+		return nil, nil
+	}
+	return ChangeResponseToOriginalColumns(meta.TableName, res), nil
+}
+
+func put(ctx context.Context, tableName string, putObj map[string]interface{}, conditionExp string, expressionAttr map[string]interface{}) (map[string]interface{}, error) {
+	oldResp, res, err := services.PutItem(ctx, tableName, putObj, conditionExp, expressionAttr)
 	if err != nil {
 		return nil, err
 	}
-	go services.StreamDataToThirdParty(oldResp, res, tableName)
+	services.StreamDataToThirdParty(oldResp, res, tableName)
 	return oldResp, nil
 }
 
@@ -158,25 +275,79 @@ func queryResponse(query models.Query, c *gin.Context) {
 	span, ctx := opentracing.StartSpanFromContext(c.Request.Context(), c.Request.URL.RequestURI())
 	c.Request = c.Request.WithContext(ctx)
 	defer span.Finish()
-	var err1 error
-	if allow := services.MayIReadOrWrite(query.TableName, false, ""); !allow {
+	res, err := DoQuery(c.Request.Context(), query)
+	if err == ErrNotAllowed {
 		c.JSON(http.StatusOK, gin.H{})
 		return
 	}
+	if err == nil {
+		changedOutput, err := queryOutput(res, flatItemsResponse(c))
+		if err != nil {
+			c.JSON(errors.HTTPResponse(err, query))
+		} else {
+			c.JSON(http.StatusOK, changedOutput)
+		}
+	} else {
+		c.JSON(errors.HTTPResponse(err, query))
+	}
+}
 
-	if query.Select == "COUNT" {
-		query.OnlyCount = true
+// consistentReadContext returns ctx with strongly consistent reads when a
+// read of the table with the ConsistentRead of the request has to be, see
+// services.IsConsistentRead
+func consistentReadContext(ctx context.Context, tableName string, consistentRead *bool) context.Context {
+	if services.IsConsistentRead(tableName, consistentRead) {
+		return storage.WithConsistentReads(ctx)
 	}
+	return ctx
+}
 
-	query.StartFrom, err1 = ConvertDynamoToMap(query.TableName, query.ExclusiveStartKey)
-	if err1 != nil {
-		c.JSON(errors.New("ValidationException", err1).HTTPResponse(query))
-		return
+// DoQuery runs a Query request and returns its Items, Count and
+// LastEvaluatedKey with the original column names
+func DoQuery(ctx context.Context, query models.Query) (map[string]interface{}, error) {
+	if err := validateExpressionAttributes(query.ExpressionAttributeNames, query.ExpressionAttributeValues, query.RangeExp, query.FilterExp, query.ProjectionExpression); err != nil {
+		return nil, err
 	}
-	query.RangeValMap, err1 = ConvertDynamoToMap(query.TableName, query.ExpressionAttributeValues)
-	if err1 != nil {
-		c.JSON(errors.New("ValidationException", err1).HTTPResponse(query))
-		return
+	if allow := services.MayIReadOrWrite(query.TableName, false, ""); !allow {
+		return nil, ErrNotAllowed
+	}
+	if err := services.CheckCapacity(query.TableName, false); err != nil {
+		return nil, err
+	}
+	query, err := prepareQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	ctx = consistentReadContext(ctx, query.TableName, query.ConsistentRead)
+	res, hash, err := services.QueryAttributes(ctx, query)
+	if span := opentracing.SpanFromContext(ctx); span != nil && hash != "" {
+		span.SetTag("qHash", hash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	services.ConsumeCapacity(query.TableName, false, services.QueryCapacityUnits(resultSize(res)))
+	return ChangeQueryResponseColumn(query.TableName, res), nil
+}
+
+// prepareQuery converts the DynamoDB values of a Query request and maps its
+// attribute names and projection to the Spanner columns
+func prepareQuery(query models.Query) (models.Query, error) {
+	var err error
+	if isCountSelect(query.Select) {
+		query.OnlyCount = true
+	}
+	query.StartFrom, err = ConvertDynamoToMap(query.TableName, query.ExclusiveStartKey)
+	if err != nil {
+		return query, errors.New("ValidationException", err).WithParameter("ExclusiveStartKey")
+	}
+	query.RangeValMap, err = ConvertDynamoToMap(query.TableName, query.ExpressionAttributeValues)
+	if err != nil {
+		return query, errors.New("ValidationException", err).WithParameter("ExpressionAttributeValues")
+	}
+	query.FilterExp, query.RangeValMap, err = applyLegacyFilter(query.TableName, "QueryFilter", query.QueryFilter, query.FilterExp, query.RangeValMap)
+	if err != nil {
+		return query, err
 	}
 
 	if query.Limit == 0 {
@@ -184,29 +355,38 @@ func queryResponse(query models.Query, c *gin.Context) {
 	}
 	query.ExpressionAttributeNames = ChangeColumnToSpannerExpressionName(query.TableName, query.ExpressionAttributeNames)
 	query = ReplaceHashRangeExpr(query)
-	res, hash, err := services.QueryAttributes(c.Request.Context(), query)
-	if err == nil {
-		changedOutput := ChangeQueryResponseColumn(query.TableName, res)
-		if _, ok := changedOutput["Items"]; ok && changedOutput["Items"] != nil {
-			changedOutput["Items"], err = ChangeMaptoDynamoMap(changedOutput["Items"])
-			if err != nil {
-				c.JSON(errors.HTTPResponse(err, "ItemsChangeError"))
-			}
+	query.ProjectionExpression, err = services.SelectProjection(query.TableName, query.IndexName, query.Select, query.ProjectionExpression)
+	if err != nil {
+		return query, err
+	}
+	return query, services.ValidateProjection(query.TableName, query.ProjectionExpression, query.ExpressionAttributeNames)
+}
+
+// queryOutput converts the result of a query or a scan to the DynamoDB
+// response, with the Items as a list when flatItems is set
+func queryOutput(res map[string]interface{}, flatItems bool) (map[string]interface{}, error) {
+	changedOutput := res
+	if _, ok := changedOutput["Items"]; ok && changedOutput["Items"] != nil {
+		var itemsOutput interface{}
+		var err error
+		if flatItems {
+			itemsOutput, err = itemListJSON(changedOutput["Items"])
+		} else {
+			itemsOutput, err = attributeValuesJSON(changedOutput["Items"])
 		}
-		if _, ok := changedOutput["LastEvaluatedKey"]; ok && changedOutput["LastEvaluatedKey"] != nil {
-			changedOutput["LastEvaluatedKey"], err = ChangeMaptoDynamoMap(changedOutput["LastEvaluatedKey"])
-			if err != nil {
-				c.JSON(errors.HTTPResponse(err, "LastEvaluatedKeyChangeError"))
-			}
+		if err != nil {
+			return nil, err
 		}
-
-		c.JSON(http.StatusOK, changedOutput)
-	} else {
-		c.JSON(errors.HTTPResponse(err, query))
+		changedOutput["Items"] = itemsOutput
 	}
-	if hash != "" {
-		span = span.SetTag("qHash", hash)
+	if _, ok := changedOutput["LastEvaluatedKey"]; ok && changedOutput["LastEvaluatedKey"] != nil {
+		lastEvaluatedKey, err := attributeValuesJSON(changedOutput["LastEvaluatedKey"])
+		if err != nil {
+			return nil, err
+		}
+		changedOutput["LastEvaluatedKey"] = lastEvaluatedKey
 	}
+	return changedOutput, nil
 }
 
 // QueryTable queries a table
@@ -240,6 +420,177 @@ func QueryTable(c *gin.Context) {
 	}
 }
 
+// BatchQuery runs several queries in one request
+// @Description Runs the Queries concurrently from one Spanner snapshot and returns their results in the same order
+// @Summary Run several queries
+// @ID batch-query
+// @Produce  json
+// @Success 200 {object} gin.H
+// @Param requestBody body models.BatchQuery true "Please add request body of type models.BatchQuery"
+// @Failure 500 {object} gin.H "{"errorMessage":"We had a problem with our server. Try again later.","errorCode":"E0001"}"
+// @Router /BatchQuery/ [post]
+// @Failure 401 {object} gin.H "{"errorMessage":"API access not allowed","errorCode": "E0005"}"
+func BatchQuery(c *gin.Context) {
+	defer PanicHandler(c)
+	defer c.Request.Body.Close()
+	carrier := opentracing.HTTPHeadersCarrier(c.Request.Header)
+	spanContext, err := opentracing.GlobalTracer().Extract(opentracing.HTTPHeaders, carrier)
+	if err != nil || spanContext == nil {
+		logger.LogDebug(err)
+	}
+	span, ctx := opentracing.StartSpanFromContext(c.Request.Context(), c.Request.URL.RequestURI(), opentracing.ChildOf(spanContext))
+	c.Request = c.Request.WithContext(ctx)
+	defer span.Finish()
+	span = addParentSpanID(c, span)
+	var batchQuery models.BatchQuery
+	if err := c.ShouldBindJSON(&batchQuery); err != nil {
+		c.JSON(errors.New("ValidationException", err).HTTPResponse(batchQuery))
+		return
+	}
+	logger.LogInfo(batchQuery)
+	results, err := DoBatchQuery(c.Request.Context(), batchQuery)
+	if err == ErrNotAllowed {
+		c.JSON(http.StatusOK, gin.H{})
+		return
+	}
+	if err != nil {
+		c.JSON(errors.HTTPResponse(err, batchQuery))
+		return
+	}
+	span = span.SetTag("batchQueryCount", len(results))
+	responses := make([]map[string]interface{}, len(results))
+	for i, res := range results {
+		responses[i], err = queryOutput(res, flatItemsResponse(c))
+		if err != nil {
+			c.JSON(errors.HTTPResponse(err, batchQuery))
+			return
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"Responses": responses})
+}
+
+// DoBatchQuery runs the queries of a BatchQuery request and returns their
+// results in the same order, with the original column names
+func DoBatchQuery(ctx context.Context, batchQuery models.BatchQuery) ([]map[string]interface{}, error) {
+	if len(batchQuery.Queries) == 0 || len(batchQuery.Queries) > maxBatchQueries {
+		return nil, errors.New("ValidationException", "Queries must have between 1 and", maxBatchQueries, "queries").WithParameter("Queries")
+	}
+	queries := make([]models.Query, len(batchQuery.Queries))
+	for i, query := range batchQuery.Queries {
+		if err := validateExpressionAttributes(query.ExpressionAttributeNames, query.ExpressionAttributeValues, query.RangeExp, query.FilterExp, query.ProjectionExpression); err != nil {
+			return nil, err
+		}
+		if allow := services.MayIReadOrWrite(query.TableName, false, ""); !allow {
+			return nil, ErrNotAllowed
+		}
+		if err := services.CheckCapacity(query.TableName, false); err != nil {
+			return nil, err
+		}
+		var err error
+		queries[i], err = prepareQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	results, err := services.BatchQuery(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	for i, res := range results {
+		services.ConsumeCapacity(queries[i].TableName, false, services.QueryCapacityUnits(resultSize(res)))
+		results[i] = ChangeQueryResponseColumn(queries[i].TableName, res)
+	}
+	return results, nil
+}
+
+// QueryStream queries a table and streams the matching items
+// @Description Query a table and stream every matching item as a line of JSON
+// @Summary Stream query results
+// @ID query-stream
+// @Produce  json
+// @Success 200 {object} gin.H
+// @Param requestBody body models.Query true "Please add request body of type models.Query"
+// @Failure 500 {object} gin.H "{"errorMessage":"We had a problem with our server. Try again later.","errorCode":"E0001"}"
+// @Router /QueryStream/ [post]
+// @Failure 401 {object} gin.H "{"errorMessage":"API access not allowed","errorCode": "E0005"}"
+func QueryStream(c *gin.Context) {
+	defer PanicHandler(c)
+	defer c.Request.Body.Close()
+	carrier := opentracing.HTTPHeadersCarrier(c.Request.Header)
+	spanContext, err := opentracing.GlobalTracer().Extract(opentracing.HTTPHeaders, carrier)
+	if err != nil || spanContext == nil {
+		logger.LogDebug(err)
+	}
+	span, ctx := opentracing.StartSpanFromContext(c.Request.Context(), c.Request.URL.RequestURI(), opentracing.ChildOf(spanContext))
+	c.Request = c.Request.WithContext(ctx)
+	defer span.Finish()
+	span = addParentSpanID(c, span)
+	var query models.Query
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(errors.New("ValidationException", err).HTTPResponse(query))
+		return
+	}
+	if err := validateExpressionAttributes(query.ExpressionAttributeNames, query.ExpressionAttributeValues, query.RangeExp, query.FilterExp, query.ProjectionExpression); err != nil {
+		c.JSON(errors.HTTPResponse(err, query))
+		return
+	}
+	logger.LogInfo(query)
+	if allow := services.MayIReadOrWrite(query.TableName, false, ""); !allow {
+		c.JSON(http.StatusOK, gin.H{})
+		return
+	}
+	if throttled(c, query.TableName, false, query) {
+		return
+	}
+	// unlike Query, the stream is not paged unless the client asks for a Limit
+	limit := query.Limit
+	if limit == 0 {
+		limit = math.MaxInt64
+	}
+	query, err = prepareQuery(query)
+	if err != nil {
+		c.JSON(errors.HTTPResponse(err, query))
+		return
+	}
+	query.Limit = limit
+
+	encoder := json.NewEncoder(c.Writer)
+	size := 0
+	defer func() {
+		services.ConsumeCapacity(query.TableName, false, services.QueryCapacityUnits(size))
+	}()
+	ctx = consistentReadContext(c.Request.Context(), query.TableName, query.ConsistentRead)
+	err = services.QueryAttributesStream(ctx, query, func(row map[string]interface{}) error {
+		size += services.ItemSize(row)
+		item, err := attributeValuesJSON(ChangeResponseToOriginalColumns(query.TableName, row))
+		if err != nil {
+			return err
+		}
+		if !c.Writer.Written() {
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Status(http.StatusOK)
+		}
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		if !c.Writer.Written() {
+			c.JSON(errors.HTTPResponse(err, query))
+			return
+		}
+		// headers are already sent, so report the failure as the last line
+		_, body := errors.HTTPResponse(err, query)
+		encoder.Encode(body)
+		return
+	}
+	if !c.Writer.Written() {
+		c.Status(http.StatusOK)
+	}
+}
+
 // GetItemMeta to get with projections
 // @Description Get a record with projections
 // @Summary Get a record with projections
@@ -268,31 +619,65 @@ func GetItemMeta(c *gin.Context) {
 	} else {
 		span.SetTag("table", getItemMeta.TableName)
 		logger.LogDebug(getItemMeta)
-		if allow := services.MayIReadOrWrite(getItemMeta.TableName, false, ""); !allow {
+		item, notModified, err := DoGetItem(c.Request.Context(), getItemMeta)
+		if err == ErrNotAllowed {
 			c.JSON(http.StatusOK, gin.H{})
-			return
-		}
-		getItemMeta.PrimaryKeyMap, err = ConvertDynamoToMap(getItemMeta.TableName, getItemMeta.Key)
-		if err != nil {
-			c.JSON(errors.New("ValidationException", err).HTTPResponse(getItemMeta))
-			return
-		}
-		getItemMeta.ExpressionAttributeNames = ChangeColumnToSpannerExpressionName(getItemMeta.TableName, getItemMeta.ExpressionAttributeNames)
-		res, rowErr := services.GetWithProjection(c.Request.Context(), getItemMeta.TableName, getItemMeta.PrimaryKeyMap, getItemMeta.ProjectionExpression, getItemMeta.ExpressionAttributeNames)
-		if rowErr == nil {
-			changedColumns := ChangeResponseToOriginalColumns(getItemMeta.TableName, res)
-			output, err := ChangeMaptoDynamoMap(changedColumns)
+		} else if err != nil {
+			c.JSON(errors.HTTPResponse(err, getItemMeta))
+		} else if notModified {
+			c.Header(notModifiedHeader, "true")
+			c.JSON(http.StatusOK, gin.H{"Item": gin.H{}})
+		} else {
+			output, err := attributeValuesJSON(item)
 			if err != nil {
 				c.JSON(errors.HTTPResponse(err, "OutputChangedError"))
+				return
 			}
-			output = map[string]interface{}{
+			c.JSON(http.StatusOK, map[string]interface{}{
 				"Item": output,
-			}
-			c.JSON(http.StatusOK, output)
-		} else {
-			c.JSON(errors.HTTPResponse(rowErr, getItemMeta))
+			})
+		}
+	}
+}
+
+// DoGetItem reads the item of a GetItem request with the original column
+// names. notModified reports that the item still has the IfVersionNotEqual
+// version, it is not returned then.
+func DoGetItem(ctx context.Context, getItemMeta models.GetItemMeta) (item map[string]interface{}, notModified bool, err error) {
+	if err := validateExpressionAttributes(getItemMeta.ExpressionAttributeNames, nil, getItemMeta.ProjectionExpression); err != nil {
+		return nil, false, err
+	}
+	if allow := services.MayIReadOrWrite(getItemMeta.TableName, false, ""); !allow {
+		return nil, false, ErrNotAllowed
+	}
+	if err := services.CheckCapacity(getItemMeta.TableName, false); err != nil {
+		return nil, false, err
+	}
+	getItemMeta.PrimaryKeyMap, err = ConvertDynamoToMap(getItemMeta.TableName, getItemMeta.Key)
+	if err != nil {
+		return nil, false, errors.New("ValidationException", err).WithParameter("Key")
+	}
+	getItemMeta.ExpressionAttributeNames = ChangeColumnToSpannerExpressionName(getItemMeta.TableName, getItemMeta.ExpressionAttributeNames)
+	getItemMeta.ProjectionExpression = services.DefaultProjection(getItemMeta.TableName, getItemMeta.ProjectionExpression)
+	if err := services.ValidateProjection(getItemMeta.TableName, getItemMeta.ProjectionExpression, getItemMeta.ExpressionAttributeNames); err != nil {
+		return nil, false, err
+	}
+	var res map[string]interface{}
+	consistentRead := services.IsConsistentRead(getItemMeta.TableName, getItemMeta.ConsistentRead)
+	if getItemMeta.IfVersionNotEqual != nil {
+		res, notModified, err = services.GetWithProjectionIfVersionNotEqual(ctx, getItemMeta.TableName, getItemMeta.PrimaryKeyMap, getItemMeta.ProjectionExpression, getItemMeta.ExpressionAttributeNames, convertFrom(getItemMeta.IfVersionNotEqual, getItemMeta.TableName))
+		if err == nil && notModified {
+			services.ConsumeCapacity(getItemMeta.TableName, false, services.ReadCapacityUnits([]map[string]interface{}{res}, 1, true))
+			return nil, true, nil
 		}
+	} else {
+		res, err = services.CoalescedGetWithProjection(ctx, getItemMeta.TableName, getItemMeta.PrimaryKeyMap, getItemMeta.ProjectionExpression, getItemMeta.ExpressionAttributeNames, consistentRead)
+	}
+	if err != nil {
+		return nil, false, err
 	}
+	services.ConsumeCapacity(getItemMeta.TableName, false, services.ReadCapacityUnits([]map[string]interface{}{res}, 1, consistentRead))
+	return ChangeResponseToOriginalColumns(getItemMeta.TableName, res), false, nil
 }
 
 // BatchGetItem to get with projections
@@ -323,29 +708,29 @@ func BatchGetItem(c *gin.Context) {
 	if err1 := c.ShouldBindJSON(&batchGetMeta); err1 != nil {
 		c.JSON(errors.New("ValidationException", err1).HTTPResponse(batchGetMeta))
 	} else {
+		responses, capacityUnits, err := DoBatchGetItem(c.Request.Context(), batchGetMeta)
+		if err == ErrNotAllowed {
+			c.JSON(http.StatusOK, []gin.H{})
+			return
+		}
+		if err != nil {
+			c.JSON(errors.HTTPResponse(err, batchGetMeta))
+			return
+		}
 		output := make(map[string]interface{})
-
-		for k, v := range batchGetMeta.RequestItems {
-			batchGetWithProjectionMeta := v
-			batchGetWithProjectionMeta.TableName = k
-			logger.LogDebug(batchGetWithProjectionMeta)
-			if allow := services.MayIReadOrWrite(batchGetWithProjectionMeta.TableName, false, ""); !allow {
-				c.JSON(http.StatusOK, []gin.H{})
-				return
-			}
-			var singleOutput interface{}
-			singleOutput, span, err = batchGetDataSingleTable(c.Request.Context(), batchGetWithProjectionMeta, span)
-			if err != nil {
-				c.JSON(errors.HTTPResponse(err, batchGetWithProjectionMeta))
-			}
-			currOutput, err := ChangeMaptoDynamoMap(singleOutput)
+		for k, items := range responses {
+			output[k], err = itemListJSON(items)
 			if err != nil {
-				c.JSON(errors.HTTPResponse(err, batchGetWithProjectionMeta))
+				c.JSON(errors.HTTPResponse(err, batchGetMeta))
+				return
 			}
-			output[k] = currOutput["L"]
 		}
 
-		c.JSON(http.StatusOK, map[string]interface{}{"Responses": output})
+		response := map[string]interface{}{"Responses": output}
+		if capacity := consumedCapacity(batchGetMeta.ReturnConsumedCapacity, capacityUnits); capacity != nil {
+			response["ConsumedCapacity"] = capacity
+		}
+		c.JSON(http.StatusOK, response)
 
 		if time.Since(start) > time.Second*1 {
 			go fmt.Println("BatchGetCall", batchGetMeta)
@@ -353,24 +738,64 @@ func BatchGetItem(c *gin.Context) {
 	}
 }
 
-func batchGetDataSingleTable(ctx context.Context, batchGetWithProjectionMeta models.BatchGetWithProjectionMeta, span opentracing.Span) (interface{}, opentracing.Span, error) {
+// DoBatchGetItem reads the keys of a BatchGetItem request and returns the
+// items of every table with the original column names, along with the read
+// capacity units each table used
+func DoBatchGetItem(ctx context.Context, batchGetMeta models.BatchGetMeta) (map[string][]map[string]interface{}, map[string]float64, error) {
+	if err := validateReturnConsumedCapacity(batchGetMeta.ReturnConsumedCapacity); err != nil {
+		return nil, nil, err
+	}
+	responses := make(map[string][]map[string]interface{})
+	capacityUnits := make(map[string]float64)
+
+	for k, v := range batchGetMeta.RequestItems {
+		batchGetWithProjectionMeta := v
+		batchGetWithProjectionMeta.TableName = k
+		logger.LogDebug(batchGetWithProjectionMeta)
+		if err := validateExpressionAttributes(batchGetWithProjectionMeta.ExpressionAttributeNames, nil, batchGetWithProjectionMeta.ProjectionExpression); err != nil {
+			return nil, nil, err
+		}
+		if allow := services.MayIReadOrWrite(batchGetWithProjectionMeta.TableName, false, ""); !allow {
+			return nil, nil, ErrNotAllowed
+		}
+		if err := services.CheckCapacity(batchGetWithProjectionMeta.TableName, false); err != nil {
+			return nil, nil, err
+		}
+		items, err := batchGetDataSingleTable(ctx, batchGetWithProjectionMeta)
+		if err != nil {
+			return nil, nil, err
+		}
+		responses[k] = items
+		capacityUnits[k] = services.ReadCapacityUnits(items, len(v.Keys), services.IsConsistentRead(k, v.ConsistentRead))
+		services.ConsumeCapacity(k, false, capacityUnits[k])
+	}
+	return responses, capacityUnits, nil
+}
+
+func batchGetDataSingleTable(ctx context.Context, batchGetWithProjectionMeta models.BatchGetWithProjectionMeta) ([]map[string]interface{}, error) {
 
 	var err1 error
 	batchGetWithProjectionMeta.KeyArray, err1 = ConvertDynamoArrayToMapArray(batchGetWithProjectionMeta.TableName, batchGetWithProjectionMeta.Keys)
 	if err1 != nil {
-		return nil, nil, errors.New("ValidationException", err1.Error())
+		return nil, errors.New("ValidationException", err1.Error()).WithParameter("Keys")
 	}
 	batchGetWithProjectionMeta.ExpressionAttributeNames = ChangeColumnToSpannerExpressionName(batchGetWithProjectionMeta.TableName, batchGetWithProjectionMeta.ExpressionAttributeNames)
-	res, err2 := services.BatchGetWithProjection(ctx, batchGetWithProjectionMeta.TableName, batchGetWithProjectionMeta.KeyArray, batchGetWithProjectionMeta.ProjectionExpression, batchGetWithProjectionMeta.ExpressionAttributeNames)
+	batchGetWithProjectionMeta.ProjectionExpression = services.DefaultProjection(batchGetWithProjectionMeta.TableName, batchGetWithProjectionMeta.ProjectionExpression)
+	if err := services.ValidateProjection(batchGetWithProjectionMeta.TableName, batchGetWithProjectionMeta.ProjectionExpression, batchGetWithProjectionMeta.ExpressionAttributeNames); err != nil {
+		return nil, err
+	}
+	res, err2 := services.BatchGetWithProjection(ctx, batchGetWithProjectionMeta.TableName, batchGetWithProjectionMeta.KeyArray, batchGetWithProjectionMeta.ProjectionExpression, batchGetWithProjectionMeta.ExpressionAttributeNames, services.IsConsistentRead(batchGetWithProjectionMeta.TableName, batchGetWithProjectionMeta.ConsistentRead))
 
-	span = span.SetTag("table", batchGetWithProjectionMeta.TableName)
-	span = span.SetTag("batchRequestCount", len(batchGetWithProjectionMeta.Keys))
-	span = span.SetTag("batchResponseCount", len(res))
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span.SetTag("table", batchGetWithProjectionMeta.TableName)
+		span.SetTag("batchRequestCount", len(batchGetWithProjectionMeta.Keys))
+		span.SetTag("batchResponseCount", len(res))
+	}
 
 	if err2 != nil {
-		return nil, span, err2
+		return nil, err2
 	}
-	return ChangesArrayResponseToOriginalColumns(batchGetWithProjectionMeta.TableName, res), span, nil
+	return ChangesArrayResponseToOriginalColumns(batchGetWithProjectionMeta.TableName, res), nil
 }
 
 // DeleteItem  ...
@@ -400,35 +825,144 @@ func DeleteItem(c *gin.Context) {
 		c.JSON(errors.New("ValidationException", err).HTTPResponse(deleteItem))
 	} else {
 		logger.LogDebug(deleteItem)
-		if allow := services.MayIReadOrWrite(deleteItem.TableName, true, "DeleteItem"); !allow {
+		oldRes, err := DoDeleteItem(c.Request.Context(), deleteItem)
+		if err == ErrNotAllowed {
 			c.JSON(http.StatusOK, gin.H{})
+		} else if err == nil {
+			output, _ := attributeValuesJSON(oldRes)
+			c.JSON(http.StatusOK, map[string]interface{}{"Attributes": output})
+		} else {
+			c.JSON(errors.HTTPResponse(err, deleteItem))
+		}
+	}
+}
+
+// DoDeleteItem deletes the item of a DeleteItem request and returns it with
+// the original column names
+func DoDeleteItem(ctx context.Context, deleteItem models.Delete) (map[string]interface{}, error) {
+	if err := validateExpressionAttributes(deleteItem.ExpressionAttributeNames, deleteItem.ExpressionAttributeValues, deleteItem.ConditionExpression); err != nil {
+		return nil, err
+	}
+	if allow := services.MayIReadOrWrite(deleteItem.TableName, true, "DeleteItem"); !allow {
+		return nil, ErrNotAllowed
+	}
+	if err := services.CheckCapacity(deleteItem.TableName, true); err != nil {
+		return nil, err
+	}
+	var err error
+	deleteItem.PrimaryKeyMap, err = ConvertDynamoToMap(deleteItem.TableName, deleteItem.Key)
+	if err != nil {
+		return nil, errors.New("ValidationException", err).WithParameter("Key")
+	}
+	deleteItem.ExpressionAttributeMap, err = ConvertDynamoToMap(deleteItem.TableName, deleteItem.ExpressionAttributeValues)
+	if err != nil {
+		return nil, errors.New("ValidationException", err).WithParameter("ExpressionAttributeValues")
+	}
+
+	deleteItem.ConditionExpression, deleteItem.ExpressionAttributeMap, err = applyLegacyExpected(deleteItem.TableName, deleteItem.Expected, deleteItem.ConditionalOperator, deleteItem.ConditionExpression, deleteItem.ExpressionAttributeMap)
+	if err != nil {
+		return nil, err
+	}
+	deleteItem.ConditionExpression = applyAttributeNames(deleteItem.TableName, utils.NormalizeKeywords(deleteItem.ConditionExpression), deleteItem.ExpressionAttributeNames)
+
+	oldRes, _ := services.GetWithProjection(ctx, deleteItem.TableName, deleteItem.PrimaryKeyMap, "", nil)
+	if err := services.Delete(ctx, deleteItem.TableName, deleteItem.PrimaryKeyMap, deleteItem.ConditionExpression, deleteItem.ExpressionAttributeMap, nil); err != nil {
+		return nil, err
+	}
+	services.ConsumeCapacity(deleteItem.TableName, true, services.WriteCapacityUnits([]map[string]interface{}{oldRes}))
+	services.StreamDataToThirdParty(oldRes, nil, deleteItem.TableName)
+	return ChangeResponseToOriginalColumns(deleteItem.TableName, oldRes), nil
+}
+
+// BatchConditionalDelete deletes keys each guarded by its own condition
+// @Description Deletes every key whose ConditionExpression holds, in chunked transactions, and returns the result of each key
+// @Summary Delete keys with conditions
+// @ID batch-conditional-delete
+// @Produce  json
+// @Success 200 {object} gin.H
+// @Param requestBody body models.BatchConditionalDelete true "Please add request body of type models.BatchConditionalDelete"
+// @Failure 500 {object} gin.H "{"errorMessage":"We had a problem with our server. Try again later.","errorCode":"E0001"}"
+// @Router /BatchConditionalDelete/ [post]
+// @Failure 401 {object} gin.H "{"errorMessage":"API access not allowed","errorCode": "E0005"}"
+func BatchConditionalDelete(c *gin.Context) {
+	defer PanicHandler(c)
+	defer c.Request.Body.Close()
+	carrier := opentracing.HTTPHeadersCarrier(c.Request.Header)
+	spanContext, err := opentracing.GlobalTracer().Extract(opentracing.HTTPHeaders, carrier)
+	if err != nil || spanContext == nil {
+		logger.LogDebug(err)
+	}
+	span, ctx := opentracing.StartSpanFromContext(c.Request.Context(), c.Request.URL.RequestURI(), opentracing.ChildOf(spanContext))
+	c.Request = c.Request.WithContext(ctx)
+	defer span.Finish()
+	span = addParentSpanID(c, span)
+	var batchDelete models.BatchConditionalDelete
+	if err := c.ShouldBindJSON(&batchDelete); err != nil {
+		c.JSON(errors.New("ValidationException", err).HTTPResponse(batchDelete))
+		return
+	}
+	if len(batchDelete.Deletes) == 0 || len(batchDelete.Deletes) > maxConditionalDeletes {
+		c.JSON(errors.New("ValidationException", "Deletes must have between 1 and", maxConditionalDeletes, "keys").WithParameter("Deletes").HTTPResponse(batchDelete))
+		return
+	}
+	keys := make([]map[string]interface{}, len(batchDelete.Deletes))
+	conditions := make([]string, len(batchDelete.Deletes))
+	attrMaps := make([]map[string]interface{}, len(batchDelete.Deletes))
+	for i, deleteItem := range batchDelete.Deletes {
+		if err := validateExpressionAttributes(deleteItem.ExpressionAttributeNames, deleteItem.ExpressionAttributeValues, deleteItem.ConditionExpression); err != nil {
+			c.JSON(errors.HTTPResponse(err, batchDelete))
 			return
 		}
-		deleteItem.PrimaryKeyMap, err = ConvertDynamoToMap(deleteItem.TableName, deleteItem.Key)
+		keys[i], err = ConvertDynamoToMap(batchDelete.TableName, deleteItem.Key)
 		if err != nil {
-			c.JSON(errors.New("ValidationException", err).HTTPResponse(deleteItem))
+			c.JSON(errors.New("ValidationException", err).WithParameter("Key").HTTPResponse(batchDelete))
 			return
 		}
-		deleteItem.ExpressionAttributeMap, err = ConvertDynamoToMap(deleteItem.TableName, deleteItem.ExpressionAttributeValues)
+		attrMaps[i], err = ConvertDynamoToMap(batchDelete.TableName, deleteItem.ExpressionAttributeValues)
 		if err != nil {
-			c.JSON(errors.New("ValidationException", err).HTTPResponse(deleteItem))
+			c.JSON(errors.New("ValidationException", err).WithParameter("ExpressionAttributeValues").HTTPResponse(batchDelete))
 			return
 		}
-
-		for k, v := range deleteItem.ExpressionAttributeNames {
-			deleteItem.ConditionExpression = strings.ReplaceAll(deleteItem.ConditionExpression, k, v)
+		conditions[i], attrMaps[i], err = applyLegacyExpected(batchDelete.TableName, deleteItem.Expected, deleteItem.ConditionalOperator, deleteItem.ConditionExpression, attrMaps[i])
+		if err != nil {
+			c.JSON(errors.HTTPResponse(err, batchDelete))
+			return
 		}
+		conditions[i] = applyAttributeNames(batchDelete.TableName, utils.NormalizeKeywords(conditions[i]), deleteItem.ExpressionAttributeNames)
+	}
+	if allow := services.MayIReadOrWrite(batchDelete.TableName, true, "BatchConditionalDelete"); !allow {
+		c.JSON(http.StatusOK, gin.H{})
+		return
+	}
+	if throttled(c, batchDelete.TableName, true, batchDelete) {
+		return
+	}
+	span = span.SetTag("deleteCount", len(keys))
+	errs, err := services.BatchConditionalDelete(c.Request.Context(), batchDelete.TableName, keys, conditions, attrMaps)
+	if err != nil {
+		c.JSON(errors.HTTPResponse(err, batchDelete))
+		return
+	}
+	c.JSON(http.StatusOK, map[string]interface{}{"Responses": conditionalDeleteResponses(batchDelete, errs)})
+}
 
-		oldRes, _ := services.GetWithProjection(c.Request.Context(), deleteItem.TableName, deleteItem.PrimaryKeyMap, "", nil)
-		err := services.Delete(c.Request.Context(), deleteItem.TableName, deleteItem.PrimaryKeyMap, deleteItem.ConditionExpression, deleteItem.ExpressionAttributeMap, nil)
-		if err == nil {
-			output, _ := ChangeMaptoDynamoMap(ChangeResponseToOriginalColumns(deleteItem.TableName, oldRes))
-			c.JSON(http.StatusOK, map[string]interface{}{"Attributes": output})
-			go services.StreamDataToThirdParty(oldRes, nil, deleteItem.TableName)
-		} else {
-			c.JSON(errors.HTTPResponse(err, deleteItem))
+// conditionalDeleteResponses returns the result of every key of a
+// BatchConditionalDelete, with the error response of the keys which were not
+// deleted, and consumes a write unit per deleted key, since the deleted items
+// are not sent back
+func conditionalDeleteResponses(batchDelete models.BatchConditionalDelete, errs []error) []map[string]interface{} {
+	responses := make([]map[string]interface{}, len(errs))
+	deleted := 0
+	for i, err := range errs {
+		responses[i] = map[string]interface{}{"Key": batchDelete.Deletes[i].Key, "Deleted": err == nil}
+		if err != nil {
+			_, responses[i]["Error"] = errors.HTTPResponse(err, batchDelete.Deletes[i])
+			continue
 		}
+		deleted++
 	}
+	services.ConsumeCapacity(batchDelete.TableName, true, float64(deleted))
+	return responses
 }
 
 // Scan record from table
@@ -457,50 +991,72 @@ func Scan(c *gin.Context) {
 	if err := c.ShouldBindJSON(&meta); err != nil {
 		c.JSON(errors.New("ValidationException", err).HTTPResponse(meta))
 	} else {
-		if allow := services.MayIReadOrWrite(meta.TableName, false, ""); !allow {
+		res, err := DoScan(c.Request.Context(), meta)
+		if err == ErrNotAllowed {
 			c.JSON(http.StatusOK, gin.H{})
-			return
-		}
-
-		meta.StartFrom, err = ConvertDynamoToMap(meta.TableName, meta.ExclusiveStartKey)
-		if err != nil {
-			c.JSON(errors.New("ValidationException", err).HTTPResponse(meta))
-			return
-		}
-
-		meta.ExpressionAttributeMap, err = ConvertDynamoToMap(meta.TableName, meta.ExpressionAttributeValues)
-		if err != nil {
-			c.JSON(errors.New("ValidationException", err).HTTPResponse(meta))
-			return
-		}
-		if meta.Select == "COUNT" {
-			meta.OnlyCount = true
-		}
-
-		logger.LogDebug(meta)
-		res, err := services.Scan(c.Request.Context(), meta)
-		if err == nil {
-			changedOutput := ChangeQueryResponseColumn(meta.TableName, res)
-			if _, ok := changedOutput["Items"]; ok && changedOutput["Items"] != nil {
-				itemsOutput, err := ChangeMaptoDynamoMap(changedOutput["Items"])
-				if err != nil {
-					c.JSON(errors.HTTPResponse(err, "ItemsChangeError"))
-				}
-				changedOutput["Items"] = itemsOutput["L"]
-			}
-			if _, ok := changedOutput["LastEvaluatedKey"]; ok && changedOutput["LastEvaluatedKey"] != nil {
-				changedOutput["LastEvaluatedKey"], err = ChangeMaptoDynamoMap(changedOutput["LastEvaluatedKey"])
-				if err != nil {
-					c.JSON(errors.HTTPResponse(err, "LastEvaluatedKeyChangeError"))
-				}
+		} else if err == nil {
+			changedOutput, err := queryOutput(res, true)
+			if err != nil {
+				c.JSON(errors.HTTPResponse(err, "ItemsChangeError"))
+				return
 			}
-			c.JSON(http.StatusOK, res)
+			c.JSON(http.StatusOK, changedOutput)
 		} else {
 			c.JSON(errors.HTTPResponse(err, meta))
 		}
 	}
 }
 
+// DoScan runs a Scan request and returns its Items, Count and
+// LastEvaluatedKey with the original column names
+func DoScan(ctx context.Context, meta models.ScanMeta) (map[string]interface{}, error) {
+	if allow := services.MayIReadOrWrite(meta.TableName, false, ""); !allow {
+		return nil, ErrNotAllowed
+	}
+	if err := services.CheckCapacity(meta.TableName, false); err != nil {
+		return nil, err
+	}
+	if err := validateExpressionAttributes(meta.ExpressionAttributeNames, meta.ExpressionAttributeValues, meta.FilterExpression, meta.ProjectionExpression); err != nil {
+		return nil, err
+	}
+
+	var err error
+	meta.StartFrom, err = ConvertDynamoToMap(meta.TableName, meta.ExclusiveStartKey)
+	if err != nil {
+		return nil, errors.New("ValidationException", err).WithParameter("ExclusiveStartKey")
+	}
+
+	meta.ExpressionAttributeMap, err = ConvertDynamoToMap(meta.TableName, meta.ExpressionAttributeValues)
+	if err != nil {
+		return nil, errors.New("ValidationException", err).WithParameter("ExpressionAttributeValues")
+	}
+	meta.FilterExpression, meta.ExpressionAttributeMap, err = applyLegacyFilter(meta.TableName, "ScanFilter", meta.ScanFilter, meta.FilterExpression, meta.ExpressionAttributeMap)
+	if err != nil {
+		return nil, err
+	}
+	if isCountSelect(meta.Select) {
+		meta.OnlyCount = true
+	}
+	meta.FilterExpression = utils.NormalizeKeywords(meta.FilterExpression)
+	meta.ExpressionAttributeNames = ChangeColumnToSpannerExpressionName(meta.TableName, meta.ExpressionAttributeNames)
+	meta.ProjectionExpression, err = services.SelectProjection(meta.TableName, meta.IndexName, meta.Select, meta.ProjectionExpression)
+	if err == nil {
+		err = services.ValidateProjection(meta.TableName, meta.ProjectionExpression, meta.ExpressionAttributeNames)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger.LogDebug(meta)
+	ctx = consistentReadContext(ctx, meta.TableName, meta.ConsistentRead)
+	res, err := services.Scan(ctx, meta)
+	if err != nil {
+		return nil, err
+	}
+	services.ConsumeCapacity(meta.TableName, false, services.QueryCapacityUnits(resultSize(res)))
+	return ChangeQueryResponseColumn(meta.TableName, res), nil
+}
+
 // Update updates a record in Spanner
 // @Description updates a record in Spanner
 // @Summary updates a record in Spanner
@@ -527,27 +1083,67 @@ func Update(c *gin.Context) {
 	if err := c.ShouldBindJSON(&updateAttr); err != nil {
 		c.JSON(errors.New("ValidationException", err).HTTPResponse(updateAttr))
 	} else {
-		if allow := services.MayIReadOrWrite(updateAttr.TableName, true, "update"); !allow {
+		attributes, err := DoUpdateItem(c.Request.Context(), updateAttr)
+		if err == ErrNotAllowed {
 			c.JSON(http.StatusOK, gin.H{})
-			return
-		}
-		updateAttr.PrimaryKeyMap, err = ConvertDynamoToMap(updateAttr.TableName, updateAttr.Key)
-		if err != nil {
-			c.JSON(errors.New("ValidationException", err).HTTPResponse(updateAttr))
-			return
+		} else if err != nil {
+			c.JSON(errors.HTTPResponse(err, updateAttr))
+		} else if attributes == nil {
+			c.JSON(http.StatusOK, nil)
+		} else {
+			output, err := attributeValuesJSON(attributes)
+			if err != nil {
+				c.JSON(errors.HTTPResponse(err, updateAttr))
+				return
+			}
+			c.JSON(http.StatusOK, map[string]interface{}{"Attributes": output})
 		}
-		updateAttr.ExpressionAttributeMap, err = ConvertDynamoToMap(updateAttr.TableName, updateAttr.ExpressionAttributeValues)
+	}
+}
+
+// DoUpdateItem updates the item of an UpdateItem request and returns the
+// attributes of its ReturnValues with the original column names, nil when
+// there are none to return
+func DoUpdateItem(ctx context.Context, updateAttr models.UpdateAttr) (map[string]interface{}, error) {
+	if err := validateExpressionAttributes(updateAttr.ExpressionAttributeNames, updateAttr.ExpressionAttributeValues, updateAttr.UpdateExpression, updateAttr.ConditionExpression); err != nil {
+		return nil, err
+	}
+	if allow := services.MayIReadOrWrite(updateAttr.TableName, true, "update"); !allow {
+		return nil, ErrNotAllowed
+	}
+	if err := services.CheckCapacity(updateAttr.TableName, true); err != nil {
+		return nil, err
+	}
+	var err error
+	updateAttr.PrimaryKeyMap, err = ConvertDynamoToMap(updateAttr.TableName, updateAttr.Key)
+	if err != nil {
+		return nil, errors.New("ValidationException", err).WithParameter("Key")
+	}
+	updateAttr.ExpressionAttributeMap, err = ConvertDynamoToMap(updateAttr.TableName, updateAttr.ExpressionAttributeValues)
+	if err != nil {
+		return nil, errors.New("ValidationException", err).WithParameter("ExpressionAttributeValues")
+	}
+	updateAttr.ConditionExpression, updateAttr.ExpressionAttributeMap, err = applyLegacyExpected(updateAttr.TableName, updateAttr.Expected, updateAttr.ConditionalOperator, updateAttr.ConditionExpression, updateAttr.ExpressionAttributeMap)
+	if err != nil {
+		return nil, err
+	}
+	if updateAttr.ExpectedVersion != nil {
+		versionAttr, version, err := expectedVersion(updateAttr.TableName, updateAttr.ExpectedVersion)
 		if err != nil {
-			c.JSON(errors.New("ValidationException", err).HTTPResponse(updateAttr))
-			return
+			return nil, err
 		}
-		resp, err := UpdateExpression(c.Request.Context(), updateAttr)
+		updateAttr.ConditionExpression, updateAttr.ExpressionAttributeNames, updateAttr.ExpressionAttributeMap, err = applyExpectedVersion(versionAttr, version, updateAttr.ConditionExpression, updateAttr.ExpressionAttributeNames, updateAttr.ExpressionAttributeMap)
 		if err != nil {
-			c.JSON(errors.HTTPResponse(err, updateAttr))
-		} else {
-			c.JSON(http.StatusOK, resp)
+			return nil, err
 		}
+		updateAttr.UpdateExpression = addVersionIncrement(updateAttr.UpdateExpression, updateAttr.ExpressionAttributeMap)
 	}
+	attributes, err := UpdateExpression(ctx, updateAttr)
+	if err != nil {
+		return nil, err
+	}
+	services.ConsumeCapacity(updateAttr.TableName, true, updateCapacityUnits(updateAttr))
+	return attributes, nil
 }
 
 // BatchWriteItem put & delete items in/from table
@@ -576,69 +1172,102 @@ func BatchWriteItem(c *gin.Context) {
 	if err1 := c.ShouldBindJSON(&batchWriteItem); err1 != nil {
 		c.JSON(errors.New("ValidationException", err1).HTTPResponse(batchWriteItem))
 	} else {
-		for key, value := range batchWriteItem.RequestItems {
-			if allow := services.MayIReadOrWrite(key, true, "BatchWriteItem"); !allow {
-				c.JSON(http.StatusOK, gin.H{})
-				return
-			}
-			var putData models.BatchMetaUpdate
-			putData.TableName = key
+		capacityUnits, err := DoBatchWriteItem(c.Request.Context(), batchWriteItem)
+		if err == ErrNotAllowed {
+			c.JSON(http.StatusOK, gin.H{})
+			return
+		}
+		if err != nil {
+			c.JSON(errors.HTTPResponse(err, batchWriteItem))
+			return
+		}
+		res := gin.H{"UnprocessedItems": gin.H{}}
+		if capacity := consumedCapacity(batchWriteItem.ReturnConsumedCapacity, capacityUnits); capacity != nil {
+			res["ConsumedCapacity"] = capacity
+		}
+		c.JSON(http.StatusOK, res)
+	}
+}
 
-			var deleteData models.BulkDelete
-			deleteData.TableName = key
+// DoBatchWriteItem writes and deletes the items of a BatchWriteItem request
+// and returns the write capacity units each table used
+func DoBatchWriteItem(ctx context.Context, batchWriteItem models.BatchWriteItem) (map[string]float64, error) {
+	if err := validateBatchWriteConditions(batchWriteItem); err != nil {
+		return nil, err
+	}
+	if err := validateReturnConsumedCapacity(batchWriteItem.ReturnConsumedCapacity); err != nil {
+		return nil, err
+	}
+	capacityUnits := make(map[string]float64)
+	for key, value := range batchWriteItem.RequestItems {
+		if allow := services.MayIReadOrWrite(key, true, "BatchWriteItem"); !allow {
+			return nil, ErrNotAllowed
+		}
+		if err := services.CheckCapacity(key, true); err != nil {
+			return nil, err
+		}
+		var putData models.BatchMetaUpdate
+		putData.TableName = key
 
-			for _, v := range value {
-				if v.PutReq.Item != nil {
-					putData.DynamoObject = append(putData.DynamoObject, v.PutReq.Item)
-				}
+		var deleteData models.BulkDelete
+		deleteData.TableName = key
 
-				if v.DelReq.Key != nil {
-					deleteData.DynamoObject = append(deleteData.DynamoObject, v.DelReq.Key)
-				}
+		for _, v := range value {
+			if v.PutReq.Item != nil {
+				putData.DynamoObject = append(putData.DynamoObject, v.PutReq.Item)
 			}
 
-			if putData.DynamoObject != nil {
-				err = batchUpdateItems(c.Request.Context(), putData)
-				if err != nil {
-					c.JSON(errors.HTTPResponse(err, batchWriteItem))
-					return
-				}
+			if v.DelReq.Key != nil {
+				deleteData.DynamoObject = append(deleteData.DynamoObject, v.DelReq.Key)
 			}
+		}
+
+		if putData.DynamoObject != nil {
+			units, err := batchUpdateItems(ctx, putData)
+			if err != nil {
+				return nil, err
+			}
+			capacityUnits[key] += units
+			services.ConsumeCapacity(key, true, units)
+		}
 
-			if deleteData.DynamoObject != nil {
-				err = batchDeleteItems(c.Request.Context(), deleteData)
-				if err != nil {
-					c.JSON(errors.HTTPResponse(err, batchWriteItem))
-					return
-				}
+		if deleteData.DynamoObject != nil {
+			units, err := batchDeleteItems(ctx, deleteData)
+			if err != nil {
+				return nil, err
 			}
+			capacityUnits[key] += units
+			services.ConsumeCapacity(key, true, units)
 		}
-		c.JSON(http.StatusOK, []gin.H{})
 	}
+	return capacityUnits, nil
 }
 
-func batchDeleteItems(con context.Context, bulkDelete models.BulkDelete) error {
+// batchDeleteItems deletes the items and returns the write capacity units of
+// the deletes, sized by their keys
+func batchDeleteItems(con context.Context, bulkDelete models.BulkDelete) (float64, error) {
 	var err error
 	bulkDelete.PrimaryKeyMapArray, err = ConvertDynamoArrayToMapArray(bulkDelete.TableName, bulkDelete.DynamoObject)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	err = services.BatchDelete(con, bulkDelete.TableName, bulkDelete.PrimaryKeyMapArray)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	return services.WriteCapacityUnits(bulkDelete.PrimaryKeyMapArray), nil
 }
 
-func batchUpdateItems(con context.Context, batchMetaUpdate models.BatchMetaUpdate) error {
+// batchUpdateItems writes the items and returns their write capacity units
+func batchUpdateItems(con context.Context, batchMetaUpdate models.BatchMetaUpdate) (float64, error) {
 	var err error
 	batchMetaUpdate.ArrAttrMap, err = ConvertDynamoArrayToMapArray(batchMetaUpdate.TableName, batchMetaUpdate.DynamoObject)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	err = services.BatchPut(con, batchMetaUpdate.TableName, batchMetaUpdate.ArrAttrMap)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	return services.WriteCapacityUnits(batchMetaUpdate.ArrAttrMap), nil
 }
@@ -0,0 +1,280 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/service/services"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
+	"github.com/gin-gonic/gin"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func newTestContext(headers map[string]string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest(http.MethodPost, "/v1/Query", nil)
+	for k, v := range headers {
+		c.Request.Header.Set(k, v)
+	}
+	return c
+}
+
+func TestFlatItemsResponse(t *testing.T) {
+	tests := []struct {
+		testName  string
+		headers   map[string]string
+		flatItems bool
+		want      bool
+	}{
+		{"v1 client with default config", nil, false, false},
+		{"v1 client with FlatItems enabled", nil, true, true},
+		{"X-Amz-Target request", map[string]string{"X-Amz-Target": "DynamoDB_20120810.Query"}, false, true},
+	}
+
+	for _, tc := range tests {
+		config.ConfigurationMap.FlatItems = tc.flatItems
+		got := flatItemsResponse(newTestContext(tc.headers))
+		assert.Equal(t, got, tc.want)
+	}
+	config.ConfigurationMap.FlatItems = false
+}
+
+func TestIsCountSelect(t *testing.T) {
+	tests := []struct {
+		testName    string
+		selectValue string
+		want        bool
+	}{
+		{"upper case", "COUNT", true},
+		{"lower case", "count", true},
+		{"mixed case", "Count", true},
+		{"other select", "ALL_ATTRIBUTES", false},
+		{"empty", "", false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, isCountSelect(tc.selectValue), tc.want)
+	}
+}
+
+func TestBatchWriteItemResponse(t *testing.T) {
+	tests := []struct {
+		testName string
+		body     string
+		want     string
+	}{
+		{"no consumed capacity", `{"RequestItems": {}}`, `{"UnprocessedItems":{}}`},
+		{"total consumed capacity", `{"RequestItems": {}, "ReturnConsumedCapacity": "TOTAL"}`, `{"ConsumedCapacity":[],"UnprocessedItems":{}}`},
+	}
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/v1/BatchWriteItem", bytes.NewBufferString(tc.body))
+		BatchWriteItem(c)
+		assert.Equal(t, w.Code, http.StatusOK)
+		assert.Equal(t, w.Body.String(), tc.want)
+	}
+}
+
+func TestConsistentReadContext(t *testing.T) {
+	defer func() {
+		models.ConfigController.ConsistentRead = map[string]bool{}
+		config.ConfigurationMap.ConsistentRead = nil
+	}()
+	strong, eventual := true, false
+	config.ConfigurationMap.ConsistentRead = &eventual
+	models.ConfigController.ConsistentRead = map[string]bool{"strong_table": true}
+
+	tests := []struct {
+		testName       string
+		tableName      string
+		consistentRead *bool
+		want           bool
+	}{
+		{"global default", "employee", nil, false},
+		{"table default", "strong_table", nil, true},
+		{"request", "employee", &strong, true},
+	}
+	for _, tc := range tests {
+		ctx := consistentReadContext(context.Background(), tc.tableName, tc.consistentRead)
+		assert.Equal(t, storage.ConsistentReads(ctx), tc.want)
+	}
+}
+
+func TestBatchGetConsistentReadPerTable(t *testing.T) {
+	body := `{"RequestItems": {
+		"employee": {"Keys": [{"emp_id": {"N": "1"}}], "ConsistentRead": true},
+		"department": {"Keys": [{"d_id": {"N": "1"}}], "ConsistentRead": false},
+		"project": {"Keys": [{"p_id": {"N": "1"}}]}
+	}}`
+	var batchGetMeta models.BatchGetMeta
+	assert.Equal(t, json.Unmarshal([]byte(body), &batchGetMeta), nil)
+
+	tests := []struct {
+		testName string
+		table    string
+		want     bool
+	}{
+		{"consistent read", "employee", true},
+		{"eventually consistent read", "department", false},
+		{"default", "project", false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, services.IsConsistentRead(tc.table, batchGetMeta.RequestItems[tc.table].ConsistentRead), tc.want)
+	}
+}
+
+func TestConsumedCapacity(t *testing.T) {
+	units := map[string]float64{"employee": 2, "department": 0.5}
+	tests := []struct {
+		testName               string
+		returnConsumedCapacity string
+		want                   []gin.H
+	}{
+		{"not requested", "", nil},
+		{"none", "NONE", nil},
+		{
+			"total",
+			"TOTAL",
+			[]gin.H{
+				{"TableName": "department", "CapacityUnits": 0.5},
+				{"TableName": "employee", "CapacityUnits": float64(2)},
+			},
+		},
+		{
+			"indexes",
+			"INDEXES",
+			[]gin.H{
+				{"TableName": "department", "CapacityUnits": 0.5, "Table": gin.H{"CapacityUnits": 0.5}},
+				{"TableName": "employee", "CapacityUnits": float64(2), "Table": gin.H{"CapacityUnits": float64(2)}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, consumedCapacity(tc.returnConsumedCapacity, units), tc.want)
+	}
+	assert.Equal(t, validateReturnConsumedCapacity("TOTAL"), nil)
+	assert.Equal(t, validateReturnConsumedCapacity("ALL") != nil, true)
+}
+
+func TestQueryOutput(t *testing.T) {
+	res := map[string]interface{}{
+		"Count":            1,
+		"Items":            []map[string]interface{}{{"emp_id": float64(1), "first_name": "Marc"}},
+		"LastEvaluatedKey": map[string]interface{}{"emp_id": float64(1)},
+	}
+	got, err := queryOutput(res, true)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, got, map[string]interface{}{
+		"Count": 1,
+		"Items": []map[string]interface{}{
+			{"emp_id": map[string]interface{}{"N": "1"}, "first_name": map[string]interface{}{"S": "Marc"}},
+		},
+		"LastEvaluatedKey": map[string]interface{}{"emp_id": map[string]interface{}{"N": "1"}},
+	})
+}
+
+func TestResponseAttributeOrder(t *testing.T) {
+	res := map[string]interface{}{
+		"Count": 1,
+		"Items": []map[string]interface{}{
+			{"last_name": "Lamberti", "emp_id": float64(1), "verified": true, "address": "Shamli", "age": float64(10)},
+		},
+		"LastEvaluatedKey": nil,
+	}
+	output, err := queryOutput(res, true)
+	assert.Equal(t, err, nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.JSON(http.StatusOK, output)
+	assert.Equal(t, w.Body.String(), `{"Count":1,"Items":[{"address":{"S":"Shamli"},"age":{"N":"10"},"emp_id":{"N":"1"},"last_name":{"S":"Lamberti"},"verified":{"BOOL":true}}],"LastEvaluatedKey":null}`)
+}
+
+func TestBatchQueryValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/v1/BatchQuery", BatchQuery)
+	tooMany := make([]models.Query, maxBatchQueries+1)
+
+	tests := []struct {
+		testName   string
+		batchQuery models.BatchQuery
+		wantStatus int
+	}{
+		{"no queries", models.BatchQuery{}, http.StatusBadRequest},
+		{"too many queries", models.BatchQuery{Queries: tooMany}, http.StatusBadRequest},
+		{
+			"undefined placeholder",
+			models.BatchQuery{Queries: []models.Query{{TableName: "employee", RangeExp: "emp_id = :id"}}},
+			http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		body, _ := json.Marshal(tc.batchQuery)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/v1/BatchQuery", bytes.NewReader(body))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, w.Code, tc.wantStatus)
+	}
+}
+
+func TestBatchConditionalDeleteValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/v1/BatchConditionalDelete", BatchConditionalDelete)
+	tooMany := make([]models.Delete, maxConditionalDeletes+1)
+
+	tests := []struct {
+		testName    string
+		batchDelete models.BatchConditionalDelete
+		wantStatus  int
+	}{
+		{"no deletes", models.BatchConditionalDelete{TableName: "employee"}, http.StatusBadRequest},
+		{"too many deletes", models.BatchConditionalDelete{TableName: "employee", Deletes: tooMany}, http.StatusBadRequest},
+		{
+			"undefined placeholder",
+			models.BatchConditionalDelete{TableName: "employee", Deletes: []models.Delete{{ConditionExpression: "processed = :p"}}},
+			http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		body, _ := json.Marshal(tc.batchDelete)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/v1/BatchConditionalDelete", bytes.NewReader(body))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, w.Code, tc.wantStatus)
+	}
+}
+
+func TestConditionalDeleteResponses(t *testing.T) {
+	id1 := map[string]*dynamodb.AttributeValue{"emp_id": {N: aws.String("1")}}
+	id2 := map[string]*dynamodb.AttributeValue{"emp_id": {N: aws.String("2")}}
+	batchDelete := models.BatchConditionalDelete{TableName: "employee", Deletes: []models.Delete{{Key: id1}, {Key: id2}}}
+	responses := conditionalDeleteResponses(batchDelete, []error{nil, errors.New("ConditionalCheckFailedException")})
+	assert.Equal(t, len(responses), 2)
+	assert.Equal(t, responses[0], map[string]interface{}{"Key": id1, "Deleted": true})
+	assert.Equal(t, responses[1]["Deleted"], false)
+	assert.Equal(t, responses[1]["Error"].(map[string]interface{})["code"], "ConditionalCheckFailedException")
+}
@@ -0,0 +1,238 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"expvar"
+	"net/http"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/service/services"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// InitInternalAPI - routes for the admin apis
+func InitInternalAPI(g *gin.RouterGroup) {
+	r := g.Group("/internal")
+	r.POST("/purge-tombstones", RequireAdminToken, RejectWritesWhenReadOnly, PurgeTombstones)
+	r.POST("/read-only", RequireAdminToken, SetReadOnly)
+	r.POST("/scan-delete", RequireAdminToken, RejectWritesWhenReadOnly, ScanDelete)
+	r.POST("/segment-for-key", SegmentForKey)
+	r.POST("/table-count", TableCount)
+	r.POST("/validate-table", ValidateTable)
+	r.GET("/metrics", gin.WrapH(expvar.Handler()))
+}
+
+// PurgeTombstones removes expired tombstones of a soft delete table
+// @Description Permanently removes soft deleted items older than the table's TombstoneRetention
+// @Summary Purge tombstones of a table
+// @ID purge-tombstones
+// @Produce  json
+// @Success 200 {object} gin.H
+// @Param requestBody body models.PurgeTombstones true "Please add request body of type models.PurgeTombstones"
+// @Failure 500 {object} gin.H "{"errorMessage":"We had a problem with our server. Try again later.","errorCode":"E0001"}"
+// @Router /internal/purge-tombstones/ [post]
+func PurgeTombstones(c *gin.Context) {
+	defer PanicHandler(c)
+	defer c.Request.Body.Close()
+	var purge models.PurgeTombstones
+	if err := c.ShouldBindJSON(&purge); err != nil {
+		c.JSON(errors.New("ValidationException", err).HTTPResponse(purge))
+		return
+	}
+	logger.LogDebug(purge)
+	count, err := services.PurgeTombstones(c.Request.Context(), purge.TableName)
+	if err != nil {
+		c.JSON(errors.HTTPResponse(err, purge))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"TableName": purge.TableName, "PurgedCount": count})
+}
+
+// ScanDelete deletes the items of a table matching a filter, one page at a time
+// @Description Scans a page of the table with the FilterExpression and deletes the matching items in batches, each in a transaction. Resume with the returned LastEvaluatedKey until it is null.
+// @Summary Delete the items matching a filter
+// @ID scan-delete
+// @Produce  json
+// @Success 200 {object} gin.H
+// @Param requestBody body models.ScanDelete true "Please add request body of type models.ScanDelete"
+// @Failure 500 {object} gin.H "{"errorMessage":"We had a problem with our server. Try again later.","errorCode":"E0001"}"
+// @Router /internal/scan-delete/ [post]
+func ScanDelete(c *gin.Context) {
+	defer PanicHandler(c)
+	defer c.Request.Body.Close()
+	var scanDelete models.ScanDelete
+	if err := c.ShouldBindJSON(&scanDelete); err != nil {
+		c.JSON(errors.New("ValidationException", err).HTTPResponse(scanDelete))
+		return
+	}
+	if scanDelete.FilterExpression == "" {
+		c.JSON(errors.New("ValidationException", "FilterExpression is required").WithParameter("FilterExpression").HTTPResponse(scanDelete))
+		return
+	}
+	if err := validateExpressionAttributes(scanDelete.ExpressionAttributeNames, scanDelete.ExpressionAttributeValues, scanDelete.FilterExpression); err != nil {
+		c.JSON(errors.HTTPResponse(err, scanDelete))
+		return
+	}
+	meta := models.ScanMeta{
+		TableName:                scanDelete.TableName,
+		Limit:                    scanDelete.Limit,
+		FilterExpression:         utils.NormalizeKeywords(scanDelete.FilterExpression),
+		ExpressionAttributeNames: ChangeColumnToSpannerExpressionName(scanDelete.TableName, scanDelete.ExpressionAttributeNames),
+	}
+	var err error
+	meta.StartFrom, err = ConvertDynamoToMap(scanDelete.TableName, scanDelete.ExclusiveStartKey)
+	if err != nil {
+		c.JSON(errors.New("ValidationException", err).WithParameter("ExclusiveStartKey").HTTPResponse(scanDelete))
+		return
+	}
+	meta.ExpressionAttributeMap, err = ConvertDynamoToMap(scanDelete.TableName, scanDelete.ExpressionAttributeValues)
+	if err != nil {
+		c.JSON(errors.New("ValidationException", err).WithParameter("ExpressionAttributeValues").HTTPResponse(scanDelete))
+		return
+	}
+	logger.LogInfo("scan-delete on", scanDelete.TableName, "with", scanDelete.FilterExpression)
+	deleted, lastKey, err := services.ScanDelete(c.Request.Context(), meta)
+	if err != nil {
+		c.JSON(errors.HTTPResponse(err, scanDelete))
+		return
+	}
+	res := ChangeQueryResponseColumn(scanDelete.TableName, map[string]interface{}{"LastEvaluatedKey": lastKey})
+	if res["LastEvaluatedKey"] != nil {
+		res["LastEvaluatedKey"], err = attributeValuesJSON(res["LastEvaluatedKey"])
+		if err != nil {
+			c.JSON(errors.HTTPResponse(err, "LastEvaluatedKeyChangeError"))
+			return
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"TableName": scanDelete.TableName, "DeletedCount": deleted, "LastEvaluatedKey": res["LastEvaluatedKey"]})
+}
+
+// SegmentForKey returns the segment a key is assigned to
+// @Description Returns the segment of the item's partition key out of TotalSegments
+// @Summary Segment for a key
+// @ID segment-for-key
+// @Produce  json
+// @Success 200 {object} gin.H
+// @Param requestBody body models.SegmentForKey true "Please add request body of type models.SegmentForKey"
+// @Failure 500 {object} gin.H "{"errorMessage":"We had a problem with our server. Try again later.","errorCode":"E0001"}"
+// @Router /internal/segment-for-key/ [post]
+func SegmentForKey(c *gin.Context) {
+	defer PanicHandler(c)
+	defer c.Request.Body.Close()
+	var segmentForKey models.SegmentForKey
+	if err := c.ShouldBindJSON(&segmentForKey); err != nil {
+		c.JSON(errors.New("ValidationException", err).HTTPResponse(segmentForKey))
+		return
+	}
+	logger.LogDebug(segmentForKey)
+	keyMap, err := ConvertDynamoToMap(segmentForKey.TableName, segmentForKey.Key)
+	if err != nil {
+		c.JSON(errors.New("ValidationException", err).HTTPResponse(segmentForKey))
+		return
+	}
+	segment, err := services.SegmentForKey(segmentForKey.TableName, keyMap, segmentForKey.TotalSegments)
+	if err != nil {
+		c.JSON(errors.HTTPResponse(err, segmentForKey))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"Segment": segment, "TotalSegments": segmentForKey.TotalSegments})
+}
+
+// TableCount returns the approximate number of items in a table
+// @Description Returns the item count of a table, refreshed every ItemCountRefreshInterval
+// @Summary Approximate item count of a table
+// @ID table-count
+// @Produce  json
+// @Success 200 {object} gin.H
+// @Param requestBody body models.TableCount true "Please add request body of type models.TableCount"
+// @Failure 500 {object} gin.H "{"errorMessage":"We had a problem with our server. Try again later.","errorCode":"E0001"}"
+// @Router /internal/table-count/ [post]
+func TableCount(c *gin.Context) {
+	defer PanicHandler(c)
+	defer c.Request.Body.Close()
+	var tableCount models.TableCount
+	if err := c.ShouldBindJSON(&tableCount); err != nil {
+		c.JSON(errors.New("ValidationException", err).HTTPResponse(tableCount))
+		return
+	}
+	logger.LogDebug(tableCount)
+	count, updatedAt, err := services.ApproximateItemCount(c.Request.Context(), tableCount.TableName)
+	if err != nil {
+		c.JSON(errors.HTTPResponse(err, tableCount))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"TableName": tableCount.TableName, "ItemCount": count, "LastUpdated": updatedAt.Unix()})
+}
+
+// ValidateTable reports the problems of the DynamoDB to Spanner mapping of a table
+// @Description Checks the Spanner table, its dynamodb_adapter_table_ddl rows and its key schema
+// @Summary Validate the mapping of a table
+// @ID validate-table
+// @Produce  json
+// @Success 200 {object} models.TableValidation
+// @Param requestBody body models.ValidateTable true "Please add request body of type models.ValidateTable"
+// @Failure 500 {object} gin.H "{"errorMessage":"We had a problem with our server. Try again later.","errorCode":"E0001"}"
+// @Router /internal/validate-table/ [post]
+func ValidateTable(c *gin.Context) {
+	defer PanicHandler(c)
+	defer c.Request.Body.Close()
+	var validateTable models.ValidateTable
+	if err := c.ShouldBindJSON(&validateTable); err != nil {
+		c.JSON(errors.New("ValidationException", err).HTTPResponse(validateTable))
+		return
+	}
+	logger.LogDebug(validateTable)
+	report, err := services.ValidateTable(c.Request.Context(), validateTable.TableName)
+	if err != nil {
+		c.JSON(errors.HTTPResponse(err, validateTable))
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// SetReadOnly turns the read-only mode on or off
+// @Description While read-only, writes fail with ServiceUnavailable and /readyz answers 503
+// @Summary Toggle the read-only mode
+// @ID read-only
+// @Produce  json
+// @Success 200 {object} gin.H
+// @Param requestBody body models.ReadOnly true "Please add request body of type models.ReadOnly"
+// @Failure 500 {object} gin.H "{"errorMessage":"We had a problem with our server. Try again later.","errorCode":"E0001"}"
+// @Router /internal/read-only/ [post]
+func SetReadOnly(c *gin.Context) {
+	defer PanicHandler(c)
+	defer c.Request.Body.Close()
+	var readOnly models.ReadOnly
+	if err := c.ShouldBindJSON(&readOnly); err != nil {
+		c.JSON(errors.New("ValidationException", err).HTTPResponse(readOnly))
+		return
+	}
+	logger.LogInfo("read-only mode set to", readOnly.Enabled)
+	services.SetReadOnly(readOnly.Enabled)
+	c.JSON(http.StatusOK, gin.H{"ReadOnly": services.IsReadOnly()})
+}
+
+// Readyz reports whether the adapter accepts writes, for load balancers
+func Readyz(c *gin.Context) {
+	if services.IsReadOnly() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "read-only", "ReadOnly": true})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ready", "ReadOnly": false})
+}
@@ -0,0 +1,249 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+)
+
+// comparisonOperators maps the legacy ComparisonOperator to its SQL operator
+var comparisonOperators = map[string]string{"EQ": "=", "NE": "<>", "LE": "<=", "LT": "<", "GE": ">=", "GT": ">"}
+
+// legacyFilterValueName returns the placeholder of the i-th legacy filter value.
+// The trailing underscore keeps one placeholder from being a prefix of another.
+func legacyFilterValueName(i int) string {
+	return ":__legacyFilter" + strconv.Itoa(i) + "_"
+}
+
+// legacyExpectedValueName returns the placeholder of the i-th legacy Expected value
+func legacyExpectedValueName(i int) string {
+	return ":__legacyExpected" + strconv.Itoa(i) + "_"
+}
+
+// ConvertLegacyFilter converts a ScanFilter or QueryFilter into a filter
+// expression along with the values of its placeholders
+func ConvertLegacyFilter(tableName string, filter map[string]*dynamodb.Condition) (string, map[string]interface{}, error) {
+	attrs := make([]string, 0, len(filter))
+	for attr := range filter {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil {
+		return "", nil, err
+	}
+	colDDL := models.TableDDL[strings.ReplaceAll(tableConf.ActualTable, "-", "_")]
+
+	values := map[string]interface{}{}
+	conditions := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		condition := filter[attr]
+		if condition == nil || condition.ComparisonOperator == nil {
+			return "", nil, errors.New("ValidationException", "ComparisonOperator is required for", attr)
+		}
+		col := attr
+		if spannerCol, ok := models.ColumnToOriginalCol[attr]; ok {
+			col = spannerCol
+		}
+		names := make([]string, len(condition.AttributeValueList))
+		for i, av := range condition.AttributeValueList {
+			names[i] = legacyFilterValueName(len(values))
+			values[names[i]] = convertFrom(av, tableName)
+		}
+		operator := strings.ToUpper(*condition.ComparisonOperator)
+		argErr := errors.New("ValidationException", "invalid number of arguments for ComparisonOperator", operator, attr)
+		switch operator {
+		case "EQ", "NE", "LE", "LT", "GE", "GT":
+			if len(names) != 1 {
+				return "", nil, argErr
+			}
+			conditions = append(conditions, col+" "+comparisonOperators[operator]+" "+names[0])
+		case "BEGINS_WITH":
+			if len(names) != 1 {
+				return "", nil, argErr
+			}
+			conditions = append(conditions, "STARTS_WITH("+col+", "+names[0]+")")
+		case "CONTAINS":
+			if len(names) != 1 {
+				return "", nil, argErr
+			}
+			if colDDL[col] != "STRING(MAX)" {
+				return "", nil, errors.New("ValidationException", "CONTAINS is only supported on string attributes", attr)
+			}
+			conditions = append(conditions, "STRPOS("+col+", "+names[0]+") > 0")
+		case "BETWEEN":
+			if len(names) != 2 {
+				return "", nil, argErr
+			}
+			conditions = append(conditions, col+" BETWEEN "+names[0]+" AND "+names[1])
+		case "IN":
+			if len(names) == 0 {
+				return "", nil, argErr
+			}
+			conditions = append(conditions, col+" IN ("+strings.Join(names, ", ")+")")
+		case "NULL":
+			if len(names) != 0 {
+				return "", nil, argErr
+			}
+			conditions = append(conditions, col+" IS NULL")
+		case "NOT_NULL":
+			if len(names) != 0 {
+				return "", nil, argErr
+			}
+			conditions = append(conditions, col+" IS NOT NULL")
+		default:
+			return "", nil, errors.New("ValidationException", "unsupported ComparisonOperator", operator)
+		}
+	}
+	return strings.Join(conditions, " AND "), values, nil
+}
+
+// applyLegacyFilter replaces the filter expression with the converted legacy
+// filter, which can not be combined with a FilterExpression and is held to
+// the operator limit of the expressions
+func applyLegacyFilter(tableName, filterName string, filter map[string]*dynamodb.Condition, filterExp string, values map[string]interface{}) (string, map[string]interface{}, error) {
+	if len(filter) == 0 {
+		return filterExp, values, nil
+	}
+	if filterExp != "" {
+		return "", nil, errors.New("ValidationException", "Can not use both expression and non-expression parameters in the same request: Non-expression parameters: {"+filterName+"} Expression parameters: {FilterExpression}")
+	}
+	filterExp, filterValues, err := ConvertLegacyFilter(tableName, filter)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := validateExpressionOperators(filterExp); err != nil {
+		return "", nil, err
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	for k, v := range filterValues {
+		values[k] = v
+	}
+	return filterExp, values, nil
+}
+
+// ConvertLegacyExpected converts the Expected map of a write into a condition
+// expression along with the values of its placeholders.
+// Exists:false and NULL check that the attribute is missing, NOT_NULL that it is present
+func ConvertLegacyExpected(tableName string, expected map[string]*dynamodb.ExpectedAttributeValue, conditionalOperator string) (string, map[string]interface{}, error) {
+	joinWith := " AND "
+	switch strings.ToUpper(conditionalOperator) {
+	case "", "AND":
+	case "OR":
+		joinWith = " OR "
+	default:
+		return "", nil, errors.New("ValidationException", "invalid ConditionalOperator", conditionalOperator)
+	}
+	attrs := make([]string, 0, len(expected))
+	for attr := range expected {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+
+	values := map[string]interface{}{}
+	conditions := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		exp := expected[attr]
+		if exp == nil {
+			return "", nil, errors.New("ValidationException", "Expected condition is empty for", attr)
+		}
+		col := attr
+		if spannerCol, ok := models.ColumnToOriginalCol[attr]; ok {
+			col = spannerCol
+		}
+		if exp.ComparisonOperator == nil {
+			if exp.Exists != nil && !*exp.Exists {
+				if exp.Value != nil {
+					return "", nil, errors.New("ValidationException", "Value can not be used when Exists is false for", attr)
+				}
+				conditions = append(conditions, "attribute_not_exists("+col+")")
+				continue
+			}
+			if exp.Value == nil {
+				return "", nil, errors.New("ValidationException", "Value must be provided when Exists is true for", attr)
+			}
+			name := legacyExpectedValueName(len(values))
+			values[name] = convertFrom(exp.Value, tableName)
+			conditions = append(conditions, col+" = "+name)
+			continue
+		}
+		if exp.Exists != nil {
+			return "", nil, errors.New("ValidationException", "Exists can not be used with ComparisonOperator for", attr)
+		}
+		avs := exp.AttributeValueList
+		if exp.Value != nil {
+			avs = append([]*dynamodb.AttributeValue{exp.Value}, avs...)
+		}
+		operator := strings.ToUpper(*exp.ComparisonOperator)
+		argErr := errors.New("ValidationException", "invalid number of arguments for ComparisonOperator", operator, attr)
+		switch operator {
+		case "EQ", "NE", "LE", "LT", "GE", "GT":
+			if len(avs) != 1 {
+				return "", nil, argErr
+			}
+			name := legacyExpectedValueName(len(values))
+			values[name] = convertFrom(avs[0], tableName)
+			conditions = append(conditions, col+" "+comparisonOperators[operator]+" "+name)
+		case "NULL":
+			if len(avs) != 0 {
+				return "", nil, argErr
+			}
+			conditions = append(conditions, "attribute_not_exists("+col+")")
+		case "NOT_NULL":
+			if len(avs) != 0 {
+				return "", nil, argErr
+			}
+			conditions = append(conditions, "attribute_exists("+col+")")
+		default:
+			return "", nil, errors.New("ValidationException", "unsupported ComparisonOperator", operator)
+		}
+	}
+	return strings.Join(conditions, joinWith), values, nil
+}
+
+// applyLegacyExpected replaces the condition expression with the converted
+// Expected map, which can not be combined with a ConditionExpression and is
+// held to the operator limit of the expressions
+func applyLegacyExpected(tableName string, expected map[string]*dynamodb.ExpectedAttributeValue, conditionalOperator, conditionExp string, values map[string]interface{}) (string, map[string]interface{}, error) {
+	if len(expected) == 0 {
+		return conditionExp, values, nil
+	}
+	if conditionExp != "" {
+		return "", nil, errors.New("ValidationException", "Can not use both expression and non-expression parameters in the same request: Non-expression parameters: {Expected} Expression parameters: {ConditionExpression}")
+	}
+	conditionExp, expectedValues, err := ConvertLegacyExpected(tableName, expected, conditionalOperator)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := validateExpressionOperators(conditionExp); err != nil {
+		return "", nil, err
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	for k, v := range expectedValues {
+		values[k] = v
+	}
+	return conditionExp, values, nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/utils"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func legacyCondition(operator string, values ...*dynamodb.AttributeValue) *dynamodb.Condition {
+	return &dynamodb.Condition{ComparisonOperator: aws.String(operator), AttributeValueList: values}
+}
+
+func TestConvertLegacyFilter(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{
+		"employee": {PartitionKey: "emp_id", ActualTable: "employee"},
+	}
+	models.TableDDL["employee"] = map[string]string{"emp_id": "FLOAT64", "first_name": "STRING(MAX)", "age": "FLOAT64"}
+	tests := []struct {
+		testName   string
+		filter     map[string]*dynamodb.Condition
+		want       string
+		wantValues map[string]interface{}
+		wantErr    bool
+	}{
+		{
+			"comparison",
+			map[string]*dynamodb.Condition{"age": legacyCondition("GE", &dynamodb.AttributeValue{N: aws.String("20")})},
+			"age >= :__legacyFilter0_",
+			map[string]interface{}{":__legacyFilter0_": float64(20)},
+			false,
+		},
+		{
+			"between and begins_with sorted by attribute",
+			map[string]*dynamodb.Condition{
+				"first_name": legacyCondition("begins_with", &dynamodb.AttributeValue{S: aws.String("Al")}),
+				"age":        legacyCondition("BETWEEN", &dynamodb.AttributeValue{N: aws.String("20")}, &dynamodb.AttributeValue{N: aws.String("30")}),
+			},
+			"age BETWEEN :__legacyFilter0_ AND :__legacyFilter1_ AND STARTS_WITH(first_name, :__legacyFilter2_)",
+			map[string]interface{}{":__legacyFilter0_": float64(20), ":__legacyFilter1_": float64(30), ":__legacyFilter2_": "Al"},
+			false,
+		},
+		{
+			"in and contains",
+			map[string]*dynamodb.Condition{
+				"emp_id":     legacyCondition("IN", &dynamodb.AttributeValue{N: aws.String("1")}, &dynamodb.AttributeValue{N: aws.String("2")}),
+				"first_name": legacyCondition("CONTAINS", &dynamodb.AttributeValue{S: aws.String("li")}),
+			},
+			"emp_id IN (:__legacyFilter0_, :__legacyFilter1_) AND STRPOS(first_name, :__legacyFilter2_) > 0",
+			map[string]interface{}{":__legacyFilter0_": float64(1), ":__legacyFilter1_": float64(2), ":__legacyFilter2_": "li"},
+			false,
+		},
+		{
+			"contains on a number",
+			map[string]*dynamodb.Condition{"age": legacyCondition("CONTAINS", &dynamodb.AttributeValue{N: aws.String("2")})},
+			"",
+			nil,
+			true,
+		},
+		{
+			"wrong number of values",
+			map[string]*dynamodb.Condition{"age": legacyCondition("EQ")},
+			"",
+			nil,
+			true,
+		},
+		{
+			"null checks",
+			map[string]*dynamodb.Condition{"age": legacyCondition("NULL"), "first_name": legacyCondition("NOT_NULL")},
+			"age IS NULL AND first_name IS NOT NULL",
+			map[string]interface{}{},
+			false,
+		},
+		{
+			"null check with a value",
+			map[string]*dynamodb.Condition{"age": legacyCondition("NOT_NULL", &dynamodb.AttributeValue{N: aws.String("2")})},
+			"",
+			nil,
+			true,
+		},
+		{
+			"unsupported operator",
+			map[string]*dynamodb.Condition{"age": legacyCondition("NOT_CONTAINS", &dynamodb.AttributeValue{N: aws.String("2")})},
+			"",
+			nil,
+			true,
+		},
+	}
+	for _, tc := range tests {
+		got, values, err := ConvertLegacyFilter("employee", tc.filter)
+		assert.Equal(t, err != nil, tc.wantErr)
+		assert.Equal(t, got, tc.want)
+		if !tc.wantErr {
+			assert.Equal(t, values, tc.wantValues)
+		}
+	}
+}
+
+func TestApplyLegacyFilter(t *testing.T) {
+	filter := map[string]*dynamodb.Condition{"age": legacyCondition("EQ", &dynamodb.AttributeValue{N: aws.String("20")})}
+
+	_, _, err := applyLegacyFilter("employee", "ScanFilter", filter, "age > :a", nil)
+	assert.NotEqual(t, err, nil)
+
+	filterExp, values, err := applyLegacyFilter("employee", "ScanFilter", nil, "age > :a", map[string]interface{}{":a": float64(1)})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, filterExp, "age > :a")
+	assert.Equal(t, values, map[string]interface{}{":a": float64(1)})
+
+	defer func() { config.ConfigurationMap.MaxExpressionOperators = 0 }()
+	config.ConfigurationMap.MaxExpressionOperators = 3
+	filter = map[string]*dynamodb.Condition{
+		"age":  legacyCondition("EQ", &dynamodb.AttributeValue{N: aws.String("20")}),
+		"name": legacyCondition("NOT_NULL"),
+	}
+	_, _, err = applyLegacyFilter("employee", "ScanFilter", filter, "", nil)
+	assert.Equal(t, err, nil)
+	filter["city"] = legacyCondition("EQ", &dynamodb.AttributeValue{S: aws.String("Pune")})
+	_, _, err = applyLegacyFilter("employee", "ScanFilter", filter, "", nil)
+	assert.NotEqual(t, err, nil)
+
+	expected := map[string]*dynamodb.ExpectedAttributeValue{
+		"age":  {Value: &dynamodb.AttributeValue{N: aws.String("20")}},
+		"name": {Exists: aws.Bool(false)},
+	}
+	_, _, err = applyLegacyExpected("employee", expected, "", "", nil)
+	assert.Equal(t, err, nil)
+	expected["city"] = &dynamodb.ExpectedAttributeValue{Value: &dynamodb.AttributeValue{S: aws.String("Pune")}}
+	_, _, err = applyLegacyExpected("employee", expected, "", "", nil)
+	assert.NotEqual(t, err, nil)
+}
+
+func TestConvertLegacyExpected(t *testing.T) {
+	tests := []struct {
+		testName            string
+		expected            map[string]*dynamodb.ExpectedAttributeValue
+		conditionalOperator string
+		want                string
+		wantValues          map[string]interface{}
+		wantErr             bool
+	}{
+		{
+			"optimistic lock on an existing item",
+			map[string]*dynamodb.ExpectedAttributeValue{"version": {Value: &dynamodb.AttributeValue{N: aws.String("3")}}},
+			"",
+			"version = :__legacyExpected0_",
+			map[string]interface{}{":__legacyExpected0_": float64(3)},
+			false,
+		},
+		{
+			"optimistic lock on a new item",
+			map[string]*dynamodb.ExpectedAttributeValue{"emp_id": {Exists: aws.Bool(false)}, "version": {Exists: aws.Bool(false)}},
+			"",
+			"attribute_not_exists(emp_id) AND attribute_not_exists(version)",
+			map[string]interface{}{},
+			false,
+		},
+		{
+			"null operators joined with OR",
+			map[string]*dynamodb.ExpectedAttributeValue{
+				"age":     {ComparisonOperator: aws.String("NOT_NULL")},
+				"version": {ComparisonOperator: aws.String("NULL")},
+			},
+			"OR",
+			"attribute_exists(age) OR attribute_not_exists(version)",
+			map[string]interface{}{},
+			false,
+		},
+		{
+			"comparison operator",
+			map[string]*dynamodb.ExpectedAttributeValue{"version": {ComparisonOperator: aws.String("LT"), AttributeValueList: []*dynamodb.AttributeValue{{N: aws.String("3")}}}},
+			"",
+			"version < :__legacyExpected0_",
+			map[string]interface{}{":__legacyExpected0_": float64(3)},
+			false,
+		},
+		{
+			"exists without a value",
+			map[string]*dynamodb.ExpectedAttributeValue{"version": {Exists: aws.Bool(true)}},
+			"",
+			"",
+			nil,
+			true,
+		},
+		{
+			"not exists with a value",
+			map[string]*dynamodb.ExpectedAttributeValue{"version": {Exists: aws.Bool(false), Value: &dynamodb.AttributeValue{N: aws.String("3")}}},
+			"",
+			"",
+			nil,
+			true,
+		},
+		{
+			"invalid conditional operator",
+			map[string]*dynamodb.ExpectedAttributeValue{"version": {ComparisonOperator: aws.String("NULL")}},
+			"XOR",
+			"",
+			nil,
+			true,
+		},
+	}
+	for _, tc := range tests {
+		got, values, err := ConvertLegacyExpected("employee", tc.expected, tc.conditionalOperator)
+		assert.Equal(t, err != nil, tc.wantErr)
+		assert.Equal(t, got, tc.want)
+		if !tc.wantErr {
+			assert.Equal(t, values, tc.wantValues)
+		}
+	}
+}
+
+func TestApplyLegacyExpectedOptimisticLock(t *testing.T) {
+	expected := map[string]*dynamodb.ExpectedAttributeValue{
+		"emp_id":  {Exists: aws.Bool(true), Value: &dynamodb.AttributeValue{N: aws.String("1")}},
+		"version": {Value: &dynamodb.AttributeValue{N: aws.String("3")}},
+	}
+	_, _, err := applyLegacyExpected("employee", expected, "", "version = :v", nil)
+	assert.NotEqual(t, err, nil)
+
+	conditionExp, values, err := applyLegacyExpected("employee", expected, "", "", nil)
+	assert.Equal(t, err, nil)
+
+	tests := []struct {
+		testName string
+		stored   float64
+		want     bool
+	}{
+		{"version matches", 3, true},
+		{"version was bumped", 4, false},
+	}
+	for _, tc := range tests {
+		e, err := utils.CreateConditionExpression(conditionExp, values)
+		assert.Equal(t, err, nil)
+		e.ValueMap[e.Tokens[0]] = float64(1)
+		e.ValueMap[e.Tokens[1]] = tc.stored
+		got, _ := utils.EvaluateExpression(e)
+		assert.Equal(t, got, tc.want)
+	}
+}
@@ -15,13 +15,45 @@
 package v1
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/subtle"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
 	"runtime/debug"
+	"strings"
+	"time"
 
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/service/services"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
 	"github.com/gin-gonic/gin"
 )
 
+var (
+	defaultCORSMethods = []string{http.MethodPost}
+	defaultCORSHeaders = []string{"Content-Type", "X-Amz-Target"}
+)
+
+// DynamoDB limits an item to 400KB, the body of a single item write gets
+// room for the DynamoDB JSON encoding of the item and its expressions
+const itemWriteBodyLimit int64 = 1 << 20
+
+// defaultMaxRequestBodySize matches the 16MB limit of a BatchWriteItem request
+const defaultMaxRequestBodySize int64 = 16 << 20
+
+// readTimestampHeader holds the timestamp the Spanner reads of a read
+// request read at
+const readTimestampHeader = "X-Adapter-Read-Timestamp"
+
+// itemWriteActions are the single item writes limited to itemWriteBodyLimit
+var itemWriteActions = map[string]bool{"PutItem": true, "UpdateItem": true, "DeleteItem": true}
+
 // PanicHandler is global handler for all type of panic
 func PanicHandler(c *gin.Context) {
 	if e := recover(); e != nil {
@@ -30,3 +62,229 @@ func PanicHandler(c *gin.Context) {
 		c.JSON(errors.New("ServerInternalError", e, stack).HTTPResponse(e))
 	}
 }
+
+// RejectWritesWhenReadOnly aborts write requests with ServiceUnavailable
+// while the read-only mode is on
+func RejectWritesWhenReadOnly(c *gin.Context) {
+	if services.IsReadOnly() {
+		c.AbortWithStatusJSON(errors.New("ServiceUnavailable", "writes are disabled while the adapter is in read-only mode").HTTPResponse(c.Request.URL.Path))
+		return
+	}
+	c.Next()
+}
+
+// ReadTimestamp sets the X-Adapter-Read-Timestamp header of the responses of
+// read requests to the timestamp their Spanner reads read at, the oldest one
+// when there were several, so clients of stale reads know how fresh the
+// items are. Requests which did not read get no header.
+func ReadTimestamp(c *gin.Context) {
+	ctx := storage.WithReadTimestamp(c.Request.Context())
+	c.Request = c.Request.WithContext(ctx)
+	c.Writer = &readTimestampWriter{ResponseWriter: c.Writer, ctx: ctx}
+	c.Next()
+}
+
+// readTimestampWriter sets the read timestamp header right before the
+// response is written, once the reads are done
+type readTimestampWriter struct {
+	gin.ResponseWriter
+	ctx context.Context
+}
+
+func (w *readTimestampWriter) setHeader() {
+	if w.Written() {
+		return
+	}
+	if ts, ok := storage.ReadTimestamp(w.ctx); ok {
+		w.Header().Set(readTimestampHeader, ts.UTC().Format(time.RFC3339Nano))
+	}
+}
+
+func (w *readTimestampWriter) WriteHeaderNow() {
+	w.setHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *readTimestampWriter) Write(data []byte) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *readTimestampWriter) WriteString(s string) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *readTimestampWriter) Flush() {
+	w.setHeader()
+	w.ResponseWriter.Flush()
+}
+
+// RequireAdminToken aborts requests without an "Authorization: Bearer" header
+// holding the AdminToken with AccessDeniedException. Without an AdminToken the
+// routes it guards are disabled.
+func RequireAdminToken(c *gin.Context) {
+	token := config.ConfigurationMap.AdminToken
+	if token == "" {
+		c.AbortWithStatusJSON(errors.New("AccessDeniedException", "set AdminToken in the config to use", c.Request.URL.Path).HTTPResponse(c.Request.URL.Path))
+		return
+	}
+	given := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+		c.AbortWithStatusJSON(errors.New("AccessDeniedException", "invalid admin token").HTTPResponse(c.Request.URL.Path))
+		return
+	}
+	c.Next()
+}
+
+// CORSMiddleware allows browser requests from the CORSAllowedOrigins, "*"
+// allows every origin. Requests from other origins get no CORS headers.
+func CORSMiddleware() gin.HandlerFunc {
+	origins := map[string]bool{}
+	for _, origin := range config.ConfigurationMap.CORSAllowedOrigins {
+		origins[origin] = true
+	}
+	methods := config.ConfigurationMap.CORSAllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	headers := config.ConfigurationMap.CORSAllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+	allowMethods := strings.Join(methods, ", ")
+	allowHeaders := strings.Join(headers, ", ")
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		c.Writer.Header().Add("Vary", "Origin")
+		if !origins[origin] && !origins["*"] {
+			c.Next()
+			return
+		}
+		c.Header("Access-Control-Allow-Origin", origin)
+		if c.Request.Method == http.MethodOptions {
+			c.Header("Access-Control-Allow-Methods", allowMethods)
+			c.Header("Access-Control-Allow-Headers", allowHeaders)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
+
+// gzipResponseWriter compresses the response body
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	writer *gzip.Writer
+}
+
+// WriteHeader drops the Content-Length of the uncompressed body set by the
+// handler, which does not match the compressed one
+func (g *gzipResponseWriter) WriteHeader(code int) {
+	g.Header().Del("Content-Length")
+	g.ResponseWriter.WriteHeader(code)
+}
+
+func (g *gzipResponseWriter) Write(data []byte) (int, error) {
+	g.Header().Del("Content-Length")
+	return g.writer.Write(data)
+}
+
+func (g *gzipResponseWriter) WriteString(s string) (int, error) {
+	return g.Write([]byte(s))
+}
+
+// Flush sends the compressed data written so far, for the streamed responses
+func (g *gzipResponseWriter) Flush() {
+	g.writer.Flush()
+	g.ResponseWriter.Flush()
+}
+
+// gzipRequestBody closes the gzip reader along with the request body
+type gzipRequestBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (g gzipRequestBody) Close() error {
+	g.Reader.Close()
+	return g.body.Close()
+}
+
+// GzipMiddleware decompresses the request bodies sent with
+// Content-Encoding: gzip and compresses the responses of clients which send
+// Accept-Encoding: gzip
+func GzipMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strings.EqualFold(c.GetHeader("Content-Encoding"), "gzip") {
+			reader, err := gzip.NewReader(c.Request.Body)
+			if err != nil {
+				c.AbortWithStatusJSON(errors.New("ValidationException", "invalid gzip request body", err).HTTPResponse(nil))
+				return
+			}
+			c.Request.Body = gzipRequestBody{Reader: reader, body: c.Request.Body}
+			c.Request.Header.Del("Content-Encoding")
+			c.Request.Header.Del("Content-Length")
+			c.Request.ContentLength = -1
+		}
+		if !strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") {
+			c.Next()
+			return
+		}
+		c.Header("Content-Encoding", "gzip")
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		gz := gzip.NewWriter(c.Writer)
+		c.Writer = &gzipResponseWriter{ResponseWriter: c.Writer, writer: gz}
+		c.Writer.Header().Del("Content-Length")
+		defer gz.Close()
+		c.Next()
+	}
+}
+
+// RequestBodyLimit aborts requests with a body larger than their limit with
+// RequestEntityTooLarge, reading at most the limit into memory. Every request
+// is limited to MaxRequestBodySize and single item writes to itemWriteBodyLimit.
+func RequestBodyLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := requestBodyLimit(c.Request.URL.Path)
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(requestTooLarge(limit))
+			return
+		}
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+		body, err := ioutil.ReadAll(io.LimitReader(c.Request.Body, limit+1))
+		if err != nil {
+			c.AbortWithStatusJSON(errors.New("ValidationException", "invalid request body", err).HTTPResponse(nil))
+			return
+		}
+		if int64(len(body)) > limit {
+			c.AbortWithStatusJSON(requestTooLarge(limit))
+			return
+		}
+		c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		c.Next()
+	}
+}
+
+// requestBodyLimit returns the body size limit of the request path
+func requestBodyLimit(urlPath string) int64 {
+	limit := config.ConfigurationMap.MaxRequestBodySize
+	if limit <= 0 {
+		limit = defaultMaxRequestBodySize
+	}
+	if itemWriteActions[path.Base(urlPath)] && limit > itemWriteBodyLimit {
+		limit = itemWriteBodyLimit
+	}
+	return limit
+}
+
+func requestTooLarge(limit int64) (int, interface{}) {
+	return errors.New("RequestEntityTooLarge", "the request body is larger than", limit, "bytes").HTTPResponse(nil)
+}
@@ -0,0 +1,251 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/service/services"
+	"github.com/gin-gonic/gin"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	config.ConfigurationMap.CORSAllowedOrigins = []string{"https://admin.example.com"}
+	defer func() {
+		config.ConfigurationMap.CORSAllowedOrigins = nil
+	}()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(CORSMiddleware())
+	r.POST("/v1/GetItem", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	tests := []struct {
+		testName    string
+		method      string
+		origin      string
+		wantStatus  int
+		wantOrigin  string
+		wantMethods string
+	}{
+		{"no origin", http.MethodPost, "", http.StatusOK, "", ""},
+		{"allowed origin", http.MethodPost, "https://admin.example.com", http.StatusOK, "https://admin.example.com", ""},
+		{"preflight from allowed origin", http.MethodOptions, "https://admin.example.com", http.StatusNoContent, "https://admin.example.com", "POST"},
+		{"other origin", http.MethodPost, "https://other.example.com", http.StatusOK, "", ""},
+		{"preflight from other origin", http.MethodOptions, "https://other.example.com", http.StatusNotFound, "", ""},
+	}
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(tc.method, "/v1/GetItem", nil)
+		if tc.origin != "" {
+			req.Header.Set("Origin", tc.origin)
+		}
+		r.ServeHTTP(w, req)
+		assert.Equal(t, w.Code, tc.wantStatus)
+		assert.Equal(t, w.Header().Get("Access-Control-Allow-Origin"), tc.wantOrigin)
+		assert.Equal(t, w.Header().Get("Access-Control-Allow-Methods"), tc.wantMethods)
+	}
+}
+
+func TestGzipMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(GzipMiddleware())
+	r.POST("/v1/Query", func(c *gin.Context) {
+		body, _ := ioutil.ReadAll(c.Request.Body)
+		c.Header("Content-Length", strconv.Itoa(len(body)))
+		c.String(http.StatusOK, string(body))
+		c.Writer.Flush()
+	})
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	gz.Write([]byte(`{"TableName":"employee"}`))
+	gz.Close()
+
+	tests := []struct {
+		testName       string
+		body           []byte
+		requestGzip    bool
+		acceptGzip     bool
+		wantStatus     int
+		wantCompressed bool
+		want           string
+	}{
+		{"plain request and response", []byte(`{"TableName":"employee"}`), false, false, http.StatusOK, false, `{"TableName":"employee"}`},
+		{"gzip request", gzipped.Bytes(), true, false, http.StatusOK, false, `{"TableName":"employee"}`},
+		{"gzip response", []byte(`{"TableName":"employee"}`), false, true, http.StatusOK, true, `{"TableName":"employee"}`},
+		{"gzip request and response", gzipped.Bytes(), true, true, http.StatusOK, true, `{"TableName":"employee"}`},
+		{"invalid gzip request", []byte("not gzip"), true, false, http.StatusBadRequest, false, ""},
+	}
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/v1/Query", bytes.NewReader(tc.body))
+		if tc.requestGzip {
+			req.Header.Set("Content-Encoding", "gzip")
+		}
+		if tc.acceptGzip {
+			req.Header.Set("Accept-Encoding", "gzip")
+		}
+		r.ServeHTTP(w, req)
+		assert.Equal(t, w.Code, tc.wantStatus)
+		if tc.wantStatus != http.StatusOK {
+			continue
+		}
+		assert.Equal(t, w.Header().Get("Content-Encoding") == "gzip", tc.wantCompressed)
+		body := w.Body.Bytes()
+		if tc.wantCompressed {
+			assert.Equal(t, w.Result().Header.Get("Content-Length"), "")
+			reader, err := gzip.NewReader(w.Body)
+			assert.Equal(t, err, nil)
+			body, _ = ioutil.ReadAll(reader)
+		}
+		assert.Equal(t, string(body), tc.want)
+	}
+}
+
+func TestRejectWritesWhenReadOnly(t *testing.T) {
+	defer services.SetReadOnly(false)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/readyz", Readyz)
+	r.POST("/v1/PutItem", RejectWritesWhenReadOnly, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+	r.POST("/v1/GetItem", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	tests := []struct {
+		testName   string
+		readOnly   bool
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{"write", false, http.MethodPost, "/v1/PutItem", http.StatusOK},
+		{"ready", false, http.MethodGet, "/readyz", http.StatusOK},
+		{"write while read-only", true, http.MethodPost, "/v1/PutItem", http.StatusServiceUnavailable},
+		{"read while read-only", true, http.MethodPost, "/v1/GetItem", http.StatusOK},
+		{"not ready while read-only", true, http.MethodGet, "/readyz", http.StatusServiceUnavailable},
+	}
+	for _, tc := range tests {
+		services.SetReadOnly(tc.readOnly)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(tc.method, tc.path, nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, w.Code, tc.wantStatus)
+	}
+}
+
+func TestRequireAdminToken(t *testing.T) {
+	defer func() { config.ConfigurationMap.AdminToken = "" }()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/v1/internal/scan-delete", RequireAdminToken, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	tests := []struct {
+		testName      string
+		adminToken    string
+		authorization string
+		wantStatus    int
+	}{
+		{"no admin token configured", "", "Bearer secret", http.StatusForbidden},
+		{"empty token with no admin token", "", "Bearer ", http.StatusForbidden},
+		{"valid token", "secret", "Bearer secret", http.StatusOK},
+		{"wrong token", "secret", "Bearer other", http.StatusForbidden},
+		{"no authorization header", "secret", "", http.StatusForbidden},
+	}
+	for _, tc := range tests {
+		config.ConfigurationMap.AdminToken = tc.adminToken
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/v1/internal/scan-delete", nil)
+		if tc.authorization != "" {
+			req.Header.Set("Authorization", tc.authorization)
+		}
+		r.ServeHTTP(w, req)
+		assert.Equal(t, w.Code, tc.wantStatus)
+	}
+}
+
+func TestAdminRoutesRequireToken(t *testing.T) {
+	defer func() { config.ConfigurationMap.AdminToken = "" }()
+	config.ConfigurationMap.AdminToken = "secret"
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	InitInternalAPI(r.Group("/v1"))
+
+	for _, path := range []string{"/v1/internal/purge-tombstones", "/v1/internal/scan-delete", "/v1/internal/read-only"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(`{"TableName": "employee", "Enabled": true}`))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, w.Code, http.StatusForbidden)
+		assert.Equal(t, strings.Contains(w.Body.String(), "AccessDeniedException"), true)
+	}
+	assert.Equal(t, services.IsReadOnly(), false)
+}
+
+func TestRequestBodyLimit(t *testing.T) {
+	defer func() { config.ConfigurationMap.MaxRequestBodySize = 0 }()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestBodyLimit())
+	echo := func(c *gin.Context) {
+		body, _ := ioutil.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, "%d", len(body))
+	}
+	r.POST("/v1/PutItem", echo)
+	r.POST("/v1/BatchWriteItem", echo)
+
+	tests := []struct {
+		testName      string
+		maxSize       int64
+		path          string
+		size          int
+		contentLength bool
+		wantStatus    int
+	}{
+		{"batch within the default limit", 0, "/v1/BatchWriteItem", 2 << 20, true, http.StatusOK},
+		{"item write over the item limit", 0, "/v1/PutItem", int(itemWriteBodyLimit) + 1, true, http.StatusRequestEntityTooLarge},
+		{"item write over the item limit without a length", 0, "/v1/PutItem", int(itemWriteBodyLimit) + 1, false, http.StatusRequestEntityTooLarge},
+		{"batch within the configured limit", 2048, "/v1/BatchWriteItem", 2048, true, http.StatusOK},
+		{"batch over the configured limit", 2048, "/v1/BatchWriteItem", 2049, true, http.StatusRequestEntityTooLarge},
+		{"item write over the configured limit without a length", 2048, "/v1/PutItem", 2049, false, http.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range tests {
+		config.ConfigurationMap.MaxRequestBodySize = tc.maxSize
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, tc.path, bytes.NewReader(make([]byte, tc.size)))
+		if !tc.contentLength {
+			req.ContentLength = -1
+		}
+		r.ServeHTTP(w, req)
+		assert.Equal(t, w.Code, tc.wantStatus)
+		if tc.wantStatus == http.StatusOK {
+			assert.Equal(t, w.Body.String(), strconv.Itoa(tc.size))
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// sessionHeader holds the token of the session of a client
+const sessionHeader = "X-Dynamodb-Adapter-Session"
+
+// sessionWriteActions are the writes after which the reads of the session are
+// strongly consistent
+var sessionWriteActions = map[string]bool{"PutItem": true, "UpdateItem": true, "DeleteItem": true, "BatchWriteItem": true}
+
+// sessionCache remembers until when the sessions which wrote have to read
+// their own writes. Expired sessions are swept at most once per window.
+type sessionCache struct {
+	mu        sync.Mutex
+	window    time.Duration
+	until     map[string]time.Time
+	lastSweep time.Time
+}
+
+func newSessionCache(window time.Duration) *sessionCache {
+	return &sessionCache{window: window, until: map[string]time.Time{}}
+}
+
+// wrote records a write of the session at now
+func (s *sessionCache) wrote(token string, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastSweep) >= s.window {
+		for t, until := range s.until {
+			if !now.Before(until) {
+				delete(s.until, t)
+			}
+		}
+		s.lastSweep = now
+	}
+	s.until[token] = now.Add(s.window)
+}
+
+// recent reports if the session wrote within the window before now
+func (s *sessionCache) recent(token string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	until, ok := s.until[token]
+	if ok && !now.Before(until) {
+		delete(s.until, token)
+		return false
+	}
+	return ok
+}
+
+var (
+	sessionsOnce sync.Once
+	sessions     *sessionCache
+)
+
+// readYourWritesSessions returns the sessions shared by the /v1 api and the
+// gRPC service, nil without a ReadYourWritesWindow
+func readYourWritesSessions() *sessionCache {
+	sessionsOnce.Do(func() {
+		window, err := time.ParseDuration(config.ConfigurationMap.ReadYourWritesWindow)
+		if err == nil && window > 0 {
+			sessions = newSessionCache(window)
+		}
+	})
+	return sessions
+}
+
+// SessionContext returns ctx with strongly consistent reads when the session
+// of token wrote within the ReadYourWritesWindow
+func SessionContext(ctx context.Context, token string) context.Context {
+	if sessions := readYourWritesSessions(); sessions != nil && token != "" && sessions.recent(token, time.Now()) {
+		return storage.WithConsistentReads(ctx)
+	}
+	return ctx
+}
+
+// SessionWrote records a successful write of the session of token
+func SessionWrote(token string) {
+	if sessions := readYourWritesSessions(); sessions != nil && token != "" {
+		sessions.wrote(token, time.Now())
+	}
+}
+
+// ReadYourWrites makes the reads of a session strongly consistent for the
+// ReadYourWritesWindow after its last successful write, so that a client
+// sending the same X-Dynamodb-Adapter-Session token sees its own writes.
+// Without a ReadYourWritesWindow the sessions are not tracked.
+func ReadYourWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(sessionHeader)
+		if token == "" || readYourWritesSessions() == nil {
+			c.Next()
+			return
+		}
+		if !sessionWriteActions[path.Base(c.Request.URL.Path)] {
+			c.Request = c.Request.WithContext(SessionContext(c.Request.Context(), token))
+			c.Next()
+			return
+		}
+		c.Next()
+		if c.Writer.Status() == http.StatusOK {
+			SessionWrote(token)
+		}
+	}
+}
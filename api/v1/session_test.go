@@ -0,0 +1,47 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestSessionCache(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	sessions := newSessionCache(5 * time.Second)
+	sessions.wrote("a", start)
+	tests := []struct {
+		testName string
+		token    string
+		at       time.Duration
+		want     bool
+	}{
+		{"unknown session", "b", time.Second, false},
+		{"within the window", "a", 4 * time.Second, true},
+		{"after the window", "a", 5 * time.Second, false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, sessions.recent(tc.token, start.Add(tc.at)), tc.want)
+	}
+	assert.Equal(t, len(sessions.until), 0)
+
+	sessions.wrote("a", start)
+	sessions.wrote("b", start.Add(6*time.Second))
+	assert.Equal(t, len(sessions.until), 1)
+	assert.Equal(t, sessions.recent("b", start.Add(10*time.Second)), true)
+}
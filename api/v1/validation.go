@@ -0,0 +1,169 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+)
+
+var (
+	attributeNameKeyRegexp  = regexp.MustCompile(`^#[A-Za-z0-9_]+$`)
+	attributeValueKeyRegexp = regexp.MustCompile(`^:[A-Za-z0-9_]+$`)
+	placeholderRegexp       = regexp.MustCompile(`[#:][A-Za-z0-9_]+`)
+	operatorRegexp          = regexp.MustCompile(`(?i)\b(AND|OR|NOT|BETWEEN|IN)\b|<>|<=|>=|=|<|>|\b(attribute_exists|attribute_not_exists|attribute_type|begins_with|contains|size)\s*\(`)
+)
+
+// defaultMaxExpressionOperators matches the limit of DynamoDB on the
+// operators and functions of an expression
+const defaultMaxExpressionOperators = 300
+
+// validateExpressionAttributes checks that every ExpressionAttributeNames key
+// is a #name and every ExpressionAttributeValues key is a :value placeholder,
+// that the placeholders used by the expressions are defined, that every
+// defined placeholder is used by one of them, as DynamoDB requires, that no
+// reserved word is used as an attribute name outside of a placeholder unless
+// AllowReservedWords is set, and that none of the expressions has more
+// operators and functions than MaxExpressionOperators. The expressions are
+// all the ones of the request.
+func validateExpressionAttributes(names map[string]string, values map[string]*dynamodb.AttributeValue, expressions ...string) error {
+	for k := range names {
+		if !attributeNameKeyRegexp.MatchString(k) {
+			return errors.New("ValidationException", `ExpressionAttributeNames contains invalid key: Syntax error; key: "`+k+`"`).WithParameter("ExpressionAttributeNames." + k)
+		}
+	}
+	for k := range values {
+		if !attributeValueKeyRegexp.MatchString(k) {
+			return errors.New("ValidationException", `ExpressionAttributeValues contains invalid key: Syntax error; key: "`+k+`"`).WithParameter("ExpressionAttributeValues." + k)
+		}
+	}
+	used := map[string]bool{}
+	for _, expression := range expressions {
+		for _, placeholder := range placeholderRegexp.FindAllString(expression, -1) {
+			used[placeholder] = true
+			if placeholder[0] == '#' {
+				if _, ok := names[placeholder]; !ok {
+					return errors.New("ValidationException", "An expression attribute name used in the document path is not defined; attribute name: "+placeholder).WithParameter("ExpressionAttributeNames." + placeholder)
+				}
+				continue
+			}
+			if _, ok := values[placeholder]; !ok {
+				return errors.New("ValidationException", "An expression attribute value used in expression is not defined; attribute value: "+placeholder).WithParameter("ExpressionAttributeValues." + placeholder)
+			}
+		}
+		if word := reservedWord(expression); word != "" && !config.ConfigurationMap.AllowReservedWords {
+			return errors.New("ValidationException", "Invalid expression: Attribute name is a reserved keyword; reserved keyword: "+word+". Use an ExpressionAttributeNames placeholder, e.g. #"+strings.ToLower(word))
+		}
+		if err := validateExpressionOperators(expression); err != nil {
+			return err
+		}
+	}
+	unusedNames := []string{}
+	for k := range names {
+		if !used[k] {
+			unusedNames = append(unusedNames, k)
+		}
+	}
+	if err := unusedPlaceholders("ExpressionAttributeNames", unusedNames); err != nil {
+		return err
+	}
+	unusedValues := []string{}
+	for k := range values {
+		if !used[k] {
+			unusedValues = append(unusedValues, k)
+		}
+	}
+	return unusedPlaceholders("ExpressionAttributeValues", unusedValues)
+}
+
+// unusedPlaceholders returns the error of the unused placeholders of the
+// ExpressionAttributeNames or ExpressionAttributeValues, nil when there are none
+func unusedPlaceholders(field string, unused []string) error {
+	if len(unused) == 0 {
+		return nil
+	}
+	sort.Strings(unused)
+	return errors.New("ValidationException", "Value provided in "+field+" unused in expressions: keys: {"+strings.Join(unused, ", ")+"}").WithParameter(field + "." + unused[0])
+}
+
+// expressionOperators counts the comparators, logical operators and functions
+// of an expression, ignoring its placeholders
+func expressionOperators(expression string) int {
+	return len(operatorRegexp.FindAllString(placeholderRegexp.ReplaceAllString(expression, "_"), -1))
+}
+
+// validateExpressionOperators rejects an expression with more operators and
+// functions than MaxExpressionOperators
+func validateExpressionOperators(expression string) error {
+	if count, limit := expressionOperators(expression), maxExpressionOperators(); count > limit {
+		return errors.New("ValidationException", "Invalid expression: The expression contains too many operators and functions; operator count:", count, "limit:", limit)
+	}
+	return nil
+}
+
+func maxExpressionOperators() int {
+	if limit := config.ConfigurationMap.MaxExpressionOperators; limit > 0 {
+		return limit
+	}
+	return defaultMaxExpressionOperators
+}
+
+// validateBatchWriteConditions rejects condition fields on the requests of a
+// BatchWriteItem, as DynamoDB does not support conditional batch writes
+func validateBatchWriteConditions(batchWriteItem models.BatchWriteItem) error {
+	for table, requests := range batchWriteItem.RequestItems {
+		for _, request := range requests {
+			for _, conditions := range []models.BatchWriteConditions{request.PutReq.BatchWriteConditions, request.DelReq.BatchWriteConditions} {
+				if conditionField := batchWriteConditionField(conditions); conditionField != "" {
+					return errors.New("ValidationException", "BatchWriteItem does not support "+conditionField+", found in a request for table", table)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateReturnConsumedCapacity checks ReturnConsumedCapacity is one of the
+// values DynamoDB takes
+func validateReturnConsumedCapacity(returnConsumedCapacity string) error {
+	switch returnConsumedCapacity {
+	case "", "NONE", "TOTAL", "INDEXES":
+		return nil
+	}
+	return errors.New("ValidationException", "ReturnConsumedCapacity must be one of INDEXES, TOTAL or NONE, got", returnConsumedCapacity).WithParameter("ReturnConsumedCapacity")
+}
+
+// batchWriteConditionField returns the name of the first condition field which is set
+func batchWriteConditionField(conditions models.BatchWriteConditions) string {
+	switch {
+	case conditions.ConditionExpression != "":
+		return "ConditionExpression"
+	case conditions.ConditionalOperator != "":
+		return "ConditionalOperator"
+	case conditions.Expected != nil:
+		return "Expected"
+	case conditions.ExpressionAttributeNames != nil:
+		return "ExpressionAttributeNames"
+	case conditions.ExpressionAttributeValues != nil:
+		return "ExpressionAttributeValues"
+	}
+	return ""
+}
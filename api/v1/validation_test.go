@@ -0,0 +1,147 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestValidateExpressionAttributes(t *testing.T) {
+	value := &dynamodb.AttributeValue{S: aws.String("v")}
+	tests := []struct {
+		testName   string
+		names      map[string]string
+		values     map[string]*dynamodb.AttributeValue
+		expression string
+		wantParam  string
+	}{
+		{"empty", nil, nil, "", ""},
+		{"valid placeholders", map[string]string{"#n": "name", "#first_2": "age"}, map[string]*dynamodb.AttributeValue{":v1": value}, "#n = :v1 AND attribute_exists(#first_2)", ""},
+		{"name without #", map[string]string{"n": "name"}, nil, "", "ExpressionAttributeNames.n"},
+		{"only the # prefix", map[string]string{"#": "name"}, nil, "", "ExpressionAttributeNames.#"},
+		{"value without :", nil, map[string]*dynamodb.AttributeValue{"v1": value}, "", "ExpressionAttributeValues.v1"},
+		{"value with # prefix", nil, map[string]*dynamodb.AttributeValue{"#v1": value}, "", "ExpressionAttributeValues.#v1"},
+		{"undefined name", nil, map[string]*dynamodb.AttributeValue{":v1": value}, "#n = :v1", "ExpressionAttributeNames.#n"},
+		{"undefined value", map[string]string{"#n": "name"}, nil, "#n = :v1", "ExpressionAttributeValues.:v1"},
+		{"unused name", map[string]string{"#n": "name", "#a": "age"}, map[string]*dynamodb.AttributeValue{":v1": value}, "#n = :v1", "ExpressionAttributeNames.#a"},
+		{"unused value", map[string]string{"#n": "name"}, map[string]*dynamodb.AttributeValue{":v1": value, ":v2": value}, "#n = :v1", "ExpressionAttributeValues.:v2"},
+		{"unused without expression", nil, map[string]*dynamodb.AttributeValue{":v1": value}, "", "ExpressionAttributeValues.:v1"},
+	}
+	for _, tc := range tests {
+		err := validateExpressionAttributes(tc.names, tc.values, tc.expression)
+		if tc.wantParam == "" {
+			assert.Equal(t, err, nil)
+			continue
+		}
+		e, ok := err.(*errors.Error)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, e.Parameter, tc.wantParam)
+	}
+}
+
+func TestUnusedPlaceholders(t *testing.T) {
+	value := &dynamodb.AttributeValue{S: aws.String("v")}
+	names := map[string]string{"#n": "name", "#a": "age"}
+	values := map[string]*dynamodb.AttributeValue{":v1": value, ":v3": value, ":v2": value}
+	assert.Equal(t, validateExpressionAttributes(names, values, "#n = :v1", "#a, #n"), errors.New("ValidationException", "Value provided in ExpressionAttributeValues unused in expressions: keys: {:v2, :v3}").WithParameter("ExpressionAttributeValues.:v2"))
+	assert.Equal(t, validateExpressionAttributes(names, values, "#n = :v1 AND :v2 < :v3", "#a"), nil)
+}
+
+func TestReservedWord(t *testing.T) {
+	tests := []struct {
+		testName   string
+		expression string
+		want       string
+	}{
+		{"unaliased name", "name = :n", "name"},
+		{"aliased name", "#name = :n", ""},
+		{"nested path", "address.zone = :z", "zone"},
+		{"projection", "emp_id, Status", "Status"},
+		{"keywords and functions", "attribute_exists(emp_id) AND NOT begins_with(first_name, :p) OR age BETWEEN :a AND :b", ""},
+		{"update keywords", "SET age = :age ADD visits :one REMOVE #name", ""},
+		{"size function", "size (address) > :s", ""},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, reservedWord(tc.expression), tc.want)
+	}
+	value := &dynamodb.AttributeValue{S: aws.String("v")}
+	err := validateExpressionAttributes(nil, map[string]*dynamodb.AttributeValue{":n": value}, "name = :n")
+	assert.Equal(t, err.(*errors.Error).ErrorCode, "ValidationException")
+	assert.Equal(t, strings.Contains(err.(*errors.Error).ErrorMessage, "reserved keyword: name"), true)
+
+	config.ConfigurationMap.AllowReservedWords = true
+	defer func() { config.ConfigurationMap.AllowReservedWords = false }()
+	assert.Equal(t, validateExpressionAttributes(nil, map[string]*dynamodb.AttributeValue{":n": value}, "name = :n"), nil)
+}
+
+func TestExpressionOperators(t *testing.T) {
+	tests := []struct {
+		testName   string
+		expression string
+		want       int
+	}{
+		{"empty", "", 0},
+		{"comparison", "age >= :min", 1},
+		{"logical operators and functions", "NOT attribute_exists(#n) or begins_with(sk, :p) AND size(tags) <> :zero", 7},
+		{"between and in", "age between :a and :b AND city IN (:c1, :c2)", 4},
+		{"placeholders and names are not operators", "#and = :or AND in_stock = :in", 3},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, expressionOperators(tc.expression), tc.want)
+	}
+}
+
+func TestValidateExpressionComplexity(t *testing.T) {
+	defer func() { config.ConfigurationMap.MaxExpressionOperators = 0 }()
+	values := map[string]*dynamodb.AttributeValue{":v": {S: aws.String("v")}}
+	expression := "a = :v" + strings.Repeat(" OR a = :v", 150)
+	assert.Equal(t, expressionOperators(expression), 301)
+	err := validateExpressionAttributes(nil, values, expression)
+	assert.NotEqual(t, err, nil)
+	assert.Equal(t, err.(*errors.Error).ErrorCode, "ValidationException")
+
+	assert.Equal(t, validateExpressionAttributes(nil, values, "a = :v"+strings.Repeat(" OR a = :v", 149)), nil)
+
+	config.ConfigurationMap.MaxExpressionOperators = 3
+	assert.Equal(t, validateExpressionAttributes(nil, values, "a = :v OR a = :v"), nil)
+	assert.NotEqual(t, validateExpressionAttributes(nil, values, "a = :v OR a = :v AND a = :v"), nil)
+}
+
+func TestValidateBatchWriteConditions(t *testing.T) {
+	item := map[string]*dynamodb.AttributeValue{"id": {S: aws.String("1")}}
+	tests := []struct {
+		testName string
+		requests []models.BatchWriteSubItems
+		wantErr  bool
+	}{
+		{"plain put and delete", []models.BatchWriteSubItems{{PutReq: models.BatchPutItem{Item: item}}, {DelReq: models.BatchDeleteItem{Key: item}}}, false},
+		{"put with ConditionExpression", []models.BatchWriteSubItems{{PutReq: models.BatchPutItem{Item: item, BatchWriteConditions: models.BatchWriteConditions{ConditionExpression: "attribute_not_exists(id)"}}}}, true},
+		{"put with Expected", []models.BatchWriteSubItems{{PutReq: models.BatchPutItem{Item: item, BatchWriteConditions: models.BatchWriteConditions{Expected: map[string]interface{}{"id": map[string]interface{}{"Exists": false}}}}}}, true},
+		{"delete with ExpressionAttributeValues", []models.BatchWriteSubItems{{DelReq: models.BatchDeleteItem{Key: item, BatchWriteConditions: models.BatchWriteConditions{ExpressionAttributeValues: item}}}}, true},
+		{"delete with ConditionalOperator", []models.BatchWriteSubItems{{DelReq: models.BatchDeleteItem{Key: item, BatchWriteConditions: models.BatchWriteConditions{ConditionalOperator: "AND"}}}}, true},
+	}
+	for _, tc := range tests {
+		err := validateBatchWriteConditions(models.BatchWriteItem{RequestItems: map[string][]models.BatchWriteSubItems{"employee": tc.requests}})
+		assert.Equal(t, err != nil, tc.wantErr)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/utils"
+)
+
+// Placeholders of the version check added for an ExpectedVersion
+const (
+	versionName          = "#__version_"
+	expectedVersionValue = ":__expectedVersion_"
+	versionIncrement     = ":__versionIncrement_"
+)
+
+// expectedVersion returns the VersionAttribute of the table and the version
+// checked by the ExpectedVersion of a write
+func expectedVersion(tableName string, expected *dynamodb.AttributeValue) (string, int64, error) {
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil {
+		return "", 0, err
+	}
+	if tableConf.VersionAttribute == "" {
+		return "", 0, errors.New("ValidationException", "VersionAttribute is not configured for table", tableName).WithParameter("ExpectedVersion")
+	}
+	if expected.N == nil {
+		return "", 0, errors.New("ValidationException", "ExpectedVersion must be a number").WithParameter("ExpectedVersion")
+	}
+	version, err := strconv.ParseInt(*expected.N, 10, 64)
+	if err != nil {
+		return "", 0, errors.New("ValidationException", "ExpectedVersion must be an integer", *expected.N).WithParameter("ExpectedVersion")
+	}
+	return tableConf.VersionAttribute, version, nil
+}
+
+// setNextVersion sets the version attribute of the item put with an
+// ExpectedVersion to the version following it
+func setNextVersion(item map[string]*dynamodb.AttributeValue, versionAttr string, version int64) map[string]*dynamodb.AttributeValue {
+	if item == nil {
+		item = map[string]*dynamodb.AttributeValue{}
+	}
+	next := strconv.FormatInt(version+1, 10)
+	item[versionAttr] = &dynamodb.AttributeValue{N: &next}
+	return item
+}
+
+// applyExpectedVersion adds the check of the version attribute against the
+// expected version to the condition expression. Conditions are evaluated
+// without parentheses, so one with an OR can not be combined with it.
+func applyExpectedVersion(versionAttr string, version int64, conditionExp string, names map[string]string, values map[string]interface{}) (string, map[string]string, map[string]interface{}, error) {
+	if strings.Contains(" "+utils.NormalizeKeywords(conditionExp)+" ", " OR ") {
+		return "", nil, nil, errors.New("ValidationException", "ExpectedVersion can not be combined with a ConditionExpression using OR").WithParameter("ExpectedVersion")
+	}
+	if names == nil {
+		names = map[string]string{}
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	names[versionName] = versionAttr
+	values[expectedVersionValue] = float64(version)
+	check := versionName + " = " + expectedVersionValue
+	if strings.TrimSpace(conditionExp) != "" {
+		check += " AND " + strings.TrimSpace(conditionExp)
+	}
+	return check, names, values, nil
+}
+
+// addVersionIncrement adds the version attribute to the ADD clause of the
+// update expression, so the update and its condition bump it in one
+// transaction
+func addVersionIncrement(updateExpression string, values map[string]interface{}) string {
+	values[versionIncrement] = float64(1)
+	increment := versionName + " " + versionIncrement
+	updateExpression = " " + strings.TrimSpace(utils.NormalizeKeywords(updateExpression))
+	if i := strings.Index(updateExpression, " ADD "); i > -1 {
+		i += len(" ADD ")
+		return strings.TrimSpace(updateExpression[:i] + increment + ", " + updateExpression[i:])
+	}
+	return strings.TrimSpace(updateExpression + " ADD " + increment)
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestExpectedVersion(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{
+		"employee":   {PartitionKey: "emp_id", ActualTable: "employee", VersionAttribute: "version"},
+		"department": {PartitionKey: "d_id", ActualTable: "department"},
+	}
+	tests := []struct {
+		testName    string
+		tableName   string
+		expected    *dynamodb.AttributeValue
+		wantAttr    string
+		wantVersion int64
+		wantErr     bool
+	}{
+		{"number", "employee", &dynamodb.AttributeValue{N: aws.String("3")}, "version", 3, false},
+		{"not a number", "employee", &dynamodb.AttributeValue{S: aws.String("3")}, "", 0, true},
+		{"not an integer", "employee", &dynamodb.AttributeValue{N: aws.String("3.5")}, "", 0, true},
+		{"no VersionAttribute", "department", &dynamodb.AttributeValue{N: aws.String("3")}, "", 0, true},
+	}
+	for _, tc := range tests {
+		attr, version, err := expectedVersion(tc.tableName, tc.expected)
+		assert.Equal(t, err != nil, tc.wantErr)
+		assert.Equal(t, attr, tc.wantAttr)
+		assert.Equal(t, version, tc.wantVersion)
+	}
+}
+
+func TestApplyExpectedVersion(t *testing.T) {
+	tests := []struct {
+		testName   string
+		condition  string
+		want       string
+		wantValues map[string]interface{}
+		wantErr    bool
+	}{
+		{
+			"no condition",
+			"",
+			"#__version_ = :__expectedVersion_",
+			map[string]interface{}{":__expectedVersion_": float64(3)},
+			false,
+		},
+		{
+			"with a condition",
+			"age > :age",
+			"#__version_ = :__expectedVersion_ AND age > :age",
+			map[string]interface{}{":age": float64(10), ":__expectedVersion_": float64(3)},
+			false,
+		},
+		{
+			"with an OR",
+			"age > :age or attribute_not_exists(age)",
+			"",
+			nil,
+			true,
+		},
+	}
+	for _, tc := range tests {
+		values := map[string]interface{}{}
+		if tc.condition != "" {
+			values[":age"] = float64(10)
+		}
+		condition, names, values, err := applyExpectedVersion("version", 3, tc.condition, nil, values)
+		assert.Equal(t, err != nil, tc.wantErr)
+		assert.Equal(t, condition, tc.want)
+		assert.Equal(t, values, tc.wantValues)
+		if !tc.wantErr {
+			assert.Equal(t, names, map[string]string{"#__version_": "version"})
+		}
+	}
+}
+
+func TestSetNextVersion(t *testing.T) {
+	item := setNextVersion(map[string]*dynamodb.AttributeValue{"emp_id": {N: aws.String("1")}}, "version", 3)
+	assert.Equal(t, *item["version"].N, "4")
+	assert.Equal(t, *item["emp_id"].N, "1")
+}
+
+func TestAddVersionIncrement(t *testing.T) {
+	tests := []struct {
+		testName         string
+		updateExpression string
+		want             string
+	}{
+		{"SET only", "SET age = :age", "SET age = :age ADD #__version_ :__versionIncrement_"},
+		{"existing ADD clause", "add visits :one SET age = :age", "ADD #__version_ :__versionIncrement_, visits :one SET age = :age"},
+		{"empty", "", "ADD #__version_ :__versionIncrement_"},
+	}
+	for _, tc := range tests {
+		values := map[string]interface{}{}
+		assert.Equal(t, addVersionIncrement(tc.updateExpression, values), tc.want)
+		assert.Equal(t, values, map[string]interface{}{":__versionIncrement_": float64(1)})
+	}
+}
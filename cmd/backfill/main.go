@@ -0,0 +1,206 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command backfill copies an existing DynamoDB table into Spanner by
+// parallel scanning it and writing every item through the adapter's PutItem
+// api. Progress is checkpointed after every page so an interrupted run resumes
+// where it stopped.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+)
+
+// segmentState is the progress of a single scan segment
+type segmentState struct {
+	LastEvaluatedKey map[string]*dynamodb.AttributeValue `json:"LastEvaluatedKey,omitempty"`
+	Done             bool                                `json:"Done"`
+	Items            int64                               `json:"Items"`
+}
+
+// checkpoint is saved to disk after every page
+type checkpoint struct {
+	TableName     string                  `json:"TableName"`
+	TotalSegments int64                   `json:"TotalSegments"`
+	Segments      map[int64]*segmentState `json:"Segments"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// loadCheckpoint reads the checkpoint at path or starts a new one
+func loadCheckpoint(path, tableName string, totalSegments int64) (*checkpoint, error) {
+	cp := &checkpoint{TableName: tableName, TotalSegments: totalSegments, Segments: map[int64]*segmentState{}, path: path}
+	ba, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(ba, cp); err != nil {
+		return nil, err
+	}
+	if cp.TableName != tableName || cp.TotalSegments != totalSegments {
+		return nil, fmt.Errorf("checkpoint %s is for table %s with %d segments", path, cp.TableName, cp.TotalSegments)
+	}
+	return cp, nil
+}
+
+// segment returns the state of a segment, creating it if needed
+func (cp *checkpoint) segment(segment int64) *segmentState {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	state, ok := cp.Segments[segment]
+	if !ok {
+		state = &segmentState{}
+		cp.Segments[segment] = state
+	}
+	return state
+}
+
+// update records the progress of a segment and saves the checkpoint
+func (cp *checkpoint) update(segment int64, lastEvaluatedKey map[string]*dynamodb.AttributeValue, items int64) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	state := cp.Segments[segment]
+	state.LastEvaluatedKey = lastEvaluatedKey
+	state.Done = len(lastEvaluatedKey) == 0
+	state.Items += items
+	ba, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := cp.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, ba, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, cp.path)
+}
+
+// putItem writes the item through the adapter's PutItem api
+func putItem(client *http.Client, adapterURL, tableName string, item map[string]*dynamodb.AttributeValue) error {
+	ba, err := json.Marshal(models.Meta{TableName: tableName, Item: item})
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(adapterURL+"/v1/PutItem", "application/json", bytes.NewReader(ba))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("PutItem failed with status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
+
+// backfillSegment scans one segment from its checkpoint until it is done
+func backfillSegment(db dynamodbiface.DynamoDBAPI, client *http.Client, cp *checkpoint, adapterURL string, segment, pageSize int64) error {
+	state := cp.segment(segment)
+	if state.Done {
+		return nil
+	}
+	startKey := state.LastEvaluatedKey
+	for {
+		input := &dynamodb.ScanInput{
+			TableName:         aws.String(cp.TableName),
+			Segment:           aws.Int64(segment),
+			TotalSegments:     aws.Int64(cp.TotalSegments),
+			ExclusiveStartKey: startKey,
+			ConsistentRead:    aws.Bool(true),
+		}
+		if pageSize > 0 {
+			input.Limit = aws.Int64(pageSize)
+		}
+		out, err := db.Scan(input)
+		if err != nil {
+			return err
+		}
+		for _, item := range out.Items {
+			if err := putItem(client, adapterURL, cp.TableName, item); err != nil {
+				return err
+			}
+		}
+		if err := cp.update(segment, out.LastEvaluatedKey, int64(len(out.Items))); err != nil {
+			return err
+		}
+		if len(out.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		startKey = out.LastEvaluatedKey
+	}
+}
+
+func main() {
+	tableName := flag.String("table", "", "DynamoDB table to copy")
+	adapterURL := flag.String("adapter", "http://localhost:9050", "base url of the dynamodb-adapter")
+	totalSegments := flag.Int64("segments", 4, "number of parallel scan segments")
+	pageSize := flag.Int64("page-size", 100, "items read per Scan call")
+	checkpointPath := flag.String("checkpoint", "", "checkpoint file, defaults to backfill-<table>.json")
+	flag.Parse()
+	if *tableName == "" || *totalSegments < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *checkpointPath == "" {
+		*checkpointPath = "backfill-" + *tableName + ".json"
+	}
+
+	cp, err := loadCheckpoint(*checkpointPath, *tableName, *totalSegments)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	sess := session.Must(session.NewSessionWithOptions(session.Options{SharedConfigState: session.SharedConfigEnable}))
+	db := dynamodb.New(sess)
+	client := &http.Client{}
+
+	var wg sync.WaitGroup
+	errs := make([]error, *totalSegments)
+	for segment := int64(0); segment < *totalSegments; segment++ {
+		wg.Add(1)
+		go func(segment int64) {
+			defer wg.Done()
+			errs[segment] = backfillSegment(db, client, cp, *adapterURL, segment, *pageSize)
+		}(segment)
+	}
+	wg.Wait()
+
+	failed := false
+	for segment, err := range errs {
+		if err != nil {
+			log.Printf("segment %d failed: %v", segment, err)
+			failed = true
+		}
+	}
+	if failed {
+		log.Fatalln("backfill incomplete, run again to resume from", *checkpointPath)
+	}
+	log.Println("backfill of", *tableName, "complete")
+}
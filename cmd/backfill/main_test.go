@@ -0,0 +1,85 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+// pagedScan returns one item per page, keyed by the item's id
+type pagedScan struct {
+	dynamodbiface.DynamoDBAPI
+	ids []string
+}
+
+func (p *pagedScan) Scan(input *dynamodb.ScanInput) (*dynamodb.ScanOutput, error) {
+	next := 0
+	if input.ExclusiveStartKey != nil {
+		for i, id := range p.ids {
+			if id == *input.ExclusiveStartKey["id"].S {
+				next = i + 1
+			}
+		}
+	}
+	item := map[string]*dynamodb.AttributeValue{"id": {S: aws.String(p.ids[next])}}
+	out := &dynamodb.ScanOutput{Items: []map[string]*dynamodb.AttributeValue{item}}
+	if next < len(p.ids)-1 {
+		out.LastEvaluatedKey = item
+	}
+	return out, nil
+}
+
+func TestBackfillSegmentResumes(t *testing.T) {
+	var written []string
+	adapter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var meta models.Meta
+		json.NewDecoder(r.Body).Decode(&meta)
+		written = append(written, *meta.Item["id"].S)
+	}))
+	defer adapter.Close()
+
+	dir, err := ioutil.TempDir("", "backfill")
+	assert.Equal(t, err, nil)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "checkpoint.json")
+
+	cp, err := loadCheckpoint(path, "employee", 1)
+	assert.Equal(t, err, nil)
+	cp.segment(0)
+	assert.Equal(t, cp.update(0, map[string]*dynamodb.AttributeValue{"id": {S: aws.String("a")}}, 1), nil)
+
+	cp, err = loadCheckpoint(path, "employee", 1)
+	assert.Equal(t, err, nil)
+	db := &pagedScan{ids: []string{"a", "b", "c"}}
+	assert.Equal(t, backfillSegment(db, adapter.Client(), cp, adapter.URL, 0, 1), nil)
+	assert.Equal(t, written, []string{"b", "c"})
+	assert.Equal(t, cp.Segments[0].Done, true)
+	assert.Equal(t, cp.Segments[0].Items, int64(3))
+
+	_, err = loadCheckpoint(path, "employee", 2)
+	assert.NotEqual(t, err, nil)
+}
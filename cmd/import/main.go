@@ -0,0 +1,300 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command import loads a DynamoDB JSON export stored in GCS into Spanner. It
+// reads the {"Item":{...}} lines of every object under the source path and
+// writes them in batches through the adapter's BatchWriteItem api. Progress
+// is checkpointed after every batch so an interrupted run resumes where it
+// stopped.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	storage "google.golang.org/api/storage/v1"
+)
+
+// maxLineSize fits the DynamoDB JSON of a 400KB item
+const maxLineSize = 4 << 20
+
+// objectState is the progress of a single export object
+type objectState struct {
+	Lines int64 `json:"Lines"`
+	Items int64 `json:"Items"`
+	Done  bool  `json:"Done"`
+}
+
+// checkpoint is saved to disk after every batch
+type checkpoint struct {
+	TableName string                  `json:"TableName"`
+	Source    string                  `json:"Source"`
+	Objects   map[string]*objectState `json:"Objects"`
+
+	path string
+}
+
+// loadCheckpoint reads the checkpoint at path or starts a new one
+func loadCheckpoint(path, tableName, source string) (*checkpoint, error) {
+	cp := &checkpoint{TableName: tableName, Source: source, Objects: map[string]*objectState{}, path: path}
+	ba, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(ba, cp); err != nil {
+		return nil, err
+	}
+	if cp.TableName != tableName || cp.Source != source {
+		return nil, fmt.Errorf("checkpoint %s is for table %s from %s", path, cp.TableName, cp.Source)
+	}
+	return cp, nil
+}
+
+// object returns the state of an object, creating it if needed
+func (cp *checkpoint) object(name string) *objectState {
+	state, ok := cp.Objects[name]
+	if !ok {
+		state = &objectState{}
+		cp.Objects[name] = state
+	}
+	return state
+}
+
+// save writes the checkpoint to its path
+func (cp *checkpoint) save() error {
+	ba, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := cp.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, ba, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, cp.path)
+}
+
+// objectStore lists and reads the objects of a bucket
+type objectStore interface {
+	List(bucket, prefix string) ([]string, error)
+	Open(bucket, object string) (io.ReadCloser, error)
+}
+
+// gcsStore reads the objects from GCS with the application default credentials
+type gcsStore struct {
+	svc *storage.Service
+}
+
+func (g gcsStore) List(bucket, prefix string) ([]string, error) {
+	var names []string
+	err := g.svc.Objects.List(bucket).Prefix(prefix).Pages(context.Background(), func(objects *storage.Objects) error {
+		for _, object := range objects.Items {
+			if !strings.HasSuffix(object.Name, "/") {
+				names = append(names, object.Name)
+			}
+		}
+		return nil
+	})
+	return names, err
+}
+
+func (g gcsStore) Open(bucket, object string) (io.ReadCloser, error) {
+	resp, err := g.svc.Objects.Get(bucket, object).Download()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// parseSource splits a gs://bucket/path source into its bucket and path
+func parseSource(source string) (string, string, error) {
+	if !strings.HasPrefix(source, "gs://") {
+		return "", "", fmt.Errorf("source %s is not a gs:// path", source)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(source, "gs://"), "/", 2)
+	if parts[0] == "" {
+		return "", "", fmt.Errorf("source %s has no bucket", source)
+	}
+	if len(parts) == 1 {
+		return parts[0], "", nil
+	}
+	return parts[0], parts[1], nil
+}
+
+// exportLine is a line of a DynamoDB JSON export
+type exportLine struct {
+	Item map[string]*dynamodb.AttributeValue `json:"Item"`
+}
+
+// batchWrite writes the items through the adapter's BatchWriteItem api
+func batchWrite(client *http.Client, adapterURL, tableName string, items []map[string]*dynamodb.AttributeValue) error {
+	requests := make([]models.BatchWriteSubItems, 0, len(items))
+	for _, item := range items {
+		requests = append(requests, models.BatchWriteSubItems{PutReq: models.BatchPutItem{Item: item}})
+	}
+	ba, err := json.Marshal(models.BatchWriteItem{RequestItems: map[string][]models.BatchWriteSubItems{tableName: requests}})
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(adapterURL+"/v1/BatchWriteItem", "application/json", bytes.NewReader(ba))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("BatchWriteItem failed with status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
+
+// importObject writes the items of one object from its checkpoint until it
+// is done. Objects ending with .gz are decompressed.
+func importObject(store objectStore, client *http.Client, cp *checkpoint, adapterURL, bucket, object string, batchSize int) error {
+	state := cp.object(object)
+	if state.Done {
+		return nil
+	}
+	body, err := store.Open(bucket, object)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	var r io.Reader = body
+	if strings.HasSuffix(object, ".gz") {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var batch []map[string]*dynamodb.AttributeValue
+	var line int64
+	flush := func() error {
+		if len(batch) > 0 {
+			if err := batchWrite(client, adapterURL, cp.TableName, batch); err != nil {
+				return err
+			}
+		}
+		state.Lines = line
+		state.Items += int64(len(batch))
+		batch = batch[:0]
+		return cp.save()
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line++
+		if line <= state.Lines || len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var l exportLine
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return fmt.Errorf("line %d of %s: %v", line, object, err)
+		}
+		if len(l.Item) == 0 {
+			return fmt.Errorf("line %d of %s has no Item", line, object)
+		}
+		batch = append(batch, l.Item)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	state.Done = true
+	return cp.save()
+}
+
+// importSource imports every object under the source path in name order and
+// returns the number of items imported from them so far
+func importSource(store objectStore, client *http.Client, cp *checkpoint, adapterURL string, batchSize int) (int64, error) {
+	bucket, prefix, err := parseSource(cp.Source)
+	if err != nil {
+		return 0, err
+	}
+	objects, err := store.List(bucket, prefix)
+	if err != nil {
+		return 0, err
+	}
+	if len(objects) == 0 {
+		return 0, fmt.Errorf("no objects found under %s", cp.Source)
+	}
+	sort.Strings(objects)
+	var total int64
+	for _, object := range objects {
+		err := importObject(store, client, cp, adapterURL, bucket, object, batchSize)
+		total += cp.object(object).Items
+		if err != nil {
+			return total, err
+		}
+		log.Printf("imported %d items from gs://%s/%s", cp.object(object).Items, bucket, object)
+	}
+	return total, nil
+}
+
+func main() {
+	tableName := flag.String("table", "", "table to import into")
+	source := flag.String("source", "", "gs://bucket/path of the export object, or of a prefix of several objects")
+	adapterURL := flag.String("adapter", "http://localhost:9050", "base url of the dynamodb-adapter")
+	batchSize := flag.Int("batch-size", 25, "items written per BatchWriteItem call")
+	checkpointPath := flag.String("checkpoint", "", "checkpoint file, defaults to import-<table>.json")
+	flag.Parse()
+	if *tableName == "" || *source == "" || *batchSize < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *checkpointPath == "" {
+		*checkpointPath = "import-" + *tableName + ".json"
+	}
+
+	cp, err := loadCheckpoint(*checkpointPath, *tableName, *source)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	svc, err := storage.NewService(context.Background())
+	if err != nil {
+		log.Fatalln(err)
+	}
+	total, err := importSource(gcsStore{svc: svc}, &http.Client{}, cp, *adapterURL, *batchSize)
+	if err != nil {
+		log.Printf("import failed after %d items: %v", total, err)
+		log.Fatalln("import incomplete, run again to resume from", *checkpointPath)
+	}
+	log.Println("import of", *tableName, "complete,", total, "items")
+}
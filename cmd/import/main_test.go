@@ -0,0 +1,142 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+// memoryStore serves the objects of a single bucket from memory
+type memoryStore map[string][]byte
+
+func (m memoryStore) List(bucket, prefix string) ([]string, error) {
+	var names []string
+	for name := range m {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (m memoryStore) Open(bucket, object string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(m[object])), nil
+}
+
+func gzipped(s string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte(s))
+	gz.Close()
+	return buf.Bytes()
+}
+
+func TestParseSource(t *testing.T) {
+	tests := []struct {
+		testName   string
+		source     string
+		wantBucket string
+		wantPath   string
+		wantErr    bool
+	}{
+		{"object", "gs://exports/employee/data/0.json.gz", "exports", "employee/data/0.json.gz", false},
+		{"bucket", "gs://exports", "exports", "", false},
+		{"not gcs", "s3://exports/employee", "", "", true},
+		{"no bucket", "gs:///employee", "", "", true},
+	}
+	for _, tc := range tests {
+		bucket, path, err := parseSource(tc.source)
+		assert.Equal(t, err != nil, tc.wantErr)
+		assert.Equal(t, bucket, tc.wantBucket)
+		assert.Equal(t, path, tc.wantPath)
+	}
+}
+
+func TestImportSourceResumes(t *testing.T) {
+	var written []string
+	var batches int
+	adapter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch models.BatchWriteItem
+		json.NewDecoder(r.Body).Decode(&batch)
+		for _, request := range batch.RequestItems["employee"] {
+			written = append(written, *request.PutReq.Item["emp_id"].N)
+		}
+		batches++
+	}))
+	defer adapter.Close()
+
+	store := memoryStore{
+		"employee/data/0.json":    []byte(`{"Item":{"emp_id":{"N":"1"}}}` + "\n" + `{"Item":{"emp_id":{"N":"2"}}}` + "\n\n" + `{"Item":{"emp_id":{"N":"3"}}}` + "\n"),
+		"employee/data/1.json.gz": gzipped(`{"Item":{"emp_id":{"N":"4"}}}` + "\n"),
+	}
+
+	dir, err := ioutil.TempDir("", "import")
+	assert.Equal(t, err, nil)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "checkpoint.json")
+
+	cp, err := loadCheckpoint(path, "employee", "gs://exports/employee/data")
+	assert.Equal(t, err, nil)
+	cp.object("employee/data/0.json").Lines = 1
+	cp.object("employee/data/0.json").Items = 1
+	assert.Equal(t, cp.save(), nil)
+
+	cp, err = loadCheckpoint(path, "employee", "gs://exports/employee/data")
+	assert.Equal(t, err, nil)
+	total, err := importSource(store, adapter.Client(), cp, adapter.URL, 1)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, total, int64(4))
+	assert.Equal(t, written, []string{"2", "3", "4"})
+	assert.Equal(t, batches, 3)
+	assert.Equal(t, cp.Objects["employee/data/0.json"].Done, true)
+	assert.Equal(t, cp.Objects["employee/data/1.json.gz"].Items, int64(1))
+
+	written = nil
+	total, err = importSource(store, adapter.Client(), cp, adapter.URL, 1)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, total, int64(4))
+	assert.Equal(t, len(written), 0)
+
+	_, err = loadCheckpoint(path, "employee", "gs://exports/other")
+	assert.NotEqual(t, err, nil)
+}
+
+func TestImportObjectRejectsInvalidLines(t *testing.T) {
+	adapter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer adapter.Close()
+	dir, err := ioutil.TempDir("", "import")
+	assert.Equal(t, err, nil)
+	defer os.RemoveAll(dir)
+
+	store := memoryStore{"a.json": []byte(`{"emp_id":{"N":"1"}}` + "\n"), "b.json": []byte("not json\n")}
+	for _, object := range []string{"a.json", "b.json"} {
+		cp, err := loadCheckpoint(filepath.Join(dir, object), "employee", "gs://exports/"+object)
+		assert.Equal(t, err, nil)
+		assert.NotEqual(t, importObject(store, adapter.Client(), cp, adapter.URL, "exports", object, 25), nil)
+	}
+}
@@ -30,11 +30,48 @@ import (
 
 // Configuration struct
 type Configuration struct {
-	GoogleProjectID string
-	SpannerDb       string
-	QueryLimit      int64
+	GoogleProjectID          string
+	SpannerDb                string
+	QueryLimit               int64
+	FlatItems                bool
+	KMSKeyName               string
+	WrappedDataKey           string
+	PubSubPublishTimeout     string
+	PubSubPublishRetries     int
+	PubSubMessageOrdering    bool
+	ItemCountRefreshInterval string
+	WriteConcurrency         int
+	SlowQueryThreshold       string
+	SecondarySpannerDb       string
+	ReadSpannerDb            string
+	WriteSpannerDb           string
+	SecondaryWritable        bool
+	FailoverCheckInterval    string
+	FailoverThreshold        int
+	ReadOnly                 bool
+	CORSEnabled              bool
+	CORSAllowedOrigins       []string
+	CORSAllowedMethods       []string
+	CORSAllowedHeaders       []string
+	MaxRequestBodySize       int64
+	PprofEnabled             bool
+	PprofAddr                string
+	ListenAddr               string
+	GRPCAddr                 string
+	AdminToken               string
+	ConsistentRead           *bool
+	ReadYourWritesWindow     string
+	MaxExpressionOperators   int
+	AllowReservedWords       bool
+	SessionPoolMinOpened     int
+	WarmUpSessionPool        bool
+	OptimizerVersion         string
+	AttributeValueEncoder    string
 }
 
+// defaultListenAddr is used when neither LISTEN_ADDR nor ListenAddr is set
+const defaultListenAddr = ":9050"
+
 var once sync.Once
 
 // ConfigurationMap pointer
@@ -48,72 +85,43 @@ func init() {
 var DbConfigMap map[string]models.TableConfig
 
 // InitConfig loads ConfigurationMap and DbConfigMap in memory based on ACTIVE_ENV
-// These config files are read from rice-box
+// These config files are read from rice-box unless CONFIG_SOURCE selects
+// environment variables or Secret Manager
 func InitConfig(box *rice.Box) {
 	once.Do(func() {
-		env := os.Getenv("ACTIVE_ENV")
+		env := "staging"
+		if os.Getenv("ACTIVE_ENV") == "PRODUCTION" {
+			env = "production"
+		}
 		ConfigurationMap = new(Configuration)
-		if env == "PRODUCTION" {
-			ba, err := box.Bytes("production/tables-production.json")
-			if err != nil {
-				logger.LogFatal(err)
-			}
-			err = json.Unmarshal(ba, &DbConfigMap)
-			if err != nil {
-				logger.LogFatal(err)
-			}
-			ba, err = box.Bytes("production/config-production.json")
-			if err != nil {
-				logger.LogFatal(err)
-			}
-			err = json.Unmarshal(ba, ConfigurationMap)
-			if err != nil {
-				logger.LogFatal(err)
-			}
-			ba, err = box.Bytes("production/spanner-production.json")
-			if err != nil {
-				logger.LogFatal(err)
-			}
-			tmp := make(map[string]string)
-			err = json.Unmarshal(ba, &tmp)
-			if err != nil {
-				logger.LogFatal(err)
-			}
-			for k, v := range tmp {
-				models.SpannerTableMap[changeTableNameForSP(k)] = v
-			}
-		} else {
-			ba, err := box.Bytes("staging/tables-staging.json")
-			if err != nil {
-				logger.LogFatal(err)
-			}
-			err = json.Unmarshal(ba, &DbConfigMap)
-			if err != nil {
-				logger.LogFatal(err)
-			}
-			ba, err = box.Bytes("staging/config-staging.json")
-			if err != nil {
-				logger.LogFatal(err)
-			}
-			err = json.Unmarshal(ba, ConfigurationMap)
-			if err != nil {
-				logger.LogFatal(err)
-			}
-			ba, err = box.Bytes("staging/spanner-staging.json")
-			if err != nil {
-				logger.LogFatal(err)
-			}
-			tmp := make(map[string]string)
-			err = json.Unmarshal(ba, &tmp)
-			if err != nil {
-				logger.LogFatal(err)
-			}
-			for k, v := range tmp {
-				models.SpannerTableMap[changeTableNameForSP(k)] = v
-			}
-
+		ba, err := readConfig(box, env, "tables")
+		if err != nil {
+			logger.LogFatal(err)
+		}
+		err = json.Unmarshal(ba, &DbConfigMap)
+		if err != nil {
+			logger.LogFatal(err)
+		}
+		ba, err = readConfig(box, env, "config")
+		if err != nil {
+			logger.LogFatal(err)
+		}
+		err = json.Unmarshal(ba, ConfigurationMap)
+		if err != nil {
+			logger.LogFatal(err)
+		}
+		ba, err = readConfig(box, env, "spanner")
+		if err != nil {
+			logger.LogFatal(err)
+		}
+		tmp := make(map[string]string)
+		err = json.Unmarshal(ba, &tmp)
+		if err != nil {
+			logger.LogFatal(err)
+		}
+		for k, v := range tmp {
+			models.SpannerTableMap[changeTableNameForSP(k)] = v
 		}
-
 	})
 }
 
@@ -135,6 +143,31 @@ func GetTableConf(tableName string) (models.TableConfig, error) {
 	return models.TableConfig{}, errors.New("ResourceNotFoundException", tableName)
 }
 
+// IsSoftDelete checks if deletes on the table only write a tombstone
+func IsSoftDelete(tableName string) bool {
+	tableConf, ok := DbConfigMap[tableName]
+	if !ok {
+		return false
+	}
+	if tableConf.ActualTable != "" {
+		tableConf = DbConfigMap[tableConf.ActualTable]
+	}
+	return tableConf.SoftDelete
+}
+
+// ListenAddr returns the address the adapter serves on, taken from the
+// LISTEN_ADDR env variable, then the ListenAddr config, then :9050. A host
+// binds the adapter to one interface, e.g. 127.0.0.1:9050.
+func ListenAddr() string {
+	if addr := os.Getenv("LISTEN_ADDR"); addr != "" {
+		return addr
+	}
+	if ConfigurationMap.ListenAddr != "" {
+		return ConfigurationMap.ListenAddr
+	}
+	return defaultListenAddr
+}
+
 // changeTableNameForSP - ReplaceAll the hyphens (-) with underscore for giver string
 func changeTableNameForSP(tableName string) string {
 	tableName = strings.ReplaceAll(tableName, "-", "_")
@@ -15,6 +15,7 @@
 package config
 
 import (
+	"os"
 	"testing"
 
 	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
@@ -124,3 +125,49 @@ func TestChangeTableNameForSP(t *testing.T) {
 		assert.Equal(t, got, tc.want)
 	}
 }
+
+func TestIsSoftDelete(t *testing.T) {
+	DbConfigMap = map[string]models.TableConfig{
+		"orders":       {PartitionKey: "id", SoftDelete: true},
+		"orders_alias": {ActualTable: "orders"},
+		"department":   {PartitionKey: "d_id"},
+	}
+
+	tests := []struct {
+		testName  string
+		tableName string
+		want      bool
+	}{
+		{"table which is not present", "xyz", false},
+		{"table without soft delete", "department", false},
+		{"table with soft delete", "orders", true},
+		{"table pointing to a soft delete table", "orders_alias", true},
+	}
+
+	for _, tc := range tests {
+		got := IsSoftDelete(tc.tableName)
+		assert.Equal(t, got, tc.want)
+	}
+}
+
+func TestListenAddr(t *testing.T) {
+	defer func() {
+		os.Unsetenv("LISTEN_ADDR")
+		ConfigurationMap.ListenAddr = ""
+	}()
+	tests := []struct {
+		testName   string
+		env        string
+		listenAddr string
+		want       string
+	}{
+		{"default", "", "", ":9050"},
+		{"config", "", "127.0.0.1:8080", "127.0.0.1:8080"},
+		{"env over config", ":9000", "127.0.0.1:8080", ":9000"},
+	}
+	for _, tc := range tests {
+		os.Setenv("LISTEN_ADDR", tc.env)
+		ConfigurationMap.ListenAddr = tc.listenAddr
+		assert.Equal(t, ListenAddr(), tc.want)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"os"
+
+	rice "github.com/GeertJohan/go.rice"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"google.golang.org/api/option"
+	gtransport "google.golang.org/api/transport/grpc"
+	secretmanagerpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1"
+)
+
+const secretManagerEndpoint = "secretmanager.googleapis.com:443"
+
+// configEnv maps the config documents to the env variables holding them. With
+// CONFIG_SOURCE=env the variables hold the JSON documents and with
+// CONFIG_SOURCE=secretmanager their _SECRET variants hold the secret versions,
+// e.g. projects/my-project/secrets/adapter-tables/versions/latest
+var configEnv = map[string]string{
+	"tables":  "DYNAMODB_ADAPTER_TABLES",
+	"config":  "DYNAMODB_ADAPTER_CONFIG",
+	"spanner": "DYNAMODB_ADAPTER_SPANNER",
+}
+
+// readConfig reads the tables, config or spanner document from the source
+// selected by CONFIG_SOURCE, falling back to the file embedded in the rice box
+// for the environment when the source does not set it
+func readConfig(box *rice.Box, env, name string) ([]byte, error) {
+	switch source := os.Getenv("CONFIG_SOURCE"); source {
+	case "", "embedded":
+	case "env":
+		if v := os.Getenv(configEnv[name]); v != "" {
+			return []byte(v), nil
+		}
+	case "secretmanager":
+		if v := os.Getenv(configEnv[name] + "_SECRET"); v != "" {
+			return accessSecret(context.Background(), v)
+		}
+	default:
+		return nil, errors.New("CONFIG_SOURCE must be embedded, env or secretmanager", source)
+	}
+	if box == nil {
+		return nil, errors.New("no embedded config for", name)
+	}
+	return box.Bytes(env + "/" + name + "-" + env + ".json")
+}
+
+// accessSecret returns the payload of the Secret Manager secret version
+func accessSecret(ctx context.Context, version string) ([]byte, error) {
+	conn, err := gtransport.Dial(ctx, option.WithEndpoint(secretManagerEndpoint), option.WithScopes("https://www.googleapis.com/auth/cloud-platform"))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	resp, err := secretmanagerpb.NewSecretManagerServiceClient(conn).AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: version})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Payload.Data, nil
+}
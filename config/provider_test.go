@@ -0,0 +1,52 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestReadConfig(t *testing.T) {
+	defer os.Unsetenv("CONFIG_SOURCE")
+	defer os.Unsetenv("DYNAMODB_ADAPTER_TABLES")
+	os.Setenv("DYNAMODB_ADAPTER_TABLES", `{"employee":{}}`)
+
+	tests := []struct {
+		testName string
+		source   string
+		doc      string
+		want     string
+		wantErr  bool
+	}{
+		{"document from env", "env", "tables", `{"employee":{}}`, false},
+		{"env falls back to the embedded file", "env", "spanner", "", true},
+		{"embedded file", "", "tables", "", true},
+		{"unknown source", "consul", "tables", "", true},
+	}
+
+	for _, tc := range tests {
+		os.Setenv("CONFIG_SOURCE", tc.source)
+		got, err := readConfig(nil, "staging", tc.doc)
+		if tc.wantErr {
+			assert.NotEqual(t, err, nil)
+			continue
+		}
+		assert.Equal(t, err, nil)
+		assert.Equal(t, string(got), tc.want)
+	}
+}
@@ -17,6 +17,8 @@
 package initializer
 
 import (
+	"context"
+
 	rice "github.com/GeertJohan/go.rice"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/service/services"
@@ -33,7 +35,15 @@ func InitAll(box *rice.Box) error {
 	if err != nil {
 		return err
 	}
+	if err := storage.ValidateEncryptedColumns(); err != nil {
+		return err
+	}
+	if config.ConfigurationMap.WarmUpSessionPool {
+		storage.GetStorageInstance().WarmUp(context.Background())
+	}
+	services.SetReadOnly(config.ConfigurationMap.ReadOnly)
 	services.StartConfigManager()
 	services.InitStream()
+	services.StartItemCounter()
 	return nil
 }
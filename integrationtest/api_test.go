@@ -15,12 +15,15 @@
 package integrationtest
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 
 	rice "github.com/GeertJohan/go.rice"
@@ -542,6 +545,30 @@ var (
 		Limit:         4,
 	}
 
+	//pages of 2, which reassemble every row once
+	queryTestCase17 = models.Query{
+		TableName: "employee",
+		Limit:     2,
+	}
+
+	queryTestCase18 = models.Query{
+		TableName: "employee",
+		Limit:     2,
+		ExclusiveStartKey: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("2")},
+			"offset": {N: aws.String("2")},
+		},
+	}
+
+	queryTestCase19 = models.Query{
+		TableName: "employee",
+		Limit:     2,
+		ExclusiveStartKey: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("4")},
+			"offset": {N: aws.String("4")},
+		},
+	}
+
 	queryTestCaseOutput1 = `{"Count":5,"Items":{"L":[{"address":{"S":"Shamli"},"age":{"N":"10"},"emp_id":{"N":"1"},"first_name":{"S":"Marc"},"last_name":{"S":"Richards"}},{"address":{"S":"Ney York"},"age":{"N":"20"},"emp_id":{"N":"2"},"first_name":{"S":"Catalina"},"last_name":{"S":"Smith"}},{"address":{"S":"Pune"},"age":{"N":"30"},"emp_id":{"N":"3"},"first_name":{"S":"Alice"},"last_name":{"S":"Trentor"}},{"address":{"S":"Silicon Valley"},"age":{"N":"40"},"emp_id":{"N":"4"},"first_name":{"S":"Lea"},"last_name":{"S":"Martin"}},{"address":{"S":"London"},"age":{"N":"50"},"emp_id":{"N":"5"},"first_name":{"S":"David"},"last_name":{"S":"Lomond"}}]},"LastEvaluatedKey":null}`
 
 	queryTestCaseOutput2 = `{"Count":5,"Items":{"L":[{"emp_id":{"N":"1"},"first_name":{"S":"Marc"}},{"emp_id":{"N":"2"},"first_name":{"S":"Catalina"}},{"emp_id":{"N":"3"},"first_name":{"S":"Alice"}},{"emp_id":{"N":"4"},"first_name":{"S":"Lea"}},{"emp_id":{"N":"5"},"first_name":{"S":"David"}}]},"LastEvaluatedKey":null}`
@@ -569,6 +596,12 @@ var (
 	queryTestCaseOutput15 = `{"Count":1,"Items":{"L":[{"emp_id":{"N":"3"},"first_name":{"S":"Alice"},"last_name":{"S":"Trentor"}}]},"LastEvaluatedKey":null}`
 
 	queryTestCaseOutput16 = `{"Count":1,"Items":{"L":[]},"LastEvaluatedKey":null}`
+
+	queryTestCaseOutput17 = `{"Count":2,"Items":{"L":[{"address":{"S":"Shamli"},"age":{"N":"10"},"emp_id":{"N":"1"},"first_name":{"S":"Marc"},"last_name":{"S":"Richards"}},{"address":{"S":"Ney York"},"age":{"N":"20"},"emp_id":{"N":"2"},"first_name":{"S":"Catalina"},"last_name":{"S":"Smith"}}]},"LastEvaluatedKey":{"emp_id":{"N":"2"},"offset":{"N":"2"}}}`
+
+	queryTestCaseOutput18 = `{"Count":2,"Items":{"L":[{"address":{"S":"Pune"},"age":{"N":"30"},"emp_id":{"N":"3"},"first_name":{"S":"Alice"},"last_name":{"S":"Trentor"}},{"address":{"S":"Silicon Valley"},"age":{"N":"40"},"emp_id":{"N":"4"},"first_name":{"S":"Lea"},"last_name":{"S":"Martin"}}]},"LastEvaluatedKey":{"emp_id":{"N":"4"},"offset":{"N":"4"}}}`
+
+	queryTestCaseOutput19 = `{"Count":1,"Items":{"L":[{"address":{"S":"London"},"age":{"N":"50"},"emp_id":{"N":"5"},"first_name":{"S":"David"},"last_name":{"S":"Lomond"}}]},"LastEvaluatedKey":null}`
 )
 
 //Test Data for Scan API
@@ -665,7 +698,7 @@ var (
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":val1": {N: aws.String("10")},
 		},
-		FilterExpression: "age > :val1",
+		FilterExpression: "#ag > :val1",
 	}
 	ScanTestCase11Output = `{"Count":4,"Items":{"L":[{"address":{"S":"Ney York"},"age":{"N":"20"},"emp_id":{"N":"2"},"first_name":{"S":"Catalina"},"last_name":{"S":"Smith"}},{"address":{"S":"Pune"},"age":{"N":"30"},"emp_id":{"N":"3"},"first_name":{"S":"Alice"},"last_name":{"S":"Trentor"}},{"address":{"S":"Silicon Valley"},"age":{"N":"40"},"emp_id":{"N":"4"},"first_name":{"S":"Lea"},"last_name":{"S":"Martin"}},{"address":{"S":"London"},"age":{"N":"50"},"emp_id":{"N":"5"},"first_name":{"S":"David"},"last_name":{"S":"Lomond"}}]},"LastEvaluatedKey":null}`
 
@@ -687,6 +720,48 @@ var (
 		Select:    "COUNT",
 	}
 	ScanTestCase13Output = `{"Count":5,"Items":{"L":[]},"LastEvaluatedKey":null}`
+
+	ScanTestCase14Name = "14: Scan with Limit pages by primary key"
+	ScanTestCase14     = models.ScanMeta{
+		TableName: "employee",
+		Limit:     3,
+	}
+	ScanTestCase14Output = `{"Count":3,"Items":{"L":[{"address":{"S":"Shamli"},"age":{"N":"10"},"emp_id":{"N":"1"},"first_name":{"S":"Marc"},"last_name":{"S":"Richards"}},{"address":{"S":"Ney York"},"age":{"N":"20"},"emp_id":{"N":"2"},"first_name":{"S":"Catalina"},"last_name":{"S":"Smith"}},{"address":{"S":"Pune"},"age":{"N":"30"},"emp_id":{"N":"3"},"first_name":{"S":"Alice"},"last_name":{"S":"Trentor"}}]},"LastEvaluatedKey":{"emp_id":{"N":"3"}}}`
+
+	ScanTestCase15Name = "15: Item inserted before the LastEvaluatedKey between pages"
+	ScanTestCase15     = models.Meta{
+		TableName: "employee",
+		Item: map[string]*dynamodb.AttributeValue{
+			"emp_id":     {N: aws.String("0")},
+			"first_name": {S: aws.String("Paul")},
+		},
+	}
+
+	ScanTestCase16Name = "16: Next page neither skips nor repeats items"
+	ScanTestCase16     = models.ScanMeta{
+		TableName: "employee",
+		Limit:     3,
+		ExclusiveStartKey: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("3")},
+		},
+	}
+	ScanTestCase16Output = `{"Count":2,"Items":{"L":[{"address":{"S":"Silicon Valley"},"age":{"N":"40"},"emp_id":{"N":"4"},"first_name":{"S":"Lea"},"last_name":{"S":"Martin"}},{"address":{"S":"London"},"age":{"N":"50"},"emp_id":{"N":"5"},"first_name":{"S":"David"},"last_name":{"S":"Lomond"}}]},"LastEvaluatedKey":null}`
+
+	ScanTestCase17Name = "17: Removing the inserted item"
+	ScanTestCase17     = models.Delete{
+		TableName: "employee",
+		Key: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("0")},
+		},
+	}
+
+	ScanTestCase18Name = "18: Select SPECIFIC_ATTRIBUTES with only the key"
+	ScanTestCase18     = models.ScanMeta{
+		TableName:            "employee",
+		Select:               "SPECIFIC_ATTRIBUTES",
+		ProjectionExpression: "emp_id",
+	}
+	ScanTestCase18Output = `{"Count":5,"Items":{"L":[{"emp_id":{"N":"1"}},{"emp_id":{"N":"2"}},{"emp_id":{"N":"3"}},{"emp_id":{"N":"4"}},{"emp_id":{"N":"5"}}]},"LastEvaluatedKey":null}`
 )
 
 //Test Data for UpdateItem API
@@ -827,6 +902,64 @@ var (
 			":val2": {N: aws.String("9")},
 		},
 	}
+
+	UpdateItemTestCase11Name = "11: UpdateExpression inserts the item when the key is absent"
+	UpdateItemTestCase11     = models.UpdateAttr{
+		TableName: "employee",
+		Key: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("100")},
+		},
+		UpdateExpression: "SET first_name = :fn ADD age :age",
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":fn":  {S: aws.String("Ria")},
+			":age": {N: aws.String("5")},
+		},
+		ReturnValues: "ALL_NEW",
+	}
+	UpdateItemTestCase11Output = `{"Attributes":{"age":{"N":"5"},"emp_id":{"N":"100"},"first_name":{"S":"Ria"}}}`
+
+	//400 bad request
+	UpdateItemTestCase12Name = "12: attribute_exists on the key makes the update strict for an absent key"
+	UpdateItemTestCase12     = models.UpdateAttr{
+		TableName: "employee",
+		Key: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("101")},
+		},
+		ConditionExpression: "attribute_exists(emp_id)",
+		UpdateExpression:    "SET age = :age",
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":age": {N: aws.String("10")},
+		},
+	}
+
+	UpdateItemTestCase13Name = "13: attribute_exists on the key updates an existing item"
+	UpdateItemTestCase13     = models.UpdateAttr{
+		TableName: "employee",
+		Key: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("1")},
+		},
+		ConditionExpression: "attribute_exists(emp_id)",
+		UpdateExpression:    "SET age = :age",
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":age": {N: aws.String("10")},
+		},
+		ReturnValues: "ALL_NEW",
+	}
+	UpdateItemTestCase13Output = `{"Attributes":{"address":{"S":"Shamli"},"age":{"N":"10"},"emp_id":{"N":"1"},"first_name":{"S":"Marc"},"last_name":{"S":"Richards"}}}`
+
+	UpdateItemTestCase14Name = "14: ALL_NEW returns the whole item after the update"
+	UpdateItemTestCase14     = models.UpdateAttr{
+		TableName: "employee",
+		Key: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("2")},
+		},
+		UpdateExpression: "SET age = :age",
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":age": {N: aws.String("20")},
+		},
+		ReturnValues: "ALL_NEW",
+	}
+	UpdateItemTestCase14Output = `{"Attributes":{"address":{"S":"Ney York"},"age":{"N":"20"},"emp_id":{"N":"2"},"first_name":{"S":"Catalina"},"last_name":{"S":"Smith"}}}`
 )
 
 //Test Data for PutItem API
@@ -954,6 +1087,82 @@ var (
 			"age":    {N: aws.String("10")},
 		},
 	}
+
+	PutItemTestCase10Name = "10: ReturnValues ALL_OLD on an existing item"
+	PutItemTestCase10     = models.Meta{
+		TableName: "employee",
+		Item: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("1")},
+			"age":    {N: aws.String("10")},
+		},
+		ReturnValues: "ALL_OLD",
+	}
+	PutItemTestCase10Output = `{"Attributes":{"address":{"S":"Shamli"},"age":{"N":"10"},"emp_id":{"N":"1"},"first_name":{"S":"Marc"},"last_name":{"S":"Richards"}}}`
+
+	//400 bad request
+	PutItemTestCase11Name = "11: ReturnValues not supported by PutItem"
+	PutItemTestCase11     = models.Meta{
+		TableName: "employee",
+		Item: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("1")},
+			"age":    {N: aws.String("10")},
+		},
+		ReturnValues: "ALL_NEW",
+	}
+
+	PutItemTestCase12Name = "12: ConditionExpression with begins_with"
+	PutItemTestCase12     = models.Meta{
+		TableName: "employee",
+		Item: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("1")},
+			"age":    {N: aws.String("10")},
+		},
+		ConditionExpression: "begins_with(first_name, :prefix)",
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":prefix": {S: aws.String("Ma")},
+		},
+	}
+
+	//400 bad request
+	PutItemTestCase13Name = "13: ConditionExpression with begins_with not matching"
+	PutItemTestCase13     = models.Meta{
+		TableName: "employee",
+		Item: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("1")},
+			"age":    {N: aws.String("10")},
+		},
+		ConditionExpression: "begins_with(first_name, :prefix)",
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":prefix": {S: aws.String("Ca")},
+		},
+	}
+
+	PutItemTestCase14Name = "14: ConditionExpression with size"
+	PutItemTestCase14     = models.Meta{
+		TableName: "employee",
+		Item: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("1")},
+			"age":    {N: aws.String("10")},
+		},
+		ConditionExpression: "size(address) <= :max",
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":max": {N: aws.String("10")},
+		},
+	}
+
+	//400 bad request
+	PutItemTestCase15Name = "15: ConditionExpression with size over the bound"
+	PutItemTestCase15     = models.Meta{
+		TableName: "employee",
+		Item: map[string]*dynamodb.AttributeValue{
+			"emp_id": {N: aws.String("1")},
+			"age":    {N: aws.String("10")},
+		},
+		ConditionExpression: "size(address) <= :max",
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":max": {N: aws.String("3")},
+		},
+	}
 )
 
 //Test Data DeleteItem API
@@ -1729,6 +1938,9 @@ func testQueryAPI(t *testing.T) {
 		createPostTestCase("count with other attributes present", "/v1/Query", queryTestCaseOutput14, queryTestCase14),
 		createPostTestCase("Select with other than count", "/v1/Query", queryTestCaseOutput15, queryTestCase15),
 		createPostTestCase("all attributes", "/v1/Query", queryTestCaseOutput16, queryTestCase16),
+		createPostTestCase("first page of 2", "/v1/Query", queryTestCaseOutput17, queryTestCase17),
+		createPostTestCase("second page of 2", "/v1/Query", queryTestCaseOutput18, queryTestCase18),
+		createPostTestCase("last page of 2", "/v1/Query", queryTestCaseOutput19, queryTestCase19),
 	}
 	apitest.RunTests(t, tests)
 }
@@ -1792,6 +2004,11 @@ func testScanAPI(t *testing.T) {
 		createPostTestCase(ScanTestCase11Name, "/v1/Query", ScanTestCase11Output, ScanTestCase11),
 		createPostTestCase(ScanTestCase12Name, "/v1/Query", ScanTestCase12Output, ScanTestCase12),
 		createPostTestCase(ScanTestCase13Name, "/v1/Query", ScanTestCase13Output, ScanTestCase13),
+		createPostTestCase(ScanTestCase14Name, "/v1/Scan", ScanTestCase14Output, ScanTestCase14),
+		createStatusCheckPostTestCase(ScanTestCase15Name, "/v1/PutItem", http.StatusOK, ScanTestCase15),
+		createPostTestCase(ScanTestCase16Name, "/v1/Scan", ScanTestCase16Output, ScanTestCase16),
+		createStatusCheckPostTestCase(ScanTestCase17Name, "/v1/DeleteItem", http.StatusOK, ScanTestCase17),
+		createPostTestCase(ScanTestCase18Name, "/v1/Scan", ScanTestCase18Output, ScanTestCase18),
 	}
 	apitest.RunTests(t, tests)
 }
@@ -1812,6 +2029,10 @@ func testUpdateItemAPI(t *testing.T) {
 		createPostTestCase(UpdateItemTestCase2Name, "/v1/UpdateItem", UpdateItemTestCase2Output, UpdateItemTestCase2),
 		createPostTestCase(UpdateItemTestCase3Name, "/v1/UpdateItem", UpdateItemTestCase3Output, UpdateItemTestCase3),
 		createPostTestCase(UpdateItemTestCase7Name, "/v1/UpdateItem", UpdateItemTestCase7Output, UpdateItemTestCase7),
+		createPostTestCase(UpdateItemTestCase11Name, "/v1/UpdateItem", UpdateItemTestCase11Output, UpdateItemTestCase11),
+		createStatusCheckPostTestCase(UpdateItemTestCase12Name, "/v1/UpdateItem", http.StatusBadRequest, UpdateItemTestCase12),
+		createPostTestCase(UpdateItemTestCase13Name, "/v1/UpdateItem", UpdateItemTestCase13Output, UpdateItemTestCase13),
+		createPostTestCase(UpdateItemTestCase14Name, "/v1/UpdateItem", UpdateItemTestCase14Output, UpdateItemTestCase14),
 	}
 	apitest.RunTests(t, tests)
 }
@@ -1833,6 +2054,12 @@ func testPutItemAPI(t *testing.T) {
 		createPostTestCase(PutItemTestCase3Name, "/v1/PutItem", PutItemTestCase3Output, PutItemTestCase3),
 		createPostTestCase(PutItemTestCase4Name, "/v1/PutItem", PutItemTestCase4Output, PutItemTestCase4),
 		createStatusCheckPostTestCase(PutItemTestCase9Name, "/v1/PutItem", http.StatusOK, PutItemTestCase9),
+		createPostTestCase(PutItemTestCase10Name, "/v1/PutItem", PutItemTestCase10Output, PutItemTestCase10),
+		createStatusCheckPostTestCase(PutItemTestCase11Name, "/v1/PutItem", http.StatusBadRequest, PutItemTestCase11),
+		createStatusCheckPostTestCase(PutItemTestCase12Name, "/v1/PutItem", http.StatusOK, PutItemTestCase12),
+		createStatusCheckPostTestCase(PutItemTestCase13Name, "/v1/PutItem", http.StatusBadRequest, PutItemTestCase13),
+		createStatusCheckPostTestCase(PutItemTestCase14Name, "/v1/PutItem", http.StatusOK, PutItemTestCase14),
+		createStatusCheckPostTestCase(PutItemTestCase15Name, "/v1/PutItem", http.StatusBadRequest, PutItemTestCase15),
 	}
 	apitest.RunTests(t, tests)
 }
@@ -1878,6 +2105,69 @@ func testBatchWriteItemAPI(t *testing.T) {
 	apitest.RunTests(t, tests)
 }
 
+// testConditionalAddAPI increments a counter from parallel requests, each
+// guarded by the limit, which the counter never exceeds
+func testConditionalAddAPI(t *testing.T) {
+	const (
+		limit    = 5
+		requests = 20
+	)
+	handler := handlerInitFunc()
+	post := func(url string, input interface{}) (int, string) {
+		body, err := json.Marshal(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code, w.Body.String()
+	}
+	key := map[string]*dynamodb.AttributeValue{"emp_id": {N: aws.String("500")}}
+	if code, body := post("/v1/UpdateItem", models.UpdateAttr{
+		TableName:                 "employee",
+		Key:                       key,
+		UpdateExpression:          "SET age = :zero",
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":zero": {N: aws.String("0")}},
+	}); code != http.StatusOK {
+		t.Fatal("creating the counter failed:", code, body)
+	}
+
+	increment := models.UpdateAttr{
+		TableName:           "employee",
+		Key:                 key,
+		UpdateExpression:    "ADD age :one",
+		ConditionExpression: "age < :limit",
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":one":   {N: aws.String("1")},
+			":limit": {N: aws.String(fmt.Sprint(limit))},
+		},
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	codes := map[int]int{}
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			code, _ := post("/v1/UpdateItem", increment)
+			mu.Lock()
+			codes[code]++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if codes[http.StatusOK] != limit || codes[http.StatusBadRequest] != requests-limit {
+		t.Error("expected", limit, "increments and", requests-limit, "failed conditions, got", codes)
+	}
+
+	code, body := post("/v1/GetItem", models.GetItemMeta{TableName: "employee", Key: key, ProjectionExpression: "age"})
+	if want := `{"Item":{"age":{"N":"5"}}}`; code != http.StatusOK || body != want {
+		t.Error("expected", want, "got", code, body)
+	}
+}
+
 func TestApi(t *testing.T) {
 	// this is done to maintain the order of the test cases
 	var testNames = []string{
@@ -1890,6 +2180,7 @@ func TestApi(t *testing.T) {
 		"PutItemAPI",
 		"DeleteItemAPI",
 		"BatchWriteItemAPI",
+		"ConditionalAddAPI",
 	}
 
 	var tests = map[string]func(t *testing.T){
@@ -1902,6 +2193,7 @@ func TestApi(t *testing.T) {
 		"PutItemAPI":        testPutItemAPI,
 		"DeleteItemAPI":     testDeleteItemAPI,
 		"BatchWriteItemAPI": testBatchWriteItemAPI,
+		"ConditionalAddAPI": testConditionalAddAPI,
 	}
 
 	// setup the test database and tables
@@ -16,7 +16,6 @@ package integrationtest
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -25,6 +24,7 @@ import (
 
 	"cloud.google.com/go/spanner"
 	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
 	"google.golang.org/api/iterator"
 	adminpb "google.golang.org/genproto/googleapis/spanner/admin/database/v1"
 )
@@ -198,7 +198,7 @@ func spannerBatchPut(ctx context.Context, db string, m []*spanner.Mutation) erro
 	defer client.Close()
 
 	if _, err = client.Apply(ctx, m); err != nil {
-		return errors.New("ResourceNotFoundException: " + err.Error())
+		return errors.FromSpanner(err)
 	}
 	return nil
 }
@@ -17,18 +17,29 @@ package main
 import (
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	rice "github.com/GeertJohan/go.rice"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/api"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/api/rpc"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/docs"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/initializer"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/service/services"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/swaggo/gin-swagger/swaggerFiles"
+	"google.golang.org/grpc"
 )
 
+// streamStopTimeout bounds how long shutdown waits for pending stream publishes
+const streamStopTimeout = 10 * time.Second
+
 // starting point of the application
 
 // @title dynamodb-adapter APIs
@@ -47,7 +58,9 @@ func main() {
 		log.Fatalln(initErr)
 	}
 	r := gin.Default()
-	pprof.Register(r)
+	if config.ConfigurationMap.PprofEnabled {
+		registerPprof(r, config.ConfigurationMap.PprofAddr)
+	}
 	r.GET("/doc/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	docs.SwaggerInfo.Host = ""
 	r.GET("/", func(c *gin.Context) {
@@ -59,11 +72,46 @@ func main() {
 		c.JSON(404, gin.H{"code": "RouteNotFound"})
 	})
 	api.InitAPI(r)
+	var grpcServer *grpc.Server
+	if config.ConfigurationMap.GRPCAddr != "" {
+		grpcServer = rpc.NewServer()
+		go func() {
+			if err := rpc.Serve(grpcServer, config.ConfigurationMap.GRPCAddr); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
 	go func() {
-		err := r.Run(":9050")
+		err := r.Run(config.ListenAddr())
 		if err != nil {
 			log.Fatal(err)
 		}
 	}()
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
+	<-shutdown
+	// the gRPC calls in flight finish before the Spanner clients close
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
 	storage.GetStorageInstance().Close()
+	if err := services.StopStream(streamStopTimeout); err != nil {
+		log.Println(err)
+	}
+}
+
+// registerPprof serves the profiling routes on a separate admin server bound
+// to addr, or on r when addr is empty
+func registerPprof(r *gin.Engine, addr string) {
+	if addr == "" {
+		pprof.Register(r)
+		return
+	}
+	admin := gin.New()
+	pprof.Register(admin)
+	go func() {
+		if err := admin.Run(addr); err != nil {
+			log.Fatal(err)
+		}
+	}()
 }
@@ -24,14 +24,17 @@ import (
 
 // Meta struct
 type Meta struct {
-	TableName                 string                              `json:"TableName"`
-	AttrMap                   map[string]interface{}              `json:"AttrMap"`
-	ReturnValues              string                              `json:"ReturnValues"`
-	ConditionExpression       string                              `json:"ConditionExpression"`
-	ExpressionAttributeMap    map[string]interface{}              `json:"ExpressionAttributeMap"`
-	ExpressionAttributeNames  map[string]string                   `json:"ExpressionAttributeNames"`
-	ExpressionAttributeValues map[string]*dynamodb.AttributeValue `json:"ExpressionAttributeValues"`
-	Item                      map[string]*dynamodb.AttributeValue `json:"Item"`
+	TableName                 string                                      `json:"TableName"`
+	AttrMap                   map[string]interface{}                      `json:"AttrMap"`
+	ReturnValues              string                                      `json:"ReturnValues"`
+	ConditionExpression       string                                      `json:"ConditionExpression"`
+	ExpressionAttributeMap    map[string]interface{}                      `json:"ExpressionAttributeMap"`
+	ExpressionAttributeNames  map[string]string                           `json:"ExpressionAttributeNames"`
+	ExpressionAttributeValues map[string]*dynamodb.AttributeValue         `json:"ExpressionAttributeValues"`
+	Item                      map[string]*dynamodb.AttributeValue         `json:"Item"`
+	Expected                  map[string]*dynamodb.ExpectedAttributeValue `json:"Expected"`
+	ConditionalOperator       string                                      `json:"ConditionalOperator"`
+	ExpectedVersion           *dynamodb.AttributeValue                    `json:"ExpectedVersion,omitempty"`
 }
 
 // GetKeyMeta struct
@@ -70,11 +73,14 @@ type GetItemMeta struct {
 	ProjectionExpression     string                              `json:"ProjectionExpression"`
 	ExpressionAttributeNames map[string]string                   `json:"ExpressionAttributeNames"`
 	Key                      map[string]*dynamodb.AttributeValue `json:"Key"`
+	IfVersionNotEqual        *dynamodb.AttributeValue            `json:"IfVersionNotEqual,omitempty"`
+	ConsistentRead           *bool                               `json:"ConsistentRead"`
 }
 
 //BatchGetMeta struct
 type BatchGetMeta struct {
-	RequestItems map[string]BatchGetWithProjectionMeta `json:"RequestItems"`
+	RequestItems           map[string]BatchGetWithProjectionMeta `json:"RequestItems"`
+	ReturnConsumedCapacity string                                `json:"ReturnConsumedCapacity"`
 }
 
 // BatchGetWithProjectionMeta struct
@@ -84,17 +90,26 @@ type BatchGetWithProjectionMeta struct {
 	ProjectionExpression     string                                `json:"ProjectionExpression"`
 	ExpressionAttributeNames map[string]string                     `json:"ExpressionAttributeNames"`
 	Keys                     []map[string]*dynamodb.AttributeValue `json:"Keys"`
+	ConsistentRead           *bool                                 `json:"ConsistentRead"`
 }
 
 // Delete struct
 type Delete struct {
-	TableName                 string                              `json:"TableName"`
-	PrimaryKeyMap             map[string]interface{}              `json:"PrimaryKeyMap"`
-	ConditionExpression       string                              `json:"ConditionExpression"`
-	ExpressionAttributeMap    map[string]interface{}              `json:"ExpressionAttributeMap"`
-	Key                       map[string]*dynamodb.AttributeValue `json:"Key"`
-	ExpressionAttributeValues map[string]*dynamodb.AttributeValue `json:"ExpressionAttributeValues"`
-	ExpressionAttributeNames  map[string]string                   `json:"ExpressionAttributeNames"`
+	TableName                 string                                      `json:"TableName"`
+	PrimaryKeyMap             map[string]interface{}                      `json:"PrimaryKeyMap"`
+	ConditionExpression       string                                      `json:"ConditionExpression"`
+	ExpressionAttributeMap    map[string]interface{}                      `json:"ExpressionAttributeMap"`
+	Key                       map[string]*dynamodb.AttributeValue         `json:"Key"`
+	ExpressionAttributeValues map[string]*dynamodb.AttributeValue         `json:"ExpressionAttributeValues"`
+	ExpressionAttributeNames  map[string]string                           `json:"ExpressionAttributeNames"`
+	Expected                  map[string]*dynamodb.ExpectedAttributeValue `json:"Expected"`
+	ConditionalOperator       string                                      `json:"ConditionalOperator"`
+}
+
+// BatchConditionalDelete struct
+type BatchConditionalDelete struct {
+	TableName string   `json:"TableName"`
+	Deletes   []Delete `json:"Deletes"`
 }
 
 // BulkDelete struct
@@ -120,19 +135,34 @@ type Query struct {
 	ExpressionAttributeValues map[string]*dynamodb.AttributeValue `json:"ExpressionAttributeValues"`
 	ExclusiveStartKey         map[string]*dynamodb.AttributeValue `json:"ExclusiveStartKey"`
 	Select                    string                              `json:"Select"`
+	QueryFilter               map[string]*dynamodb.Condition      `json:"QueryFilter"`
+	AllowFullScan             bool                                `json:"AllowFullScan"`
+	ConsistentRead            *bool                               `json:"ConsistentRead"`
+	// ScanKeys are the key columns a Scan is ordered and paged by
+	ScanKeys []string `json:"-"`
+	// ScanDescending orders and pages by the ScanKeys in descending order
+	ScanDescending bool `json:"-"`
+}
+
+// BatchQuery struct
+type BatchQuery struct {
+	Queries []Query `json:"Queries"`
 }
 
 // UpdateAttr struct
 type UpdateAttr struct {
-	TableName                 string                              `json:"TableName"`
-	PrimaryKeyMap             map[string]interface{}              `json:"PrimaryKeyMap"`
-	ReturnValues              string                              `json:"ReturnValues"`
-	UpdateExpression          string                              `json:"UpdateExpression"`
-	ConditionExpression       string                              `json:"ConditionExpression"`
-	ExpressionAttributeMap    map[string]interface{}              `json:"AttrVals"`
-	ExpressionAttributeNames  map[string]string                   `json:"ExpressionAttributeNames"`
-	Key                       map[string]*dynamodb.AttributeValue `json:"Key"`
-	ExpressionAttributeValues map[string]*dynamodb.AttributeValue `json:"ExpressionAttributeValues"`
+	TableName                 string                                      `json:"TableName"`
+	PrimaryKeyMap             map[string]interface{}                      `json:"PrimaryKeyMap"`
+	ReturnValues              string                                      `json:"ReturnValues"`
+	UpdateExpression          string                                      `json:"UpdateExpression"`
+	ConditionExpression       string                                      `json:"ConditionExpression"`
+	ExpressionAttributeMap    map[string]interface{}                      `json:"AttrVals"`
+	ExpressionAttributeNames  map[string]string                           `json:"ExpressionAttributeNames"`
+	Key                       map[string]*dynamodb.AttributeValue         `json:"Key"`
+	ExpressionAttributeValues map[string]*dynamodb.AttributeValue         `json:"ExpressionAttributeValues"`
+	Expected                  map[string]*dynamodb.ExpectedAttributeValue `json:"Expected"`
+	ConditionalOperator       string                                      `json:"ConditionalOperator"`
+	ExpectedVersion           *dynamodb.AttributeValue                    `json:"ExpectedVersion,omitempty"`
 }
 
 //ScanMeta for Scan request
@@ -149,25 +179,97 @@ type ScanMeta struct {
 	ExpressionAttributeNames  map[string]string                   `json:"ExpressionAttributeNames"`
 	ExpressionAttributeMap    map[string]interface{}              `json:"ExpressionAttributeMap"`
 	ExpressionAttributeValues map[string]*dynamodb.AttributeValue `json:"ExpressionAttributeValues"`
+	ScanFilter                map[string]*dynamodb.Condition      `json:"ScanFilter"`
+	AllowFullScan             bool                                `json:"AllowFullScan"`
+	Segment                   int64                               `json:"Segment"`
+	TotalSegments             int64                               `json:"TotalSegments"`
+	ConsistentRead            *bool                               `json:"ConsistentRead"`
 }
 
 // TableConfig for Configuration table
 type TableConfig struct {
-	PartitionKey     string                 `json:"PartitionKey,omitempty"`
-	SortKey          string                 `json:"SortKey,omitempty"`
-	Indices          map[string]TableConfig `json:"Indices,omitempty"`
-	GCSSourcePath    string                 `json:"GcsSourcePath,omitempty"`
-	DDBIndexName     string                 `json:"DdbIndexName,omitempty"`
-	SpannerIndexName string                 `json:"Table,omitempty"`
-	IsPadded         bool                   `json:"IsPadded,omitempty"`
-	IsComplement     bool                   `json:"IsComplement,omitempty"`
-	TableSource      string                 `json:"TableSource,omitempty"`
-	ActualTable      string                 `json:"ActualTable,omitempty"`
+	PartitionKey         string                 `json:"PartitionKey,omitempty"`
+	SortKey              string                 `json:"SortKey,omitempty"`
+	Indices              map[string]TableConfig `json:"Indices,omitempty"`
+	GCSSourcePath        string                 `json:"GcsSourcePath,omitempty"`
+	DDBIndexName         string                 `json:"DdbIndexName,omitempty"`
+	SpannerIndexName     string                 `json:"Table,omitempty"`
+	IsPadded             bool                   `json:"IsPadded,omitempty"`
+	IsComplement         bool                   `json:"IsComplement,omitempty"`
+	TableSource          string                 `json:"TableSource,omitempty"`
+	ActualTable          string                 `json:"ActualTable,omitempty"`
+	SoftDelete           bool                   `json:"SoftDelete,omitempty"`
+	TombstoneRetention   string                 `json:"TombstoneRetention,omitempty"`
+	VersionAttribute     string                 `json:"VersionAttribute,omitempty"`
+	EncryptedAttributes  []string               `json:"EncryptedAttributes,omitempty"`
+	OverflowColumn       string                 `json:"OverflowColumn,omitempty"`
+	ExcludeByDefault     []string               `json:"ExcludeByDefault,omitempty"`
+	StrictProjection     bool                   `json:"StrictProjection,omitempty"`
+	CoalesceReads        bool                   `json:"CoalesceReads,omitempty"`
+	TableHints           map[string]string      `json:"TableHints,omitempty"`
+	StatementHints       map[string]string      `json:"StatementHints,omitempty"`
+	FilterableAttributes []string               `json:"FilterableAttributes,omitempty"`
+	ProjectionType       string                 `json:"ProjectionType,omitempty"`
+	NonKeyAttributes     []string               `json:"NonKeyAttributes,omitempty"`
+	InterleavedTables    map[string]string      `json:"InterleavedTables,omitempty"`
+	Defaults             map[string]interface{} `json:"Defaults,omitempty"`
+}
+
+// Tombstone columns written instead of deleting rows on SoftDelete tables
+const (
+	SoftDeleteColumn     = "dynamodb_adapter_deleted"
+	SoftDeleteTimeColumn = "dynamodb_adapter_deleted_at"
+)
+
+// PurgeTombstones struct
+type PurgeTombstones struct {
+	TableName string `json:"TableName"`
+}
+
+// ScanDelete struct
+type ScanDelete struct {
+	TableName                 string                              `json:"TableName"`
+	FilterExpression          string                              `json:"FilterExpression"`
+	ExpressionAttributeNames  map[string]string                   `json:"ExpressionAttributeNames"`
+	ExpressionAttributeValues map[string]*dynamodb.AttributeValue `json:"ExpressionAttributeValues"`
+	ExclusiveStartKey         map[string]*dynamodb.AttributeValue `json:"ExclusiveStartKey"`
+	Limit                     int64                               `json:"Limit"`
+}
+
+// ReadOnly struct
+type ReadOnly struct {
+	Enabled bool `json:"Enabled"`
+}
+
+// TableCount struct
+type TableCount struct {
+	TableName string `json:"TableName"`
+}
+
+// ValidateTable struct
+type ValidateTable struct {
+	TableName string `json:"TableName"`
+}
+
+// TableValidation is the report of the validate-table api
+type TableValidation struct {
+	TableName    string   `json:"TableName"`
+	SpannerTable string   `json:"SpannerTable"`
+	Valid        bool     `json:"Valid"`
+	Problems     []string `json:"Problems"`
+}
+
+// SegmentForKey struct
+type SegmentForKey struct {
+	TableName     string                              `json:"TableName"`
+	Key           map[string]*dynamodb.AttributeValue `json:"Key"`
+	TotalSegments int64                               `json:"TotalSegments"`
 }
 
 //BatchWriteItem for Batch Operation
 type BatchWriteItem struct {
-	RequestItems map[string][]BatchWriteSubItems `json:"RequestItems"`
+	RequestItems           map[string][]BatchWriteSubItems `json:"RequestItems"`
+	ReturnConsumedCapacity string                          `json:"ReturnConsumedCapacity"`
 }
 
 //BatchWriteSubItems is for BatchWriteItem
@@ -179,11 +281,23 @@ type BatchWriteSubItems struct {
 //BatchDeleteItem is for BatchWriteSubItems
 type BatchDeleteItem struct {
 	Key map[string]*dynamodb.AttributeValue `json:"Key"`
+	BatchWriteConditions
 }
 
 //BatchPutItem is for BatchWriteSubItems
 type BatchPutItem struct {
 	Item map[string]*dynamodb.AttributeValue `json:"Item"`
+	BatchWriteConditions
+}
+
+// BatchWriteConditions holds the condition fields which BatchWriteItem does
+// not support, they are only read to reject the request
+type BatchWriteConditions struct {
+	ConditionExpression       string                              `json:"ConditionExpression"`
+	ConditionalOperator       string                              `json:"ConditionalOperator"`
+	Expected                  map[string]interface{}              `json:"Expected"`
+	ExpressionAttributeNames  map[string]string                   `json:"ExpressionAttributeNames"`
+	ExpressionAttributeValues map[string]*dynamodb.AttributeValue `json:"ExpressionAttributeValues"`
 }
 
 // TableDDL - this contains the DDL
@@ -231,6 +345,14 @@ type UpdateExpressionCondition struct {
 	AddValues map[string]float64
 }
 
+// UpdateAction for a single clause of an update expression
+type UpdateAction struct {
+	Action       string
+	Attributes   map[string]interface{}
+	Expr         *UpdateExpressionCondition
+	ColsToRemove []string
+}
+
 type dynamodbAdapterTableDdl struct {
 	Table    string
 	Column   string
@@ -247,6 +369,9 @@ type ConfigControllerModel struct {
 	WriteMap          map[string]struct{}
 	StreamEnable      map[string]struct{}
 	PubSubTopic       map[string]string
+	ConsistentRead    map[string]bool
+	ReadCapacity      map[string]float64
+	WriteCapacity     map[string]float64
 }
 
 // ConfigController object for ConfigControllerModel
@@ -263,6 +388,9 @@ func init() {
 	ConfigController.WriteMap = make(map[string]struct{})
 	ConfigController.StreamEnable = make(map[string]struct{})
 	ConfigController.PubSubTopic = make(map[string]string)
+	ConfigController.ConsistentRead = make(map[string]bool)
+	ConfigController.ReadCapacity = make(map[string]float64)
+	ConfigController.WriteCapacity = make(map[string]float64)
 }
 
 // StreamDataModel for streaming data
@@ -20,19 +20,60 @@ import (
 	"strings"
 
 	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var errorMapping = map[string]string{
 	"Cancelled":          "ValidationError",
-	"DeadlineExceeded":   "ValidationError",
+	"DeadlineExceeded":   "InternalServerError",
 	"FailedPrecondition": "ConditionalCheckFailedException",
-	"Aborted":            "ValidationError",
+	"Aborted":            "InternalServerError",
+	"Unavailable":        "InternalServerError",
+	"ResourceExhausted":  "ProvisionedThroughputExceededException",
+}
+
+// grpcErrorMapping maps the transient grpc codes returned by Spanner to the
+// DynamoDB errors which the AWS SDKs retry
+var grpcErrorMapping = map[codes.Code]string{
+	codes.DeadlineExceeded:  "InternalServerError",
+	codes.Unavailable:       "InternalServerError",
+	codes.Aborted:           "InternalServerError",
+	codes.ResourceExhausted: "ProvisionedThroughputExceededException",
+}
+
+// spannerErrorMapping maps the grpc codes of the Spanner errors which are not
+// transient to the DynamoDB errors of the same cause
+var spannerErrorMapping = map[codes.Code]string{
+	codes.NotFound:           "ResourceNotFoundException",
+	codes.AlreadyExists:      "ConditionalCheckFailedException",
+	codes.FailedPrecondition: "ValidationException",
+	codes.InvalidArgument:    "ValidationException",
+	codes.PermissionDenied:   "AccessDeniedException",
+}
+
+// retryableErrors holds the error codes which are safe to retry along with
+// their http status code
+var retryableErrors = map[string]int{
+	"InternalServerError":                    http.StatusInternalServerError,
+	"ProvisionedThroughputExceededException": http.StatusBadRequest,
+	"ServiceUnavailable":                     http.StatusServiceUnavailable,
+}
+
+// errorStatus holds the http status code of the errors which are not
+// retryable and not sent as 400
+var errorStatus = map[string]int{
+	"RequestEntityTooLarge": http.StatusRequestEntityTooLarge,
+	"AccessDeniedException": http.StatusForbidden,
+	"UncaughtException":     http.StatusInternalServerError,
 }
 
 // Error - this is the error response
 type Error struct {
 	ErrorCode    string `json:"errorCode"`
 	ErrorMessage string `json:"message"`
+	// Parameter is the request parameter or attribute which failed validation
+	Parameter string `json:"parameter,omitempty"`
 }
 
 // Error - convert error into string
@@ -45,18 +86,88 @@ func New(errorCode string, logMessage ...interface{}) *Error {
 	err := new(Error)
 	err.ErrorCode = errorCode
 	err.ErrorMessage = fmt.Sprintln(logMessage...)
+	for _, msg := range logMessage {
+		if code, ok := grpcErrorCode(msg); ok {
+			err.ErrorCode = code
+			break
+		}
+	}
 	logger.ErrorLogging(err, logMessage)
 	return err
 }
 
+// FromSpanner returns the DynamoDB error of a Spanner error by its grpc code.
+// Transient codes become the retryable errors New maps them to, and codes
+// without a DynamoDB counterpart an UncaughtException. An *Error is returned
+// as is.
+func FromSpanner(err error, logMessage ...interface{}) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	code, ok := spannerErrorMapping[status.Code(err)]
+	if !ok {
+		code = "UncaughtException"
+	}
+	return New(code, append(logMessage, err)...)
+}
+
+// WithParameter sets the request parameter or attribute which failed, like
+// ExpressionAttributeValues.:age or Key.emp_id, which is sent as the
+// errorDetail of the response
+func (e *Error) WithParameter(parameter string) *Error {
+	e.Parameter = parameter
+	return e
+}
+
+// grpcErrorCode returns the DynamoDB error for a transient grpc error
+func grpcErrorCode(v interface{}) (string, bool) {
+	err, ok := v.(error)
+	if !ok {
+		return "", false
+	}
+	if _, ok := err.(*Error); ok {
+		return "", false
+	}
+	code, ok := grpcErrorMapping[status.Code(err)]
+	return code, ok
+}
+
+// response returns the http status code and the body of the error
+func (e Error) response() (int, interface{}) {
+	httpStatus, retryable := retryableErrors[e.ErrorCode]
+	if !retryable {
+		httpStatus, ok := errorStatus[e.ErrorCode]
+		if !ok {
+			httpStatus = http.StatusBadRequest
+		}
+		return httpStatus, e.body()
+	}
+	body := e.body()
+	body["retryable"] = true
+	return httpStatus, body
+}
+
+// body is the response body of the error, with the errorDetail naming the
+// failed parameter when it is known
+func (e Error) body() map[string]interface{} {
+	body := map[string]interface{}{"code": e.ErrorCode, "message": e.ErrorMessage}
+	if e.Parameter != "" {
+		body["errorDetail"] = map[string]interface{}{"parameter": e.Parameter}
+	}
+	return body
+}
+
 // HTTPResponse - this is used to set http response
 func HTTPResponse(err error, body interface{}) (int, interface{}) {
 	e, ok := err.(*Error)
 	if ok {
-		return http.StatusBadRequest, map[string]interface{}{"code": e.ErrorCode, "message": e.ErrorMessage}
+		return e.response()
 	}
 	logger.LogError(err)
 	logger.LogErrorF("body: %+v\n ", body)
+	if code, ok := grpcErrorCode(err); ok {
+		return Error{ErrorCode: code, ErrorMessage: err.Error()}.response()
+	}
 	return http.StatusInternalServerError, map[string]interface{}{"code": "UncaughtException", "message": err.Error()}
 }
 
@@ -64,7 +175,7 @@ func HTTPResponse(err error, body interface{}) (int, interface{}) {
 func (e Error) HTTPResponse(body interface{}) (int, interface{}) {
 	logger.LogErrorF("body: %+v\n ", body)
 
-	return http.StatusBadRequest, map[string]interface{}{"code": e.ErrorCode, "message": e.ErrorMessage}
+	return e.response()
 }
 
 // AssignError - this will assign error
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestNew(t *testing.T) {
@@ -38,6 +40,21 @@ func TestHTTPResponse(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, code)
 }
 
+func TestHTTPResponseStatus(t *testing.T) {
+	code, _ := New("RequestEntityTooLarge").HTTPResponse(nil)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+}
+
+func TestHTTPResponseErrorDetail(t *testing.T) {
+	code, body := New("ValidationException", "missing value").WithParameter("ExpressionAttributeValues.:age").HTTPResponse(nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, map[string]interface{}{"parameter": "ExpressionAttributeValues.:age"}, body.(map[string]interface{})["errorDetail"])
+
+	_, body = New("ValidationException", "missing value").HTTPResponse(nil)
+	_, ok := body.(map[string]interface{})["errorDetail"]
+	assert.False(t, ok)
+}
+
 func TestNewForExceptionalError(t *testing.T) {
 	e := New("E1001")
 	assert.Error(t, e)
@@ -49,3 +66,53 @@ func TestNewForSystemErrors(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, code)
 
 }
+
+func TestHTTPResponseForGRPCErrors(t *testing.T) {
+	tests := []struct {
+		testName   string
+		err        error
+		wantStatus int
+		wantCode   string
+		retryable  bool
+	}{
+		{"Unavailable", status.Error(codes.Unavailable, "unavailable"), http.StatusInternalServerError, "InternalServerError", true},
+		{"DeadlineExceeded", status.Error(codes.DeadlineExceeded, "deadline"), http.StatusInternalServerError, "InternalServerError", true},
+		{"Aborted", status.Error(codes.Aborted, "aborted"), http.StatusInternalServerError, "InternalServerError", true},
+		{"ResourceExhausted", status.Error(codes.ResourceExhausted, "exhausted"), http.StatusBadRequest, "ProvisionedThroughputExceededException", true},
+		{"Wrapped Unavailable", New("ResourceNotFoundException", status.Error(codes.Unavailable, "unavailable")), http.StatusInternalServerError, "InternalServerError", true},
+		{"NotFound", New("ResourceNotFoundException", status.Error(codes.NotFound, "not found")), http.StatusBadRequest, "ResourceNotFoundException", false},
+		{"Other grpc code", status.Error(codes.Internal, "internal"), http.StatusInternalServerError, "UncaughtException", false},
+	}
+
+	for _, tc := range tests {
+		code, body := HTTPResponse(tc.err, nil)
+		assert.Equal(t, tc.wantStatus, code, tc.testName)
+		resp := body.(map[string]interface{})
+		assert.Equal(t, tc.wantCode, resp["code"], tc.testName)
+		_, retryable := resp["retryable"]
+		assert.Equal(t, tc.retryable, retryable, tc.testName)
+	}
+}
+
+func TestFromSpanner(t *testing.T) {
+	tests := []struct {
+		testName   string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"NotFound", status.Error(codes.NotFound, "Table not found: employee"), http.StatusBadRequest, "ResourceNotFoundException"},
+		{"AlreadyExists", status.Error(codes.AlreadyExists, "Row [1] in table employee already exists"), http.StatusBadRequest, "ConditionalCheckFailedException"},
+		{"FailedPrecondition", status.Error(codes.FailedPrecondition, "Cannot specify a null value for column: emp_id"), http.StatusBadRequest, "ValidationException"},
+		{"PermissionDenied", status.Error(codes.PermissionDenied, "Caller is missing IAM permission spanner.databases.write"), http.StatusForbidden, "AccessDeniedException"},
+		{"transient", status.Error(codes.Unavailable, "unavailable"), http.StatusInternalServerError, "InternalServerError"},
+		{"unmapped", status.Error(codes.Internal, "internal"), http.StatusInternalServerError, "UncaughtException"},
+		{"adapter error", New("ServiceUnavailable", "read-only"), http.StatusServiceUnavailable, "ServiceUnavailable"},
+	}
+
+	for _, tc := range tests {
+		code, body := FromSpanner(tc.err, "batch put failed").HTTPResponse(nil)
+		assert.Equal(t, tc.wantStatus, code, tc.testName)
+		assert.Equal(t, tc.wantCode, body.(map[string]interface{})["code"], tc.testName)
+	}
+}
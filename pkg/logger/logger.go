@@ -84,6 +84,11 @@ func LogWarn(message ...interface{}) {
 	logger.Warn(message)
 }
 
+// LogWarnw - This is Warn level log with structured fields
+func LogWarnw(message string, keysAndValues ...interface{}) {
+	logger.Warnw(message, keysAndValues...)
+}
+
 // LogDebug - This is debug level log
 func LogDebug(message ...interface{}) {
 	if env != "PRODUCTION" {
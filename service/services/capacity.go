@@ -0,0 +1,118 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DynamoDB charges one read unit per 4 KB and one write unit per 1 KB of item
+const (
+	readUnitSize  = 4096
+	writeUnitSize = 1024
+)
+
+// ItemSize returns the size of an item the way DynamoDB sizes it: the length
+// of the attribute names plus the size of their values
+func ItemSize(item map[string]interface{}) int {
+	size := 0
+	for k, v := range item {
+		size += len(k) + valueSize(v)
+	}
+	return size
+}
+
+func valueSize(v interface{}) int {
+	switch x := v.(type) {
+	case string:
+		return len(x)
+	case []byte:
+		return len(x)
+	case float64:
+		return numberSize(strconv.FormatFloat(x, 'f', -1, 64))
+	case int64:
+		return numberSize(strconv.FormatInt(x, 10))
+	case int:
+		return numberSize(strconv.Itoa(x))
+	case []interface{}:
+		size := 3
+		for _, e := range x {
+			size += 1 + valueSize(e)
+		}
+		return size
+	case []string:
+		size := 3
+		for _, e := range x {
+			size += 1 + len(e)
+		}
+		return size
+	case []float64:
+		size := 3
+		for _, e := range x {
+			size += 1 + valueSize(e)
+		}
+		return size
+	case map[string]interface{}:
+		return 3 + ItemSize(x) + len(x)
+	}
+	// BOOL and NULL
+	return 1
+}
+
+// numberSize is one byte per two significant digits plus one byte
+func numberSize(n string) int {
+	digits := strings.Trim(strings.NewReplacer("-", "", ".", "").Replace(n), "0")
+	return (len(digits)+1)/2 + 1
+}
+
+// ReadCapacityUnits returns the read units of reading the items of a batch
+// of requested keys. Keys without an item cost one unit like the smallest
+// item, and eventually consistent reads cost half.
+func ReadCapacityUnits(items []map[string]interface{}, requested int, consistentRead bool) float64 {
+	units := 0.0
+	for _, item := range items {
+		units += capacityUnits(ItemSize(item), readUnitSize)
+	}
+	if missing := requested - len(items); missing > 0 {
+		units += float64(missing)
+	}
+	if !consistentRead {
+		units /= 2
+	}
+	return units
+}
+
+// QueryCapacityUnits returns the read units of a Query or Scan which read
+// items of size bytes in total, rounded up once like in DynamoDB. Queries
+// read with a bounded staleness, so they cost half.
+func QueryCapacityUnits(size int) float64 {
+	return capacityUnits(size, readUnitSize) / 2
+}
+
+// WriteCapacityUnits returns the write units of writing the items
+func WriteCapacityUnits(items []map[string]interface{}) float64 {
+	units := 0.0
+	for _, item := range items {
+		units += capacityUnits(ItemSize(item), writeUnitSize)
+	}
+	return units
+}
+
+// capacityUnits rounds the size up to units of unitSize, at least one
+func capacityUnits(size, unitSize int) float64 {
+	return math.Max(1, math.Ceil(float64(size)/float64(unitSize)))
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestItemSize(t *testing.T) {
+	tests := []struct {
+		testName string
+		item     map[string]interface{}
+		want     int
+	}{
+		{"string", map[string]interface{}{"name": "Marc"}, 8},
+		{"number", map[string]interface{}{"age": float64(12345)}, 3 + 4},
+		{"bool and null", map[string]interface{}{"ok": true, "x": nil}, 2 + 1 + 1 + 1},
+		{"list", map[string]interface{}{"tags": []interface{}{"a", "bc"}}, 4 + 3 + 2 + 3},
+		{"map", map[string]interface{}{"p": map[string]interface{}{"c": "Pune"}}, 1 + 3 + 5 + 1},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, ItemSize(tc.item), tc.want)
+	}
+}
+
+func TestCapacityUnits(t *testing.T) {
+	small := map[string]interface{}{"emp_id": float64(1), "name": "Marc"}
+	large := map[string]interface{}{"emp_id": float64(2), "bio": strings.Repeat("a", 5000)}
+
+	assert.Equal(t, ReadCapacityUnits([]map[string]interface{}{small, large}, 2, true), float64(3))
+	assert.Equal(t, ReadCapacityUnits([]map[string]interface{}{small, large}, 2, false), 1.5)
+	assert.Equal(t, ReadCapacityUnits([]map[string]interface{}{small}, 3, true), float64(3))
+	assert.Equal(t, WriteCapacityUnits([]map[string]interface{}{small, large}), float64(1+5))
+	assert.Equal(t, WriteCapacityUnits(nil), float64(0))
+	assert.Equal(t, QueryCapacityUnits(0), 0.5)
+	assert.Equal(t, QueryCapacityUnits(ItemSize(small)+ItemSize(large)), float64(1))
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
+	"golang.org/x/sync/singleflight"
+)
+
+// getGroup coalesces the identical concurrent GetItems of CoalesceReads tables
+var getGroup singleflight.Group
+
+// getWithProjection is the read shared by coalesced GetItems
+var getWithProjection = GetWithProjection
+
+// coalescedReadTimeout bounds a shared read, which no request can cancel
+const coalescedReadTimeout = 10 * time.Second
+
+// detachedContext keeps the values of a context, like its trace span, but not
+// its cancellation, so a shared read is not cancelled with the request that
+// started it
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// sharedGet is the item read by coalesced GetItems and the timestamp the
+// shared read read at, which every request sends
+type sharedGet struct {
+	item   map[string]interface{}
+	readAt time.Time
+}
+
+// getKey identifies the GetItems reading the same projection of an item
+type getKey struct {
+	TableName                string
+	Key                      map[string]interface{}
+	ProjectionExpression     string
+	ExpressionAttributeNames map[string]string
+}
+
+// CoalescedGetWithProjection reads like GetWithProjection, but on a table with
+// CoalesceReads the eventually consistent GetItems of the same item and
+// projection which run at the same time share one Spanner read. Strongly
+// consistent reads, including the ones of a session which read its own
+// writes, are never coalesced, since the shared read might have started
+// before their request.
+func CoalescedGetWithProjection(ctx context.Context, tableName string, primaryKeyMap map[string]interface{}, projectionExpression string, expressionAttributeNames map[string]string, consistentRead bool) (map[string]interface{}, error) {
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil || !tableConf.CoalesceReads || consistentRead || storage.ConsistentReads(ctx) {
+		return getWithProjection(ctx, tableName, primaryKeyMap, projectionExpression, expressionAttributeNames)
+	}
+	key, err := json.Marshal(getKey{tableName, primaryKeyMap, projectionExpression, expressionAttributeNames})
+	if err != nil {
+		return getWithProjection(ctx, tableName, primaryKeyMap, projectionExpression, expressionAttributeNames)
+	}
+	v, err, _ := getGroup.Do(string(key), func() (interface{}, error) {
+		readCtx, cancel := context.WithTimeout(storage.WithReadTimestamp(detachedContext{ctx}), coalescedReadTimeout)
+		defer cancel()
+		item, err := getWithProjection(readCtx, tableName, primaryKeyMap, projectionExpression, expressionAttributeNames)
+		readAt, _ := storage.ReadTimestamp(readCtx)
+		return sharedGet{item, readAt}, err
+	})
+	shared, _ := v.(sharedGet)
+	if !shared.readAt.IsZero() {
+		storage.AddReadTimestamp(ctx, shared.readAt)
+	}
+	if err != nil {
+		return nil, err
+	}
+	item := shared.item
+	if item == nil {
+		return nil, nil
+	}
+	// every caller gets its own copy of the shared item
+	res := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		res[k] = v
+	}
+	return res, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestCoalescedGetWithProjection(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{
+		"hot":   {PartitionKey: "id", CoalesceReads: true},
+		"plain": {PartitionKey: "id"},
+	}
+	defer func() { getWithProjection = GetWithProjection }()
+
+	tests := []struct {
+		testName       string
+		tableName      string
+		ctx            context.Context
+		consistentRead bool
+		wantReads      int32
+	}{
+		{"eventually consistent reads", "hot", context.Background(), false, 1},
+		{"strongly consistent reads", "hot", context.Background(), true, 5},
+		{"reads of a session after its writes", "hot", storage.WithConsistentReads(context.Background()), false, 5},
+		{"table without CoalesceReads", "plain", context.Background(), false, 5},
+	}
+	for _, tc := range tests {
+		var reads int32
+		release := make(chan struct{})
+		getWithProjection = func(ctx context.Context, tableName string, primaryKeyMap map[string]interface{}, projectionExpression string, expressionAttributeNames map[string]string) (map[string]interface{}, error) {
+			atomic.AddInt32(&reads, 1)
+			<-release
+			return map[string]interface{}{"id": primaryKeyMap["id"]}, nil
+		}
+		var wg sync.WaitGroup
+		items := make([]map[string]interface{}, 5)
+		for i := range items {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				items[i], _ = CoalescedGetWithProjection(tc.ctx, tc.tableName, map[string]interface{}{"id": "k1"}, "", nil, tc.consistentRead)
+			}(i)
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		assert.Equal(t, atomic.LoadInt32(&reads), tc.wantReads)
+		for _, item := range items {
+			assert.Equal(t, item, map[string]interface{}{"id": "k1"})
+		}
+		items[0]["id"] = "changed"
+		assert.Equal(t, items[1]["id"], "k1")
+	}
+}
+
+func TestCoalescedReadTimestamp(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{"hot": {PartitionKey: "id", CoalesceReads: true}}
+	defer func() { getWithProjection = GetWithProjection }()
+	readAt := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	release := make(chan struct{})
+	getWithProjection = func(ctx context.Context, tableName string, primaryKeyMap map[string]interface{}, projectionExpression string, expressionAttributeNames map[string]string) (map[string]interface{}, error) {
+		<-release
+		storage.AddReadTimestamp(ctx, readAt)
+		return map[string]interface{}{"id": primaryKeyMap["id"]}, nil
+	}
+	var wg sync.WaitGroup
+	ctxs := make([]context.Context, 5)
+	for i := range ctxs {
+		ctxs[i] = storage.WithReadTimestamp(context.Background())
+		wg.Add(1)
+		go func(ctx context.Context) {
+			defer wg.Done()
+			CoalescedGetWithProjection(ctx, "hot", map[string]interface{}{"id": "k1"}, "", nil, false)
+		}(ctxs[i])
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	// every request sharing the read gets its timestamp, not only the leader
+	for _, ctx := range ctxs {
+		ts, ok := storage.ReadTimestamp(ctx)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, ts, readAt)
+	}
+}
+
+func TestCoalescedReadDeadline(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{"hot": {PartitionKey: "id", CoalesceReads: true}}
+	defer func() { getWithProjection = GetWithProjection }()
+	var deadline time.Time
+	var readErr error
+	getWithProjection = func(ctx context.Context, tableName string, primaryKeyMap map[string]interface{}, projectionExpression string, expressionAttributeNames map[string]string) (map[string]interface{}, error) {
+		deadline, _ = ctx.Deadline()
+		readErr = ctx.Err()
+		return map[string]interface{}{"id": primaryKeyMap["id"]}, nil
+	}
+	// the shared read outlives the cancelled request, but not its own timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	item, err := CoalescedGetWithProjection(ctx, "hot", map[string]interface{}{"id": "k1"}, "", nil, false)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, item, map[string]interface{}{"id": "k1"})
+	assert.Equal(t, readErr, nil)
+	assert.Equal(t, deadline.IsZero(), false)
+	assert.Equal(t, time.Until(deadline) <= coalescedReadTimeout, true)
+}
@@ -23,6 +23,7 @@ import (
 	"sync/atomic"
 
 	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
@@ -105,6 +106,9 @@ func fetchConfigData() {
 	defer models.ConfigController.Mux.Unlock()
 	models.ConfigController.ReadMap = map[string]struct{}{}
 	models.ConfigController.WriteMap = map[string]struct{}{}
+	models.ConfigController.ConsistentRead = map[string]bool{}
+	models.ConfigController.ReadCapacity = map[string]float64{}
+	models.ConfigController.WriteCapacity = map[string]float64{}
 	percentMap = make(map[string]int64)
 	counterTableIndex = make(map[string]int)
 	counters = make([]int64, len(data))
@@ -156,6 +160,40 @@ func parseConfig(table string, config string, count int) {
 			}
 		}
 	}
+	if len(tokens) > 3 && tokens[3] != "" {
+		models.ConfigController.ConsistentRead[table] = tokens[3] == "1"
+	}
+	if len(tokens) > 4 {
+		if units, err := strconv.ParseFloat(tokens[4], 64); err == nil && units > 0 {
+			models.ConfigController.ReadCapacity[table] = units
+		}
+	}
+	if len(tokens) > 5 {
+		if units, err := strconv.ParseFloat(tokens[5], 64); err == nil && units > 0 {
+			models.ConfigController.WriteCapacity[table] = units
+		}
+	}
+}
+
+// IsConsistentRead reports if a read of the table has to be strongly
+// consistent. The ConsistentRead of the request takes precedence over the one
+// of the table in dynamodb_adapter_config_manager, which takes precedence
+// over the ConsistentRead of the config. Reads are eventually consistent, as
+// in DynamoDB, when none of them is set.
+func IsConsistentRead(tableName string, consistentRead *bool) bool {
+	if consistentRead != nil {
+		return *consistentRead
+	}
+	models.ConfigController.Mux.RLock()
+	tableDefault, ok := models.ConfigController.ConsistentRead[tableName]
+	models.ConfigController.Mux.RUnlock()
+	if ok {
+		return tableDefault
+	}
+	if config.ConfigurationMap.ConsistentRead != nil {
+		return *config.ConfigurationMap.ConsistentRead
+	}
+	return false
 }
 
 // IsStreamEnabled checks if a table is enabled for streaming or not
@@ -17,6 +17,7 @@ package services
 import (
 	"testing"
 
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
 	"gopkg.in/go-playground/assert.v1"
 )
@@ -106,3 +107,33 @@ func TestIsPubSubAllowed(t *testing.T) {
 		assert.Equal(t, got2, tc.want2)
 	}
 }
+
+func TestIsConsistentRead(t *testing.T) {
+	defer func() {
+		models.ConfigController.ConsistentRead = map[string]bool{}
+		config.ConfigurationMap.ConsistentRead = nil
+	}()
+	parseConfig("strong_table", "1,1,,1", 0)
+	parseConfig("eventual_table", "1,1,,0", 0)
+	parseConfig("unset_table", "1,1", 0)
+	strong, eventual := true, false
+
+	tests := []struct {
+		testName       string
+		tableName      string
+		consistentRead *bool
+		globalDefault  *bool
+		want           bool
+	}{
+		{"nothing set", "unset_table", nil, nil, false},
+		{"global default", "unset_table", nil, &eventual, false},
+		{"table over global default", "strong_table", nil, &eventual, true},
+		{"eventual table", "eventual_table", nil, nil, false},
+		{"request over table", "eventual_table", &strong, nil, true},
+		{"request over global default", "unset_table", &eventual, &strong, false},
+	}
+	for _, tc := range tests {
+		config.ConfigurationMap.ConsistentRead = tc.globalDefault
+		assert.Equal(t, IsConsistentRead(tc.tableName, tc.consistentRead), tc.want)
+	}
+}
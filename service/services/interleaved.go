@@ -0,0 +1,218 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
+)
+
+// itemTable returns the table storing the item with the key of keyMap. It is
+// the interleaved child table of the longest InterleavedTables prefix of the
+// sort key, or the table itself when no prefix matches.
+func itemTable(tableConf models.TableConfig, keyMap map[string]interface{}) string {
+	if len(tableConf.InterleavedTables) == 0 || tableConf.SortKey == "" {
+		return tableConf.ActualTable
+	}
+	sValue, ok := keyMap[tableConf.SortKey].(string)
+	if !ok {
+		return tableConf.ActualTable
+	}
+	table, longest := tableConf.ActualTable, -1
+	for prefix, child := range tableConf.InterleavedTables {
+		if strings.HasPrefix(sValue, prefix) && len(prefix) > longest {
+			table, longest = child, len(prefix)
+		}
+	}
+	return table
+}
+
+// interleavedTables returns the table and its interleaved child tables in
+// the order they are read
+func interleavedTables(tableConf models.TableConfig) []string {
+	children := []string{}
+	for _, child := range tableConf.InterleavedTables {
+		if !stringInSlice(child, children) {
+			children = append(children, child)
+		}
+	}
+	sort.Strings(children)
+	return append([]string{tableConf.ActualTable}, children...)
+}
+
+// interleavedGroups splits the items of a batch by the table storing them.
+// The tables are nil when the table has no InterleavedTables.
+func interleavedGroups(tableConf models.TableConfig, items []map[string]interface{}) ([]string, map[string][]map[string]interface{}) {
+	if len(tableConf.InterleavedTables) == 0 {
+		return nil, nil
+	}
+	groups := map[string][]map[string]interface{}{}
+	for _, item := range items {
+		table := itemTable(tableConf, item)
+		groups[table] = append(groups[table], item)
+	}
+	tables := []string{}
+	for _, table := range interleavedTables(tableConf) {
+		if len(groups[table]) > 0 {
+			tables = append(tables, table)
+		}
+	}
+	return tables, groups
+}
+
+// queryInterleaved runs a query or a scan on the table and each of its
+// interleaved child tables. Spanner stores the child rows with the parent row
+// of the same partition key, so every read of a query stays within one split.
+// The pages are merged in key order and continue after the keys of the last
+// item.
+func queryInterleaved(ctx context.Context, query models.Query, tableConf models.TableConfig) (map[string]interface{}, string, error) {
+	pKey, sKey := tableConf.PartitionKey, tableConf.SortKey
+	isScan := len(query.ScanKeys) > 0
+	if !isScan {
+		if err := validateKeyCondition(query.RangeExp, pKey, sKey); err != nil {
+			return nil, "", err
+		}
+		if err := validateQueryFilter(query.FilterExp, pKey, sKey); err != nil {
+			return nil, "", err
+		}
+	}
+	// a Scan returns the items in key order, whatever its ScanIndexForward
+	query.SortAscending = query.SortAscending || isScan
+	originalLimit := query.Limit
+	if originalLimit <= 0 {
+		originalLimit = defaultQueryLimit
+	}
+	var count int64
+	var items []map[string]interface{}
+	var hash string
+	for _, table := range interleavedTables(tableConf) {
+		q := interleavedQuery(query, table, pKey, sKey, originalLimit)
+		stmt, cols, isCountQuery, _, h, err := createSpannerQuery(&q, pKey, sKey, pKey, sKey)
+		if err != nil {
+			return nil, "", err
+		}
+		if hash == "" {
+			hash = h
+		}
+		logger.LogDebug(stmt)
+		resp, err := storage.GetStorageInstance().ExecuteSpannerQuery(ctx, table, cols, isCountQuery, stmt)
+		if err != nil {
+			return nil, "", err
+		}
+		if isCountQuery {
+			if n, ok := resp[0]["Count"].(int64); ok {
+				count += n
+			}
+			continue
+		}
+		items = append(items, projectItemPaths(resp, query.ProjectionExpression, query.ExpressionAttributeNames)...)
+	}
+	if query.OnlyCount {
+		return map[string]interface{}{"Count": count, "Items": []map[string]interface{}{}, "LastEvaluatedKey": nil}, hash, nil
+	}
+	return mergeInterleaved(items, pKey, sKey, query.SortAscending, originalLimit), hash, nil
+}
+
+// interleavedQuery returns the query of one table of the hierarchy, which
+// reads one item past the limit in key order after the ExclusiveStartKey
+func interleavedQuery(query models.Query, table, pKey, sKey string, limit int64) models.Query {
+	query.TableName = table
+	query.ScanKeys = []string{pKey, sKey}
+	query.ScanDescending = !query.SortAscending
+	query.Limit = limit + 1
+	return query
+}
+
+// mergeInterleaved merges the pages read from the tables of the hierarchy
+// into one page of at most limit items in partition key and sort key order
+func mergeInterleaved(items []map[string]interface{}, pKey, sKey string, ascending bool, limit int64) map[string]interface{} {
+	less := func(a, b map[string]interface{}) bool {
+		if keyLess(a[pKey], b[pKey]) || keyLess(b[pKey], a[pKey]) {
+			return keyLess(a[pKey], b[pKey])
+		}
+		return keyLess(a[sKey], b[sKey])
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if ascending {
+			return less(items[i], items[j])
+		}
+		return less(items[j], items[i])
+	})
+	finalResp := map[string]interface{}{"LastEvaluatedKey": nil}
+	if int64(len(items)) > limit {
+		items = items[:limit]
+		last := items[len(items)-1]
+		finalResp["LastEvaluatedKey"] = map[string]interface{}{pKey: last[pKey], sKey: last[sKey]}
+	}
+	if items == nil {
+		items = []map[string]interface{}{}
+	}
+	finalResp["Count"] = len(items)
+	finalResp["Items"] = items
+	return finalResp
+}
+
+// keyLess orders sort key values the way Spanner orders the column
+func keyLess(a, b interface{}) bool {
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return x < y
+		}
+	case int64:
+		if y, ok := b.(int64); ok {
+			return x < y
+		}
+	case float64:
+		if y, ok := b.(float64); ok {
+			return x < y
+		}
+	case []byte:
+		if y, ok := b.([]byte); ok {
+			return bytes.Compare(x, y) < 0
+		}
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+// interleavedProblems checks that the InterleavedTables of the table config
+// have a table config with the same key schema
+func interleavedProblems(tableConf models.TableConfig) []string {
+	problems := []string{}
+	if len(tableConf.InterleavedTables) > 0 && tableConf.SortKey == "" {
+		problems = append(problems, "InterleavedTables need a sortKey to tell their items apart")
+	}
+	prefixes := sortedKeys(tableConf.InterleavedTables)
+	for _, prefix := range prefixes {
+		child := tableConf.InterleavedTables[prefix]
+		childConf, ok := config.DbConfigMap[child]
+		if !ok {
+			problems = append(problems, "the interleaved table "+child+" of prefix "+prefix+" has no table config")
+			continue
+		}
+		if childConf.PartitionKey != tableConf.PartitionKey || childConf.SortKey != tableConf.SortKey {
+			problems = append(problems, "the interleaved table "+child+" of prefix "+prefix+" does not have the partitionKey and sortKey of the table")
+		}
+	}
+	return problems
+}
@@ -0,0 +1,234 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/spanner/spannertest"
+	"cloud.google.com/go/spanner/spansql"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var customerConf = models.TableConfig{
+	PartitionKey: "customer_id",
+	SortKey:      "sk",
+	ActualTable:  "customer",
+	InterleavedTables: map[string]string{
+		"ORDER#":      "customer_order",
+		"ORDER#ITEM#": "customer_order_item",
+	},
+}
+
+var fakeSpannerOnce sync.Once
+
+// fakeSpanner serves the storage instance of the tests from an in-memory
+// Spanner holding the customer tables and the tagged table
+func fakeSpanner(t *testing.T) {
+	tables := []string{"customer", "customer_order"}
+	// the tests replace the table maps, so the tables are added on every call
+	for _, table := range tables {
+		models.SpannerTableMap[table] = "test"
+		models.TableDDL[table] = map[string]string{"customer_id": "STRING(MAX)", "sk": "STRING(MAX)", "total": "FLOAT64"}
+		models.TableColumnMap[table] = []string{"customer_id", "sk", "total"}
+	}
+	models.SpannerTableMap["tagged"] = "test"
+	models.TableDDL["tagged"] = map[string]string{"id": "STRING(MAX)", "tags": "BYTES(MAX)"}
+	models.TableColumnMap["tagged"] = []string{"id", "tags"}
+	fakeSpannerOnce.Do(func() {
+		srv, err := spannertest.NewServer("localhost:0")
+		assert.Equal(t, err, nil)
+		srv.SetLogger(func(string, ...interface{}) {})
+		for _, table := range tables {
+			ddl, err := spansql.ParseDDL("test", "CREATE TABLE "+table+" (customer_id STRING(MAX) NOT NULL, sk STRING(MAX) NOT NULL, total FLOAT64) PRIMARY KEY (customer_id, sk)")
+			assert.Equal(t, err, nil)
+			assert.Equal(t, srv.UpdateDDL(ddl), nil)
+		}
+		ddl, err := spansql.ParseDDL("test", "CREATE TABLE tagged (id STRING(MAX) NOT NULL, tags BYTES(MAX)) PRIMARY KEY (id)")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, srv.UpdateDDL(ddl), nil)
+		os.Setenv("SPANNER_EMULATOR_HOST", srv.Addr)
+		config.ConfigurationMap.GoogleProjectID = "test"
+		config.ConfigurationMap.SpannerDb = "test"
+		storage.GetStorageInstance()
+	})
+}
+
+func Test_itemTable(t *testing.T) {
+	tests := []struct {
+		testName string
+		keyMap   map[string]interface{}
+		want     string
+	}{
+		{"parent item", map[string]interface{}{"customer_id": "c1", "sk": "PROFILE"}, "customer"},
+		{"child item", map[string]interface{}{"customer_id": "c1", "sk": "ORDER#2020"}, "customer_order"},
+		{"longest prefix", map[string]interface{}{"customer_id": "c1", "sk": "ORDER#ITEM#1"}, "customer_order_item"},
+		{"no sort key", map[string]interface{}{"customer_id": "c1"}, "customer"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, itemTable(customerConf, tc.keyMap), tc.want)
+	}
+	assert.Equal(t, itemTable(models.TableConfig{PartitionKey: "emp_id", ActualTable: "employee"}, map[string]interface{}{"emp_id": 1}), "employee")
+}
+
+func Test_interleavedGroups(t *testing.T) {
+	profile := map[string]interface{}{"customer_id": "c1", "sk": "PROFILE"}
+	order1 := map[string]interface{}{"customer_id": "c1", "sk": "ORDER#1"}
+	order2 := map[string]interface{}{"customer_id": "c2", "sk": "ORDER#2"}
+	tables, groups := interleavedGroups(customerConf, []map[string]interface{}{order1, profile, order2})
+	assert.Equal(t, tables, []string{"customer", "customer_order"})
+	assert.Equal(t, groups["customer"], []map[string]interface{}{profile})
+	assert.Equal(t, groups["customer_order"], []map[string]interface{}{order1, order2})
+
+	tables, _ = interleavedGroups(models.TableConfig{PartitionKey: "emp_id", ActualTable: "employee"}, []map[string]interface{}{profile})
+	assert.Equal(t, tables == nil, true)
+}
+
+func Test_interleavedBatch(t *testing.T) {
+	defer func() { config.DbConfigMap = map[string]models.TableConfig{} }()
+	config.DbConfigMap = map[string]models.TableConfig{
+		"customer":       customerConf,
+		"customer_order": {PartitionKey: "customer_id", SortKey: "sk"},
+	}
+	fakeSpanner(t)
+	ctx := context.Background()
+	profile := map[string]interface{}{"customer_id": "c1", "sk": "PROFILE", "total": float64(1)}
+	order := map[string]interface{}{"customer_id": "c1", "sk": "ORDER#1", "total": float64(2)}
+	profileKey := map[string]interface{}{"customer_id": "c1", "sk": "PROFILE"}
+	orderKey := map[string]interface{}{"customer_id": "c1", "sk": "ORDER#1"}
+
+	assert.Equal(t, BatchPut(ctx, "customer", []map[string]interface{}{profile, order}), nil)
+	items, err := BatchGet(ctx, "customer", []map[string]interface{}{orderKey, profileKey})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, items, []map[string]interface{}{profile, order})
+	items, err = BatchGetWithProjection(ctx, "customer", []map[string]interface{}{profileKey}, "total", nil, true)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, items, []map[string]interface{}{{"total": float64(1)}})
+
+	errs, err := BatchConditionalDelete(ctx, "customer", []map[string]interface{}{profileKey}, []string{""}, []map[string]interface{}{nil})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, errs, []error{nil})
+	assert.Equal(t, BatchDelete(ctx, "customer", []map[string]interface{}{orderKey}), nil)
+	items, err = BatchGet(ctx, "customer", []map[string]interface{}{orderKey, profileKey})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, items, []map[string]interface{}{})
+}
+
+func Test_interleavedQuery(t *testing.T) {
+	defer delete(models.TableColumnMap, "customer_order")
+	models.TableColumnMap["customer_order"] = []string{"customer_id", "sk", "total"}
+	query := models.Query{
+		TableName:     "customer",
+		RangeExp:      "customer_id = :id",
+		RangeValMap:   map[string]interface{}{":id": "c1"},
+		StartFrom:     map[string]interface{}{"customer_id": "c1", "sk": "ORDER#1"},
+		SortAscending: true,
+	}
+	tests := []struct {
+		testName  string
+		ascending bool
+		want      string
+	}{
+		{
+			"ascending",
+			true,
+			"SELECT customer_order.`customer_id`,customer_order.`sk`,customer_order.`total` FROM customer_order WHERE sk is not null  AND customer_id = @rangeExp1 AND ((customer_id > @startKey1) OR (customer_id = @startKey1 AND sk > @startKey2))  ORDER BY customer_id ASC, sk ASC  LIMIT 3",
+		},
+		{
+			"descending",
+			false,
+			"SELECT customer_order.`customer_id`,customer_order.`sk`,customer_order.`total` FROM customer_order WHERE sk is not null  AND customer_id = @rangeExp1 AND ((customer_id < @startKey1) OR (customer_id = @startKey1 AND sk < @startKey2))  ORDER BY customer_id DESC, sk DESC  LIMIT 3",
+		},
+	}
+	for _, tc := range tests {
+		query.SortAscending = tc.ascending
+		q := interleavedQuery(query, "customer_order", "customer_id", "sk", 2)
+		stmt, _, _, _, _, err := createSpannerQuery(&q, "customer_id", "sk", "customer_id", "sk")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, stmt.SQL, tc.want)
+		assert.Equal(t, query.TableName, "customer")
+	}
+}
+
+func Test_mergeInterleaved(t *testing.T) {
+	profile := map[string]interface{}{"customer_id": "c1", "sk": "PROFILE", "name": "Marc"}
+	order1 := map[string]interface{}{"customer_id": "c1", "sk": "ORDER#1", "total": int64(10)}
+	order2 := map[string]interface{}{"customer_id": "c1", "sk": "ORDER#2", "total": int64(20)}
+	item := map[string]interface{}{"customer_id": "c1", "sk": "ORDER#ITEM#1", "qty": int64(1)}
+	tests := []struct {
+		testName  string
+		ascending bool
+		limit     int64
+		want      map[string]interface{}
+	}{
+		{
+			"whole hierarchy",
+			true,
+			10,
+			map[string]interface{}{"Count": 4, "Items": []map[string]interface{}{order1, order2, item, profile}, "LastEvaluatedKey": nil},
+		},
+		{
+			"first page",
+			true,
+			2,
+			map[string]interface{}{"Count": 2, "Items": []map[string]interface{}{order1, order2}, "LastEvaluatedKey": map[string]interface{}{"customer_id": "c1", "sk": "ORDER#2"}},
+		},
+		{
+			"descending",
+			false,
+			2,
+			map[string]interface{}{"Count": 2, "Items": []map[string]interface{}{profile, item}, "LastEvaluatedKey": map[string]interface{}{"customer_id": "c1", "sk": "ORDER#ITEM#1"}},
+		},
+	}
+	for _, tc := range tests {
+		items := []map[string]interface{}{profile, order1, order2, item}
+		assert.Equal(t, mergeInterleaved(items, "customer_id", "sk", tc.ascending, tc.limit), tc.want)
+	}
+
+	other := map[string]interface{}{"customer_id": "c0", "sk": "PROFILE"}
+	items := []map[string]interface{}{profile, order1, other, order2, item}
+	assert.Equal(t, mergeInterleaved(items, "customer_id", "sk", true, 3), map[string]interface{}{"Count": 3, "Items": []map[string]interface{}{other, order1, order2}, "LastEvaluatedKey": map[string]interface{}{"customer_id": "c1", "sk": "ORDER#2"}})
+
+	binary1 := map[string]interface{}{"customer_id": []byte{1}, "sk": "PROFILE"}
+	binary2 := map[string]interface{}{"customer_id": []byte{2}, "sk": "ORDER#1"}
+	binary3 := map[string]interface{}{"customer_id": []byte{2}, "sk": "PROFILE"}
+	items = []map[string]interface{}{binary3, binary2, binary1}
+	assert.Equal(t, mergeInterleaved(items, "customer_id", "sk", true, 10), map[string]interface{}{"Count": 3, "Items": []map[string]interface{}{binary1, binary2, binary3}, "LastEvaluatedKey": nil})
+	assert.Equal(t, mergeInterleaved(nil, "customer_id", "sk", true, 2), map[string]interface{}{"Count": 0, "Items": []map[string]interface{}{}, "LastEvaluatedKey": nil})
+}
+
+func Test_interleavedProblems(t *testing.T) {
+	defer func() { config.DbConfigMap = map[string]models.TableConfig{} }()
+	config.DbConfigMap = map[string]models.TableConfig{
+		"customer":            customerConf,
+		"customer_order":      {PartitionKey: "customer_id", SortKey: "sk"},
+		"customer_order_item": {PartitionKey: "customer_id", SortKey: "created"},
+	}
+	assert.Equal(t, interleavedProblems(customerConf), []string{
+		"the interleaved table customer_order_item of prefix ORDER#ITEM# does not have the partitionKey and sortKey of the table",
+	})
+
+	delete(config.DbConfigMap, "customer_order")
+	assert.Equal(t, interleavedProblems(models.TableConfig{PartitionKey: "customer_id", InterleavedTables: map[string]string{"ORDER#": "customer_order"}}), []string{
+		"InterleavedTables need a sortKey to tell their items apart",
+		"the interleaved table customer_order of prefix ORDER# has no table config",
+	})
+}
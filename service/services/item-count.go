@@ -0,0 +1,109 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
+	"github.com/robfig/cron"
+)
+
+// defaultItemCountRefresh is used when ItemCountRefreshInterval is not set
+const defaultItemCountRefresh = time.Hour
+
+// itemCount is the cached row count of a table
+type itemCount struct {
+	Count     int64
+	UpdatedAt time.Time
+}
+
+var itemCounts = map[string]itemCount{}
+var itemCountsMux = &sync.RWMutex{}
+
+var itemCountCron *cron.Cron
+
+// StartItemCounter counts the rows of every table and refreshes the counts
+// every ItemCountRefreshInterval
+func StartItemCounter() {
+	itemCountCron = cron.New()
+	itemCountCron.AddFunc("@every "+itemCountRefresh().String(), refreshItemCounts)
+	itemCountCron.Start()
+	go refreshItemCounts()
+}
+
+func itemCountRefresh() time.Duration {
+	interval, err := time.ParseDuration(config.ConfigurationMap.ItemCountRefreshInterval)
+	if err != nil || interval <= 0 {
+		return defaultItemCountRefresh
+	}
+	return interval
+}
+
+func refreshItemCounts() {
+	for tableName, tableConf := range config.DbConfigMap {
+		if tableConf.ActualTable != "" && tableConf.ActualTable != tableName {
+			continue
+		}
+		if _, err := countItems(ctx, tableName); err != nil {
+			logger.LogError(err, tableName)
+		}
+	}
+}
+
+// countItems runs a COUNT(*) on the table and caches the result
+func countItems(ctx context.Context, tableName string) (itemCount, error) {
+	stmt := spanner.Statement{SQL: "SELECT COUNT(*) AS count FROM " + changeTableNameForSP(tableName)}
+	if config.IsSoftDelete(tableName) {
+		stmt.SQL += " WHERE " + models.SoftDeleteColumn + " IS NULL OR " + models.SoftDeleteColumn + " = false"
+	}
+	rows, err := storage.GetStorageInstance().ExecuteSpannerQuery(ctx, tableName, []string{"count"}, true, stmt)
+	if err != nil {
+		return itemCount{}, err
+	}
+	count := itemCount{UpdatedAt: time.Now()}
+	if len(rows) > 0 {
+		count.Count, _ = rows[0]["Count"].(int64)
+	}
+	itemCountsMux.Lock()
+	itemCounts[tableName] = count
+	itemCountsMux.Unlock()
+	return count, nil
+}
+
+// ApproximateItemCount returns the cached row count of the table, counting it
+// first if it has not been counted yet
+func ApproximateItemCount(ctx context.Context, tableName string) (int64, time.Time, error) {
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	itemCountsMux.RLock()
+	count, ok := itemCounts[tableConf.ActualTable]
+	itemCountsMux.RUnlock()
+	if !ok {
+		count, err = countItems(ctx, tableConf.ActualTable)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+	}
+	return count.Count, count.UpdatedAt, nil
+}
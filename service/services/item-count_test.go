@@ -0,0 +1,53 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_itemCountRefresh(t *testing.T) {
+	defer func() { config.ConfigurationMap.ItemCountRefreshInterval = "" }()
+	config.ConfigurationMap.ItemCountRefreshInterval = ""
+	assert.Equal(t, itemCountRefresh(), defaultItemCountRefresh)
+	config.ConfigurationMap.ItemCountRefreshInterval = "15m"
+	assert.Equal(t, itemCountRefresh(), 15*time.Minute)
+}
+
+func TestApproximateItemCountCached(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{
+		"testTable":  {PartitionKey: "first"},
+		"testAlias":  {ActualTable: "testTable"},
+		"otherTable": {PartitionKey: "first"},
+	}
+	updatedAt := time.Now()
+	itemCountsMux.Lock()
+	itemCounts["testTable"] = itemCount{Count: 42, UpdatedAt: updatedAt}
+	itemCountsMux.Unlock()
+
+	count, gotUpdatedAt, err := ApproximateItemCount(context.Background(), "testAlias")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, count, int64(42))
+	assert.Equal(t, gotUpdatedAt, updatedAt)
+
+	_, _, err = ApproximateItemCount(context.Background(), "missingTable")
+	assert.NotEqual(t, err, nil)
+}
@@ -0,0 +1,34 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import "sync/atomic"
+
+// readOnly is 1 while writes are rejected, e.g. during Spanner maintenance
+var readOnly int32
+
+// SetReadOnly turns the read-only mode on or off
+func SetReadOnly(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&readOnly, v)
+}
+
+// IsReadOnly checks if writes are currently rejected
+func IsReadOnly() bool {
+	return atomic.LoadInt32(&readOnly) == 1
+}
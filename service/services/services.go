@@ -18,9 +18,18 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"hash/fnv"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"cloud.google.com/go/spanner"
 	"github.com/ahmetb/go-linq"
@@ -32,6 +41,15 @@ import (
 	"github.com/cloudspannerecosystem/dynamodb-adapter/utils"
 )
 
+// defaultTombstoneRetention is used when a SoftDelete table has no TombstoneRetention
+const defaultTombstoneRetention = 7 * 24 * time.Hour
+
+// maxTotalSegments matches the TotalSegments limit of DynamoDB
+const maxTotalSegments = 1000000
+
+// defaultQueryLimit bounds the page of a query sent without a Limit
+const defaultQueryLimit int64 = 5000
+
 // getSpannerProjections makes a projection array of columns
 func getSpannerProjections(projectionExpression, table string, expressionAttributeNames map[string]string) []string {
 	if projectionExpression == "" {
@@ -41,33 +59,317 @@ func getSpannerProjections(projectionExpression, table string, expressionAttribu
 	projections := strings.Split(projectionExpression, ",")
 	projectionCols := []string{}
 	for _, pro := range projections {
-		pro = strings.TrimSpace(pro)
-		if val, ok := expressionAttributes[pro]; ok {
-			projectionCols = append(projectionCols, val)
-		} else {
-			projectionCols = append(projectionCols, pro)
-		}
+		path := projectionPath(pro, expressionAttributes)
+		projectionCols = append(projectionCols, path[0])
 	}
 
+	cols := []string{}
 	linq.From(projectionCols).IntersectByT(linq.From(models.TableColumnMap[changeTableNameForSP(table)]), func(str string) string {
 		return str
-	}).ToSlice(&projectionCols)
-	return projectionCols
+	}).ToSlice(&cols)
+	if overflowColumn(table) != "" {
+		// attributes without a column are read from the overflow column
+		for _, col := range projectionCols {
+			if _, ok := models.TableDDL[changeTableNameForSP(table)][col]; !ok && !stringInSlice(col, cols) {
+				cols = append(cols, col)
+			}
+		}
+	}
+	return cols
+}
+
+// ValidateProjection checks the attributes of a ProjectionExpression on a
+// table with StrictProjection, which rejects an attribute without a column
+// instead of leaving it out of the items like DynamoDB does. Every attribute
+// is known to a table with an OverflowColumn.
+func ValidateProjection(table, projectionExpression string, expressionAttributeNames map[string]string) error {
+	tableConf, err := config.GetTableConf(table)
+	if err != nil || !tableConf.StrictProjection || tableConf.OverflowColumn != "" || projectionExpression == "" {
+		return nil
+	}
+	ddl := models.TableDDL[changeTableNameForSP(table)]
+	for _, pro := range strings.Split(projectionExpression, ",") {
+		attr := projectionPath(pro, expressionAttributeNames)[0]
+		if _, ok := ddl[attr]; !ok {
+			if original, ok := models.OriginalColResponse[attr]; ok {
+				attr = original
+			}
+			return errors.New("ValidationException", "ProjectionExpression names an attribute which the table does not have:", attr).WithParameter("ProjectionExpression")
+		}
+	}
+	return nil
+}
+
+// DefaultProjection returns projectionExpression, or when it is empty, the
+// columns of the table without the ExcludeByDefault ones of its config.
+// The excluded columns are only read when the client projects them.
+func DefaultProjection(tableName, projectionExpression string) string {
+	if projectionExpression != "" {
+		return projectionExpression
+	}
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil || len(tableConf.ExcludeByDefault) == 0 {
+		return projectionExpression
+	}
+	excluded := map[string]bool{}
+	for _, attr := range tableConf.ExcludeByDefault {
+		if col, ok := models.ColumnToOriginalCol[attr]; ok {
+			attr = col
+		}
+		excluded[attr] = true
+	}
+	cols := []string{}
+	for _, col := range models.TableColumnMap[changeTableNameForSP(tableConf.ActualTable)] {
+		if excluded[col] || col == "commit_timestamp" || col == models.SoftDeleteColumn || col == models.SoftDeleteTimeColumn {
+			continue
+		}
+		cols = append(cols, col)
+	}
+	return strings.Join(cols, ",")
+}
+
+// SelectProjection returns the projection of a Query or Scan for its Select.
+// ALL_PROJECTED_ATTRIBUTES reads only the attributes a KEYS_ONLY or INCLUDE
+// index projects, which Spanner reads from the index alone. It is the default
+// of an index read without a Select or a ProjectionExpression, as in DynamoDB.
+// ALL_ATTRIBUTES reads every column, the ones the index does not store are
+// joined back from the table by Spanner. SPECIFIC_ATTRIBUTES reads the
+// ProjectionExpression, which needs no join when it names only index keys.
+func SelectProjection(tableName, indexName, selectValue, projectionExpression string) (string, error) {
+	selectValue = strings.ToUpper(selectValue)
+	switch selectValue {
+	case "ALL_ATTRIBUTES":
+		if projectionExpression != "" {
+			return "", errors.New("ValidationException", "ProjectionExpression can not be used with Select", selectValue)
+		}
+		return "", nil
+	case "SPECIFIC_ATTRIBUTES":
+		if projectionExpression == "" {
+			return "", errors.New("ValidationException", "Select SPECIFIC_ATTRIBUTES needs a ProjectionExpression").WithParameter("ProjectionExpression")
+		}
+	case "ALL_PROJECTED_ATTRIBUTES":
+		if projectionExpression != "" {
+			return "", errors.New("ValidationException", "ProjectionExpression can not be used with Select", selectValue)
+		}
+		if indexName == "" {
+			return "", errors.New("ValidationException", "Select ALL_PROJECTED_ATTRIBUTES needs an IndexName")
+		}
+		tableConf, err := config.GetTableConf(tableName)
+		if err != nil {
+			return "", err
+		}
+		index, ok := tableConf.Indices[indexName]
+		if !ok {
+			return "", errors.New("ValidationException", "the table has no index", indexName)
+		}
+		if cols := indexProjection(tableConf, index); cols != nil {
+			return strings.Join(cols, ","), nil
+		}
+	case "":
+		if projectionExpression != "" || indexName == "" {
+			break
+		}
+		tableConf, err := config.GetTableConf(tableName)
+		if err != nil {
+			break
+		}
+		if cols := indexProjection(tableConf, tableConf.Indices[indexName]); cols != nil {
+			return strings.Join(cols, ","), nil
+		}
+	}
+	return DefaultProjection(tableName, projectionExpression), nil
+}
+
+// indexProjection returns the attributes projected into a KEYS_ONLY or
+// INCLUDE index, which is nil for an index projecting ALL of them
+func indexProjection(tableConf, index models.TableConfig) []string {
+	switch strings.ToUpper(index.ProjectionType) {
+	case "KEYS_ONLY", "INCLUDE":
+	default:
+		return nil
+	}
+	cols := []string{}
+	for _, k := range []string{tableConf.PartitionKey, tableConf.SortKey, index.PartitionKey, index.SortKey} {
+		if k != "" && !stringInSlice(k, cols) {
+			cols = append(cols, k)
+		}
+	}
+	if strings.ToUpper(index.ProjectionType) == "INCLUDE" {
+		for _, k := range index.NonKeyAttributes {
+			if !stringInSlice(k, cols) {
+				cols = append(cols, k)
+			}
+		}
+	}
+	return cols
+}
+
+// overflowColumn returns the OverflowColumn of the table, which is empty when
+// the table has none
+func overflowColumn(table string) string {
+	tableConf, err := config.GetTableConf(table)
+	if err != nil {
+		return ""
+	}
+	return tableConf.OverflowColumn
+}
+
+// projectionPath splits a document path like profile.email or tags[1] with
+// utils.AttributePath, replacing the expression attribute names
+func projectionPath(projection string, expressionAttributeNames map[string]string) []string {
+	path := utils.AttributePath(projection)
+	for i, name := range path {
+		if val, ok := expressionAttributeNames[name]; ok {
+			path[i] = val
+		}
+	}
+	return path
 }
 
-// Put writes an object to Spanner
+// projectNestedPaths keeps only the projected sub-paths of the map and list
+// attributes which are projected with a document path like profile.email or
+// tags[1]. Like DynamoDB, a projected list holds only its projected elements,
+// in index order, and an index past the end of the list is left out.
+func projectNestedPaths(item map[string]interface{}, projectionExpression string, expressionAttributeNames map[string]string) map[string]interface{} {
+	if item == nil || !strings.ContainsAny(projectionExpression, ".[") {
+		return item
+	}
+	var paths [][]string
+	whole := map[string]bool{}
+	for _, pro := range strings.Split(projectionExpression, ",") {
+		path := projectionPath(pro, expressionAttributeNames)
+		if len(path) == 1 {
+			whole[path[0]] = true
+			continue
+		}
+		paths = append(paths, path)
+	}
+	res := map[string]interface{}{}
+	for k, v := range item {
+		res[k] = v
+	}
+	for _, path := range paths {
+		if !whole[path[0]] {
+			delete(res, path[0])
+		}
+	}
+	// longer paths first, so a shorter overlapping path replaces the copied
+	// sub-map instead of writing into the stored one
+	sort.SliceStable(paths, func(i, j int) bool {
+		return len(paths[i]) > len(paths[j])
+	})
+	for _, path := range paths {
+		if !whole[path[0]] {
+			copyValue(res, item, path)
+		}
+	}
+	for _, path := range paths {
+		if v, ok := res[path[0]]; ok {
+			res[path[0]] = compactLists(v)
+		}
+	}
+	return res
+}
+
+// projectItemPaths applies projectNestedPaths to every item read with the
+// projection
+func projectItemPaths(items []map[string]interface{}, projectionExpression string, expressionAttributeNames map[string]string) []map[string]interface{} {
+	if !strings.ContainsAny(projectionExpression, ".[") {
+		return items
+	}
+	for i, item := range items {
+		items[i] = projectNestedPaths(item, projectionExpression, expressionAttributeNames)
+	}
+	return items
+}
+
+// listElements holds the projected elements of a list by their index until
+// compactLists turns them into a list
+type listElements map[int]interface{}
+
+// copyValue returns dst with the value at the path of src copied into it, or
+// false when src has no value at the path. A map dst is updated in place.
+func copyValue(dst, src interface{}, path []string) (interface{}, bool) {
+	if len(path) == 0 {
+		return src, true
+	}
+	if i, ok := utils.ListIndex(path[0]); ok {
+		list, ok := src.([]interface{})
+		if !ok || i >= len(list) {
+			return dst, false
+		}
+		dstList, _ := dst.(listElements)
+		if dstList == nil {
+			dstList = listElements{}
+		}
+		v, ok := copyValue(dstList[i], list[i], path[1:])
+		if !ok {
+			return dst, false
+		}
+		dstList[i] = v
+		return dstList, true
+	}
+	srcMap, ok := src.(map[string]interface{})
+	if !ok {
+		return dst, false
+	}
+	e, ok := srcMap[path[0]]
+	if !ok {
+		return dst, false
+	}
+	dstMap, _ := dst.(map[string]interface{})
+	if dstMap == nil {
+		dstMap = map[string]interface{}{}
+	}
+	v, ok := copyValue(dstMap[path[0]], e, path[1:])
+	if !ok {
+		return dst, false
+	}
+	dstMap[path[0]] = v
+	return dstMap, true
+}
+
+// compactLists turns the listElements of a projected value into lists
+func compactLists(v interface{}) interface{} {
+	switch x := v.(type) {
+	case listElements:
+		indices := make([]int, 0, len(x))
+		for i := range x {
+			indices = append(indices, i)
+		}
+		sort.Ints(indices)
+		list := make([]interface{}, 0, len(indices))
+		for _, i := range indices {
+			list = append(list, compactLists(x[i]))
+		}
+		return list
+	case map[string]interface{}:
+		for k, e := range x {
+			if c, ok := e.(listElements); ok {
+				x[k] = compactLists(c)
+			} else if m, ok := e.(map[string]interface{}); ok {
+				compactLists(m)
+			}
+		}
+	}
+	return v
+}
+
+// Put writes an object to Spanner and returns the whole item after the write,
+// merged with the item read in the same transaction. It returns nil when oldRes
+// is nil, i.e. when no return values are needed.
 func Put(ctx context.Context, tableName string, putObj map[string]interface{}, expr *models.UpdateExpressionCondition, conditionExp string, expressionAttr, oldRes map[string]interface{}) (map[string]interface{}, error) {
 	tableConf, err := config.GetTableConf(tableName)
 	if err != nil {
 		return nil, err
 	}
 
-	tableName = tableConf.ActualTable
+	tableName = itemTable(tableConf, putObj)
 	e, err := utils.CreateConditionExpression(conditionExp, expressionAttr)
 	if err != nil {
 		return nil, err
 	}
-	newResp, err := storage.GetStorageInstance().SpannerPut(ctx, tableName, putObj, e, expr)
+	newResp, old, err := storage.GetStorageInstance().SpannerPut(ctx, tableName, putObj, e, expr)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +378,7 @@ func Put(ctx context.Context, tableName string, putObj map[string]interface{}, e
 		return oldRes, nil
 	}
 	updateResp := map[string]interface{}{}
-	for k, v := range oldRes {
+	for k, v := range old {
 		updateResp[k] = v
 	}
 	for k, v := range newResp {
@@ -86,35 +388,75 @@ func Put(ctx context.Context, tableName string, putObj map[string]interface{}, e
 	return updateResp, nil
 }
 
-// Add checks the expression for converting the data
-func Add(ctx context.Context, tableName string, attrMap map[string]interface{}, condExpression string, m, expressionAttr map[string]interface{}, expr *models.UpdateExpressionCondition, oldRes map[string]interface{}) (map[string]interface{}, error) {
+// PutItem writes putObj and returns the item it replaced, which is empty when
+// the key was new, together with the new item
+func PutItem(ctx context.Context, tableName string, putObj map[string]interface{}, conditionExp string, expressionAttr map[string]interface{}) (map[string]interface{}, map[string]interface{}, error) {
 	tableConf, err := config.GetTableConf(tableName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	tableName = tableConf.ActualTable
 
-	e, err := utils.CreateConditionExpression(condExpression, expressionAttr)
+	applyDefaults(tableConf, putObj)
+	tableName = itemTable(tableConf, putObj)
+	e, err := utils.CreateConditionExpression(conditionExp, expressionAttr)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	newResp, err := storage.GetStorageInstance().SpannerAdd(ctx, tableName, m, e, expr)
+	newResp, oldResp, err := storage.GetStorageInstance().SpannerPut(ctx, tableName, putObj, e, nil)
 	if err != nil {
-		return nil, err
-	}
-	if oldRes == nil {
-		return newResp, nil
+		return nil, nil, err
 	}
 	updateResp := map[string]interface{}{}
-	for k, v := range oldRes {
+	for k, v := range oldResp {
 		updateResp[k] = v
 	}
 	for k, v := range newResp {
 		updateResp[k] = v
 	}
+	return oldResp, updateResp, nil
+}
 
-	return updateResp, nil
+// applyDefaults sets the Defaults of the table config on the attributes the
+// item does not have. An attribute explicitly set to NULL keeps its NULL.
+func applyDefaults(tableConf models.TableConfig, item map[string]interface{}) {
+	for k, v := range tableConf.Defaults {
+		if _, ok := item[k]; !ok {
+			item[k] = v
+		}
+	}
+}
+
+// Add checks the expression for converting the data and returns the whole item
+// after the update
+func Add(ctx context.Context, tableName string, attrMap map[string]interface{}, condExpression string, m, expressionAttr map[string]interface{}, expr *models.UpdateExpressionCondition) (map[string]interface{}, error) {
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil {
+		return nil, err
+	}
+	tableName = itemTable(tableConf, attrMap)
+
+	e, err := utils.CreateConditionExpression(condExpression, expressionAttr)
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.GetStorageInstance().SpannerAdd(ctx, tableName, m, e, expr)
+}
+
+// Update applies all the actions of an update expression in a single
+// transaction and returns the whole item after the update
+func Update(ctx context.Context, tableName string, attrMap map[string]interface{}, condExpression string, expressionAttr map[string]interface{}, actions []models.UpdateAction) (map[string]interface{}, error) {
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil {
+		return nil, err
+	}
+	tableName = itemTable(tableConf, attrMap)
+
+	e, err := utils.CreateConditionExpression(condExpression, expressionAttr)
+	if err != nil {
+		return nil, err
+	}
+	return storage.GetStorageInstance().SpannerUpdate(ctx, tableName, attrMap, e, actions)
 }
 
 // Del checks the expression for saving the data
@@ -125,7 +467,7 @@ func Del(ctx context.Context, tableName string, attrMap map[string]interface{},
 		return nil, err
 	}
 
-	tableName = tableConf.ActualTable
+	tableName = itemTable(tableConf, attrMap)
 
 	e, err := utils.CreateConditionExpression(condExpression, expressionAttr)
 	if err != nil {
@@ -155,8 +497,31 @@ func BatchGet(ctx context.Context, tableName string, keyMapArray []map[string]in
 	if err != nil {
 		return nil, err
 	}
-	tableName = tableConf.ActualTable
+	if tables, groups := interleavedGroups(tableConf, keyMapArray); tables != nil {
+		resp := []map[string]interface{}{}
+		for _, table := range tables {
+			res, err := batchGetTable(ctx, tableConf, table, groups[table])
+			if err != nil {
+				return nil, err
+			}
+			resp = append(resp, res...)
+		}
+		return resp, nil
+	}
+	return batchGet(ctx, tableConf, keyMapArray)
+}
 
+// batchGetTable reads the keys from table, which is the ActualTable of
+// tableConf or one of its interleaved child tables
+func batchGetTable(ctx context.Context, tableConf models.TableConfig, table string, keyMapArray []map[string]interface{}) ([]map[string]interface{}, error) {
+	if table == tableConf.ActualTable {
+		return batchGet(ctx, tableConf, keyMapArray)
+	}
+	return BatchGet(ctx, table, keyMapArray)
+}
+
+// batchGet reads the keys from the ActualTable of tableConf itself
+func batchGet(ctx context.Context, tableConf models.TableConfig, keyMapArray []map[string]interface{}) ([]map[string]interface{}, error) {
 	var pValues []interface{}
 	var sValues []interface{}
 	for i := 0; i < len(keyMapArray); i++ {
@@ -167,7 +532,7 @@ func BatchGet(ctx context.Context, tableName string, keyMapArray []map[string]in
 		}
 		pValues = append(pValues, pValue)
 	}
-	return storage.GetStorageInstance().SpannerBatchGet(ctx, tableName, pValues, sValues, nil)
+	return storage.GetStorageInstance().SpannerBatchGet(ctx, tableConf.ActualTable, pValues, sValues, nil, true)
 }
 
 // BatchPut writes bulk records to Spanner
@@ -176,53 +541,155 @@ func BatchPut(ctx context.Context, tableName string, arrAttrMap []map[string]int
 		return errors.New("ValidationException")
 	}
 
-	oldRes, err := BatchGet(ctx, tableName, arrAttrMap)
+	tableConf, err := config.GetTableConf(tableName)
 	if err != nil {
 		return err
 	}
-	tableConf, err := config.GetTableConf(tableName)
+	for _, item := range arrAttrMap {
+		applyDefaults(tableConf, item)
+	}
+	if tables, groups := interleavedGroups(tableConf, arrAttrMap); tables != nil {
+		for _, table := range tables {
+			if table == tableConf.ActualTable {
+				err = batchPut(ctx, tableConf, groups[table])
+			} else {
+				err = BatchPut(ctx, table, groups[table])
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return batchPut(ctx, tableConf, arrAttrMap)
+}
+
+// batchPut writes the items to the ActualTable of tableConf itself
+func batchPut(ctx context.Context, tableConf models.TableConfig, arrAttrMap []map[string]interface{}) error {
+	oldRes, err := batchGet(ctx, tableConf, arrAttrMap)
 	if err != nil {
 		return err
 	}
-	tableName = tableConf.ActualTable
+	tableName := tableConf.ActualTable
 	err = storage.GetStorageInstance().SpannerBatchPut(ctx, tableName, arrAttrMap)
 	if err != nil {
 		return err
 	}
-	go func() {
-		if len(oldRes) == len(arrAttrMap) {
-			for i := 0; i < len(arrAttrMap); i++ {
-				go StreamDataToThirdParty(oldRes[i], arrAttrMap[i], tableName)
-			}
-		} else {
-			for i := 0; i < len(arrAttrMap); i++ {
-				go StreamDataToThirdParty(nil, arrAttrMap[i], tableName)
-			}
-
+	if len(oldRes) == len(arrAttrMap) {
+		for i := 0; i < len(arrAttrMap); i++ {
+			StreamDataToThirdParty(oldRes[i], arrAttrMap[i], tableName)
 		}
-	}()
+	} else {
+		for i := 0; i < len(arrAttrMap); i++ {
+			StreamDataToThirdParty(nil, arrAttrMap[i], tableName)
+		}
+	}
 	return nil
 }
 
 // GetWithProjection get table data with projection
 func GetWithProjection(ctx context.Context, tableName string, primaryKeyMap map[string]interface{}, projectionExpression string, expressionAttributeNames map[string]string) (map[string]interface{}, error) {
 	if primaryKeyMap == nil {
-		return nil, errors.New("ValidationException")
+		return nil, errors.New("ValidationException", "Key is required").WithParameter("Key")
 	}
 	tableConf, err := config.GetTableConf(tableName)
 	if err != nil {
 		return nil, err
 	}
 
-	tableName = tableConf.ActualTable
+	tableName = itemTable(tableConf, primaryKeyMap)
+
+	projectionCols := getSpannerProjections(projectionExpression, tableName, expressionAttributeNames)
+	pValue := primaryKeyMap[tableConf.PartitionKey]
+	var sValue interface{}
+	if tableConf.SortKey != "" {
+		sValue = primaryKeyMap[tableConf.SortKey]
+	}
+	res, err := storage.GetStorageInstance().SpannerGet(ctx, tableName, pValue, sValue, projectionCols)
+	if err != nil {
+		return nil, err
+	}
+	return projectNestedPaths(res, projectionExpression, expressionAttributeNames), nil
+}
+
+// GetWithProjectionIfVersionNotEqual reads the item like GetWithProjection but
+// reports notModified instead when its VersionAttribute equals version
+func GetWithProjectionIfVersionNotEqual(ctx context.Context, tableName string, primaryKeyMap map[string]interface{}, projectionExpression string, expressionAttributeNames map[string]string, version interface{}) (res map[string]interface{}, notModified bool, err error) {
+	if primaryKeyMap == nil {
+		return nil, false, errors.New("ValidationException", "Key is required").WithParameter("Key")
+	}
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil {
+		return nil, false, err
+	}
+	if tableConf.VersionAttribute == "" {
+		return nil, false, errors.New("ValidationException", "VersionAttribute is not configured for table", tableName)
+	}
+	tableName = itemTable(tableConf, primaryKeyMap)
 
+	versionCol := tableConf.VersionAttribute
+	if col, ok := models.ColumnToOriginalCol[versionCol]; ok {
+		versionCol = col
+	}
 	projectionCols := getSpannerProjections(projectionExpression, tableName, expressionAttributeNames)
+	projected := len(projectionCols) == 0
+	for _, col := range projectionCols {
+		if col == versionCol {
+			projected = true
+			break
+		}
+	}
+	if !projected {
+		projectionCols = append(projectionCols, versionCol)
+	}
+
 	pValue := primaryKeyMap[tableConf.PartitionKey]
 	var sValue interface{}
 	if tableConf.SortKey != "" {
 		sValue = primaryKeyMap[tableConf.SortKey]
 	}
-	return storage.GetStorageInstance().SpannerGet(ctx, tableName, pValue, sValue, projectionCols)
+	res, err = storage.GetStorageInstance().SpannerGet(ctx, tableName, pValue, sValue, projectionCols)
+	if err != nil {
+		return nil, false, err
+	}
+	if sameVersion(res[versionCol], version) {
+		return map[string]interface{}{}, true, nil
+	}
+	if !projected {
+		delete(res, versionCol)
+	}
+	return projectNestedPaths(res, projectionExpression, expressionAttributeNames), false, nil
+}
+
+// sameVersion compares a stored version with the one sent by the client,
+// numbers decode as int64 from Spanner but as float64 from the request
+func sameVersion(stored, version interface{}) bool {
+	if stored == nil || version == nil {
+		return false
+	}
+	storedNumber, ok := numberValue(stored)
+	versionNumber, ok2 := numberValue(version)
+	if ok && ok2 {
+		return storedNumber.Cmp(versionNumber) == 0
+	}
+	return fmt.Sprint(stored) == fmt.Sprint(version)
+}
+
+// numberValue returns the value of a number attribute, which is an int64
+// read from Spanner or a float64 decoded from a request
+func numberValue(v interface{}) (*big.Float, bool) {
+	switch n := v.(type) {
+	case int64:
+		return new(big.Float).SetInt64(n), true
+	case int:
+		return new(big.Float).SetInt64(int64(n)), true
+	case float64:
+		if math.IsNaN(n) {
+			return nil, false
+		}
+		return new(big.Float).SetFloat64(n), true
+	}
+	return nil, false
 }
 
 // QueryAttributes from Spanner
@@ -231,33 +698,29 @@ func QueryAttributes(ctx context.Context, query models.Query) (map[string]interf
 	if err != nil {
 		return nil, "", err
 	}
-	var sKey string
-	var pKey string
-	tPKey := tableConf.PartitionKey
-	tSKey := tableConf.SortKey
-	if query.IndexName != "" {
-		conf := tableConf.Indices[query.IndexName]
-		query.IndexName = strings.Replace(query.IndexName, "-", "_", -1)
-
-		if tableConf.ActualTable != query.TableName {
-			query.TableName = tableConf.ActualTable
-		}
-
-		sKey = conf.SortKey
-		pKey = conf.PartitionKey
-	} else {
-		sKey = tableConf.SortKey
-		pKey = tableConf.PartitionKey
+	if err := validateFilterableAttributes(tableConf, query.FilterExp, query.AllowFullScan); err != nil {
+		return nil, "", err
 	}
-	if pKey == "" {
-		pKey = tPKey
-		sKey = tSKey
+	if query.IndexName == "" && len(tableConf.InterleavedTables) > 0 {
+		return queryInterleaved(ctx, query, tableConf)
+	}
+	tPKey, tSKey, pKey, sKey := queryKeys(&query, tableConf)
+	if err := validateKeyCondition(query.RangeExp, pKey, sKey); err != nil {
+		return nil, "", err
+	}
+	if len(query.ScanKeys) == 0 {
+		if err := validateQueryFilter(query.FilterExp, pKey, sKey); err != nil {
+			return nil, "", err
+		}
 	}
 
 	originalLimit := query.Limit
+	if originalLimit <= 0 {
+		originalLimit = defaultQueryLimit
+	}
 	query.Limit = originalLimit + 1
 
-	stmt, cols, isCountQuery, offset, hash, err := createSpannerQuery(&query, tPKey, pKey, sKey)
+	stmt, cols, isCountQuery, offset, hash, err := createSpannerQuery(&query, tPKey, tSKey, pKey, sKey)
 	if err != nil {
 		return nil, hash, err
 	}
@@ -269,48 +732,280 @@ func QueryAttributes(ctx context.Context, query models.Query) (map[string]interf
 	if isCountQuery {
 		return resp[0], hash, nil
 	}
+	resp = projectItemPaths(resp, query.ProjectionExpression, query.ExpressionAttributeNames)
+	return queryPage(&query, resp, originalLimit, offset, tPKey, tSKey, pKey, sKey), hash, nil
+}
+
+// queryPage returns the page of a query out of the rows read with one row past
+// its limit. The extra row tells whether more items follow, so a page holding
+// exactly the remaining items has no LastEvaluatedKey and the client does not
+// read an extra empty page.
+func queryPage(query *models.Query, resp []map[string]interface{}, limit, offset int64, tPKey, tSKey, pKey, sKey string) map[string]interface{} {
 	finalResp := make(map[string]interface{})
 	length := len(resp)
 	if length == 0 {
 		finalResp["Count"] = 0
 		finalResp["Items"] = []map[string]interface{}{}
 		finalResp["LastEvaluatedKey"] = nil
-		return finalResp, hash, nil
+		return finalResp
 	}
-	if int64(length) > originalLimit {
-		finalResp["Count"] = length - 1
-		last := resp[length-2]
-		if sKey != "" {
-			finalResp["LastEvaluatedKey"] = map[string]interface{}{"offset": originalLimit + offset, pKey: last[pKey], tPKey: last[tPKey], sKey: last[sKey], tSKey: last[tSKey]}
-		} else {
-			finalResp["LastEvaluatedKey"] = map[string]interface{}{"offset": originalLimit + offset, pKey: last[pKey], tPKey: last[tPKey]}
-		}
-		finalResp["Items"] = resp[:length-1]
+	if int64(length) > limit {
+		finalResp["Count"] = int(limit)
+		finalResp["LastEvaluatedKey"] = lastEvaluatedKey(query, resp[limit-1], limit+offset, tPKey, tSKey, pKey, sKey)
+		finalResp["Items"] = resp[:limit]
 	} else {
-		if query.StartFrom != nil && length-1 == 1 {
-			finalResp["Items"] = resp
-		} else {
-			finalResp["Items"] = resp
-		}
 		finalResp["Count"] = length
 		finalResp["Items"] = resp
 		finalResp["LastEvaluatedKey"] = nil
 	}
-	return finalResp, hash, nil
+	return finalResp
+}
+
+// BatchQuery runs the queries concurrently, reading from one read-only
+// transaction so that every page comes from the same snapshot, and returns
+// their results in the order of the queries
+func BatchQuery(ctx context.Context, queries []models.Query) ([]map[string]interface{}, error) {
+	ctx, done := storage.WithSnapshot(ctx)
+	defer done()
+	results := make([]map[string]interface{}, len(queries))
+	errs := make([]error, len(queries))
+	var wg sync.WaitGroup
+	for i := range queries {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _, errs[i] = QueryAttributes(ctx, queries[i])
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
 }
 
-func createSpannerQuery(query *models.Query, tPkey, pKey, sKey string) (spanner.Statement, []string, bool, int64, string, error) {
+// lastEvaluatedKey returns the continuation of a page ending with last. It has
+// every key column of the table and of the queried index, so that items of a
+// composite key table sharing the partition key are told apart, along with
+// the offset of the next page for Query or only the scan keys for Scan.
+func lastEvaluatedKey(query *models.Query, last map[string]interface{}, nextOffset int64, tPKey, tSKey, pKey, sKey string) map[string]interface{} {
+	key := map[string]interface{}{}
+	if len(query.ScanKeys) > 0 {
+		for _, k := range query.ScanKeys {
+			key[k] = last[k]
+		}
+		return key
+	}
+	for _, k := range []string{pKey, sKey, tPKey, tSKey} {
+		if k != "" {
+			key[k] = last[k]
+		}
+	}
+	key["offset"] = nextOffset
+	return key
+}
+
+// QueryAttributesStream runs the query on Spanner and passes every matching
+// row to fn as it comes off the iterator, without buffering the result set
+func QueryAttributesStream(ctx context.Context, query models.Query, fn func(map[string]interface{}) error) error {
+	tableConf, err := config.GetTableConf(query.TableName)
+	if err != nil {
+		return err
+	}
+	if err := validateFilterableAttributes(tableConf, query.FilterExp, query.AllowFullScan); err != nil {
+		return err
+	}
+	if query.IndexName == "" && len(tableConf.InterleavedTables) > 0 {
+		return errors.New("ValidationException", "streaming queries are not supported for tables with InterleavedTables", query.TableName)
+	}
+	tPKey, tSKey, pKey, sKey := queryKeys(&query, tableConf)
+	if err := validateKeyCondition(query.RangeExp, pKey, sKey); err != nil {
+		return err
+	}
+	if err := validateQueryFilter(query.FilterExp, pKey, sKey); err != nil {
+		return err
+	}
+	if query.OnlyCount {
+		return errors.New("ValidationException", "Select COUNT is not supported for streaming queries")
+	}
+	stmt, cols, _, _, _, err := createSpannerQuery(&query, tPKey, tSKey, pKey, sKey)
+	if err != nil {
+		return err
+	}
+	logger.LogDebug(stmt)
+	if strings.ContainsAny(query.ProjectionExpression, ".[") {
+		stream := fn
+		fn = func(row map[string]interface{}) error {
+			return stream(projectNestedPaths(row, query.ProjectionExpression, query.ExpressionAttributeNames))
+		}
+	}
+	return storage.GetStorageInstance().ExecuteSpannerQueryStream(ctx, query.TableName, cols, stmt, fn)
+}
+
+// keyConditionKeywords are the non attribute words of a KeyConditionExpression
+var keyConditionKeywords = map[string]bool{"AND": true, "BETWEEN": true, "begins_with": true}
+
+var keyConditionAttrRegexp = regexp.MustCompile(`(^|[^:\w])([A-Za-z_]\w*)`)
+
+// validateKeyCondition checks that a KeyConditionExpression only uses the
+// partition key and, when the table or index has one, the sort key
+func validateKeyCondition(rangeExp, pKey, sKey string) error {
+	if rangeExp == "" {
+		return nil
+	}
+	hasPKey := false
+	for _, m := range keyConditionAttrRegexp.FindAllStringSubmatch(rangeExp, -1) {
+		attr := m[2]
+		switch {
+		case keyConditionKeywords[attr]:
+		case attr == pKey:
+			hasPKey = true
+		case sKey != "" && attr == sKey:
+		default:
+			return errors.New("ValidationException", "Query key condition not supported", attr)
+		}
+	}
+	if !hasPKey {
+		return errors.New("ValidationException", "Query condition missed key schema element: "+pKey)
+	}
+	return nil
+}
+
+// filterAttrRegexp matches the top level attributes of an expression, leaving
+// out the nested segments of document paths
+var filterAttrRegexp = regexp.MustCompile(`(^|[^:\w.])([A-Za-z_]\w*)`)
+
+// validateQueryFilter checks that the FilterExpression of a Query does not use
+// the partition or the sort key of the table or index, which like in DynamoDB
+// belong in the KeyConditionExpression. Scans may filter on any attribute.
+func validateQueryFilter(filterExp, pKey, sKey string) error {
+	for _, m := range filterAttrRegexp.FindAllStringSubmatch(filterExp, -1) {
+		attr := m[2]
+		if attr == pKey || (sKey != "" && attr == sKey) {
+			return errors.New("ValidationException", "Filter Expression can only contain non-primary key attributes: Primary key attribute: "+attr)
+		}
+	}
+	return nil
+}
+
+// filterKeywords are the non attribute words of a FilterExpression, once its
+// keywords are normalized, and of the filters converted from a legacy
+// ScanFilter or QueryFilter, like "status IS NOT NULL"
+var filterKeywords = map[string]bool{"AND": true, "OR": true, "NOT": true, "BETWEEN": true, "IN": true, "IS": true, "NULL": true, "SIZE": true}
+
+// validateFilterableAttributes checks that the FilterExpression of a Query or
+// Scan only uses the keys of the table and its indexes and the
+// FilterableAttributes of its config, unless the request sets AllowFullScan.
+// Tables without FilterableAttributes may filter on any attribute.
+func validateFilterableAttributes(tableConf models.TableConfig, filterExp string, allowFullScan bool) error {
+	if allowFullScan || len(tableConf.FilterableAttributes) == 0 || filterExp == "" {
+		return nil
+	}
+	filterable := map[string]bool{tableConf.PartitionKey: true, tableConf.SortKey: true}
+	for _, conf := range tableConf.Indices {
+		filterable[conf.PartitionKey] = true
+		filterable[conf.SortKey] = true
+	}
+	for _, attr := range tableConf.FilterableAttributes {
+		filterable[attr] = true
+	}
+	for _, m := range filterAttrRegexp.FindAllStringSubmatchIndex(filterExp, -1) {
+		attr := filterExp[m[4]:m[5]]
+		// function names are followed by their arguments
+		if filterKeywords[attr] || strings.HasPrefix(strings.TrimSpace(filterExp[m[5]:]), "(") {
+			continue
+		}
+		if !filterable[attr] {
+			return errors.New("ValidationException", "FilterExpression can only filter on the keys and FilterableAttributes of the table unless AllowFullScan is set, not on: "+attr).WithParameter("FilterExpression")
+		}
+	}
+	return nil
+}
+
+// queryKeys resolves the table keys and the keys of the queried index,
+// falling back to the table keys when no index is used
+func queryKeys(query *models.Query, tableConf models.TableConfig) (tPKey, tSKey, pKey, sKey string) {
+	tPKey = tableConf.PartitionKey
+	tSKey = tableConf.SortKey
+	if query.IndexName != "" {
+		conf := tableConf.Indices[query.IndexName]
+		query.IndexName = spannerIndexName(query.IndexName)
+
+		if tableConf.ActualTable != query.TableName {
+			query.TableName = tableConf.ActualTable
+		}
+
+		sKey = conf.SortKey
+		pKey = conf.PartitionKey
+	} else {
+		sKey = tableConf.SortKey
+		pKey = tableConf.PartitionKey
+	}
+	if pKey == "" {
+		pKey = tPKey
+		sKey = tSKey
+	}
+	return
+}
+
+// spannerIndexName returns the Spanner index of a DynamoDB index name, whose
+// hyphens are underscores in Spanner
+func spannerIndexName(indexName string) string {
+	return strings.Replace(indexName, "-", "_", -1)
+}
+
+func createSpannerQuery(query *models.Query, tPkey, tSkey, pKey, sKey string) (spanner.Statement, []string, bool, int64, string, error) {
 	stmt := spanner.Statement{}
-	cols, colstr, isCountQuery, err := parseSpannerColumns(query, tPkey, pKey, sKey)
+	cols, colstr, isCountQuery, err := parseSpannerColumns(query, tPkey, tSkey, pKey, sKey)
 	if err != nil {
 		return stmt, cols, isCountQuery, 0, "", err
 	}
+	// the index name is a FORCE_INDEX hint, which takes only a plain word
+	query.IndexName = spannerIndexName(query.IndexName)
+	if query.IndexName != "" && !hintRegexp.MatchString(query.IndexName) {
+		return stmt, cols, isCountQuery, 0, "", errors.New("ValidationException", "IndexName can only have letters, digits, underscores and hyphens", query.IndexName).WithParameter("IndexName")
+	}
 	tableName := parseSpannerTableName(query)
 	whereCondition, m := parseSpannerCondition(query, pKey, sKey)
-	offsetString, offset := parseOffset(query)
-	orderBy := parseSpannerSorting(query, isCountQuery, pKey, sKey)
+	var offsetString, orderBy string
+	var offset int64
+	if len(query.ScanKeys) > 0 {
+		startAfter, err := startAfterCondition(query.ScanKeys, query.StartFrom, m, query.ScanDescending)
+		if err != nil {
+			return stmt, cols, isCountQuery, 0, "", err
+		}
+		if startAfter != "" {
+			if whereCondition == " " {
+				whereCondition = "WHERE " + startAfter + " "
+			} else {
+				whereCondition += " AND " + startAfter + " "
+			}
+		}
+		if !isCountQuery {
+			cols, colstr = withScanKeys(query, cols, colstr)
+			direction := " ASC"
+			if query.ScanDescending {
+				direction = " DESC"
+			}
+			orderBy = " ORDER BY " + strings.Join(query.ScanKeys, direction+", ") + direction + " "
+		}
+	} else {
+		offsetString, offset = parseOffset(query)
+		orderBy = parseSpannerSorting(query, isCountQuery, pKey, sKey)
+		if !isCountQuery {
+			// index rows with the same index keys are ordered by the table key,
+			// so the OFFSET of the next page neither skips nor repeats them
+			for _, k := range []string{tPkey, tSkey} {
+				if k != "" && k != pKey && k != sKey {
+					orderBy += ", " + k + " ASC "
+				}
+			}
+		}
+	}
 	limitClause := parseLimit(query, isCountQuery)
-	finalQuery := "SELECT " + colstr + " FROM " + tableName + " " + whereCondition + orderBy + limitClause + offsetString
+	finalQuery := statementHints(query.TableName) + "SELECT " + colstr + " FROM " + tableName + " " + whereCondition + orderBy + limitClause + offsetString
 	stmt.SQL = finalQuery
 	h := fnv.New64a()
 	h.Write([]byte(finalQuery))
@@ -320,7 +1015,20 @@ func createSpannerQuery(query *models.Query, tPkey, pKey, sKey string) (spanner.
 	return stmt, cols, isCountQuery, offset, rs, nil
 }
 
-func parseSpannerColumns(query *models.Query, tPkey, pKey, sKey string) ([]string, string, bool, error) {
+// withScanKeys adds the scan keys missing from a projection, which are needed
+// for the LastEvaluatedKey
+func withScanKeys(query *models.Query, cols []string, colstr string) ([]string, string) {
+	table := changeTableNameForSP(query.TableName)
+	for _, k := range query.ScanKeys {
+		if !stringInSlice(k, cols) {
+			cols = append(cols, k)
+			colstr += "," + table + ".`" + k + "`"
+		}
+	}
+	return cols, colstr
+}
+
+func parseSpannerColumns(query *models.Query, tPkey, tSkey, pKey, sKey string) ([]string, string, bool, error) {
 	if query == nil {
 		return []string{}, "", false, errors.New("Query is not present")
 	}
@@ -332,50 +1040,33 @@ func parseSpannerColumns(query *models.Query, tPkey, pKey, sKey string) ([]strin
 	var cols []string
 	if query.ProjectionExpression != "" {
 		cols = getSpannerProjections(query.ProjectionExpression, query.TableName, query.ExpressionAttributeNames)
-		insertPKey := true
-		for i := 0; i < len(cols); i++ {
-			if cols[i] == pKey {
-				insertPKey = false
-				break
-			}
-		}
-		if insertPKey {
-			cols = append(cols, pKey)
-		}
-		if sKey != "" {
-			insertSKey := true
-			for i := 0; i < len(cols); i++ {
-				if cols[i] == sKey {
-					insertSKey = false
-					break
-				}
-			}
-			if insertSKey {
-				cols = append(cols, sKey)
+		// the keys are read for the LastEvaluatedKey
+		for _, k := range []string{pKey, sKey, tPkey, tSkey} {
+			if k != "" && !stringInSlice(k, cols) {
+				cols = append(cols, k)
 			}
 		}
-		if tPkey != pKey {
-			insertSKey := true
-			for i := 0; i < len(cols); i++ {
-				if cols[i] == tPkey {
-					insertSKey = false
-					break
-				}
-			}
-			if insertSKey {
-				cols = append(cols, tPkey)
-			}
-		}
-
 	} else {
 		cols = models.TableColumnMap[table]
 	}
+	overflow := overflowColumn(query.TableName)
+	needOverflow, hasOverflow := false, false
 	for i := 0; i < len(cols); i++ {
-		if cols[i] == "commit_timestamp" {
+		if cols[i] == "commit_timestamp" || cols[i] == models.SoftDeleteColumn || cols[i] == models.SoftDeleteTimeColumn {
+			continue
+		}
+		if _, ok := models.TableDDL[table][cols[i]]; overflow != "" && !ok {
+			needOverflow = true
 			continue
 		}
+		if cols[i] == overflow {
+			hasOverflow = true
+		}
 		colStr += table + ".`" + cols[i] + "`,"
 	}
+	if needOverflow && !hasOverflow {
+		colStr += table + ".`" + overflow + "`,"
+	}
 	colStr = strings.Trim(colStr, ",")
 	return cols, colStr, false, nil
 }
@@ -385,12 +1076,52 @@ func changeTableNameForSP(tableName string) string {
 	return tableName
 }
 
+// parseSpannerTableName returns the table of the FROM clause with its table
+// hints, the TableHints of the table config and the FORCE_INDEX of an index
+// query
 func parseSpannerTableName(query *models.Query) string {
 	tableName := changeTableNameForSP(query.TableName)
+	hints := map[string]string{}
+	if tableConf, err := config.GetTableConf(query.TableName); err == nil {
+		for k, v := range tableConf.TableHints {
+			hints[k] = v
+		}
+	}
 	if query.IndexName != "" {
-		tableName += "@{FORCE_INDEX=" + query.IndexName + "}"
+		hints["FORCE_INDEX"] = query.IndexName
 	}
-	return tableName
+	return tableName + renderHints(hints)
+}
+
+// statementHints returns the StatementHints of the table config which prefix
+// the SELECT of its queries and scans
+func statementHints(tableName string) string {
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil || len(tableConf.StatementHints) == 0 {
+		return ""
+	}
+	return renderHints(tableConf.StatementHints) + " "
+}
+
+var hintRegexp = regexp.MustCompile(`^\w+$`)
+
+// renderHints returns the hints as @{KEY=value,...} in key order. Hints which
+// are not plain words are left out.
+func renderHints(hints map[string]string) string {
+	keys := make([]string, 0, len(hints))
+	for k, v := range hints {
+		if hintRegexp.MatchString(k) && hintRegexp.MatchString(v) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		keys[i] = k + "=" + hints[k]
+	}
+	return "@{" + strings.Join(keys, ",") + "}"
 }
 
 func parseSpannerCondition(query *models.Query, pKey, sKey string) (string, map[string]interface{}) {
@@ -409,6 +1140,13 @@ func parseSpannerCondition(query *models.Query, pKey, sKey string) (string, map[
 		whereClause, query.FilterExp = createWhereClause(whereClause, query.FilterExp, "filterExp", query.RangeValMap, params)
 	}
 
+	if config.IsSoftDelete(query.TableName) {
+		if whereClause != "WHERE " {
+			whereClause += " AND "
+		}
+		whereClause += "(" + models.SoftDeleteColumn + " IS NULL OR " + models.SoftDeleteColumn + " = false) "
+	}
+
 	if whereClause == "WHERE " {
 		whereClause = " "
 	}
@@ -423,10 +1161,15 @@ func createWhereClause(whereClause string, expression string, queryVar string, R
 		whereClause += " AND "
 	}
 	count := 1
-	for k, v := range RangeValueMap {
+	for _, k := range placeholders(RangeValueMap) {
+		v := RangeValueMap[k]
 		if strings.Contains(expression, k) {
 			str := queryVar + strconv.Itoa(count)
 			expression = strings.ReplaceAll(expression, k, "@"+str)
+			if b, ok := v.([]byte); ok {
+				// BYTES(MAX) columns hold the JSON of their value
+				v, _ = json.Marshal(b)
+			}
 			params[str] = v
 			count++
 		}
@@ -435,6 +1178,22 @@ func createWhereClause(whereClause string, expression string, queryVar string, R
 	return whereClause, expression
 }
 
+// placeholders returns the expression attribute values longest first, so that
+// a placeholder such as :t is not replaced inside :true
+func placeholders(valueMap map[string]interface{}) []string {
+	keys := make([]string, 0, len(valueMap))
+	for k := range valueMap {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
 func parseOffset(query *models.Query) (string, int64) {
 	logger.LogDebug(query)
 	if query.StartFrom != nil {
@@ -450,14 +1209,15 @@ func parseSpannerSorting(query *models.Query, isCountQuery bool, pKey, sKey stri
 	if isCountQuery {
 		return " "
 	}
-	if sKey == "" {
-		return " "
+	// there is no sort key order, so the rows come in partition key order
+	key := sKey
+	if key == "" {
+		key = pKey
 	}
-
 	if query.SortAscending {
-		return " ORDER BY " + sKey + " ASC "
+		return " ORDER BY " + key + " ASC "
 	}
-	return " ORDER BY " + sKey + " DESC "
+	return " ORDER BY " + key + " DESC "
 }
 
 func parseLimit(query *models.Query, isCountQuery bool) string {
@@ -465,13 +1225,13 @@ func parseLimit(query *models.Query, isCountQuery bool) string {
 		return ""
 	}
 	if query.Limit == 0 {
-		return " LIMIT 5000 "
+		return " LIMIT " + strconv.FormatInt(defaultQueryLimit, 10) + " "
 	}
 	return " LIMIT " + strconv.FormatInt(query.Limit, 10)
 }
 
 // BatchGetWithProjection from Spanner
-func BatchGetWithProjection(ctx context.Context, tableName string, keyMapArray []map[string]interface{}, projectionExpression string, expressionAttributeNames map[string]string) ([]map[string]interface{}, error) {
+func BatchGetWithProjection(ctx context.Context, tableName string, keyMapArray []map[string]interface{}, projectionExpression string, expressionAttributeNames map[string]string, consistentRead bool) ([]map[string]interface{}, error) {
 	if len(keyMapArray) == 0 {
 		var resp = make([]map[string]interface{}, 0)
 		return resp, nil
@@ -480,8 +1240,29 @@ func BatchGetWithProjection(ctx context.Context, tableName string, keyMapArray [
 	if err != nil {
 		return nil, err
 	}
-	tableName = tableConf.ActualTable
+	if tables, groups := interleavedGroups(tableConf, keyMapArray); tables != nil {
+		resp := []map[string]interface{}{}
+		for _, table := range tables {
+			var res []map[string]interface{}
+			if table == tableConf.ActualTable {
+				res, err = batchGetWithProjection(ctx, tableConf, groups[table], projectionExpression, expressionAttributeNames, consistentRead)
+			} else {
+				res, err = BatchGetWithProjection(ctx, table, groups[table], projectionExpression, expressionAttributeNames, consistentRead)
+			}
+			if err != nil {
+				return nil, err
+			}
+			resp = append(resp, res...)
+		}
+		return resp, nil
+	}
+	return batchGetWithProjection(ctx, tableConf, keyMapArray, projectionExpression, expressionAttributeNames, consistentRead)
+}
 
+// batchGetWithProjection reads the keys from the ActualTable of tableConf
+// itself
+func batchGetWithProjection(ctx context.Context, tableConf models.TableConfig, keyMapArray []map[string]interface{}, projectionExpression string, expressionAttributeNames map[string]string, consistentRead bool) ([]map[string]interface{}, error) {
+	tableName := tableConf.ActualTable
 	projectionCols := getSpannerProjections(projectionExpression, tableName, expressionAttributeNames)
 	var pValues []interface{}
 	var sValues []interface{}
@@ -493,7 +1274,11 @@ func BatchGetWithProjection(ctx context.Context, tableName string, keyMapArray [
 		}
 		pValues = append(pValues, pValue)
 	}
-	return storage.GetStorageInstance().SpannerBatchGet(ctx, tableName, pValues, sValues, projectionCols)
+	items, err := storage.GetStorageInstance().SpannerBatchGet(ctx, tableName, pValues, sValues, projectionCols, consistentRead)
+	if err != nil {
+		return nil, err
+	}
+	return projectItemPaths(items, projectionExpression, expressionAttributeNames), nil
 }
 
 // Delete service
@@ -502,7 +1287,7 @@ func Delete(ctx context.Context, tableName string, primaryKeyMap map[string]inte
 	if err != nil {
 		return err
 	}
-	tableName = tableConf.ActualTable
+	tableName = itemTable(tableConf, primaryKeyMap)
 	e, err := utils.CreateConditionExpression(condExpression, attrMap)
 	if err != nil {
 		return err
@@ -516,30 +1301,123 @@ func BatchDelete(ctx context.Context, tableName string, keyMapArray []map[string
 	if err != nil {
 		return err
 	}
-	oldRes, _ := BatchGet(ctx, tableName, keyMapArray)
+	if tables, groups := interleavedGroups(tableConf, keyMapArray); tables != nil {
+		for _, table := range tables {
+			if table == tableConf.ActualTable {
+				err = batchDelete(ctx, tableConf, groups[table])
+			} else {
+				err = BatchDelete(ctx, table, groups[table])
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return batchDelete(ctx, tableConf, keyMapArray)
+}
 
-	tableName = tableConf.ActualTable
-	err = storage.GetStorageInstance().SpannerBatchDelete(ctx, tableName, keyMapArray)
+// batchDelete deletes the keys from the ActualTable of tableConf itself
+func batchDelete(ctx context.Context, tableConf models.TableConfig, keyMapArray []map[string]interface{}) error {
+	oldRes, _ := batchGet(ctx, tableConf, keyMapArray)
+
+	tableName := tableConf.ActualTable
+	err := storage.GetStorageInstance().SpannerBatchDelete(ctx, tableName, keyMapArray)
 	if err != nil {
 		return err
 	}
-	go func() {
-		if len(oldRes) == len(keyMapArray) {
-			for i := 0; i < len(keyMapArray); i++ {
-				go StreamDataToThirdParty(oldRes[i], keyMapArray[i], tableName)
+	if len(oldRes) == len(keyMapArray) {
+		for i := 0; i < len(keyMapArray); i++ {
+			StreamDataToThirdParty(oldRes[i], keyMapArray[i], tableName)
+		}
+	} else {
+		for i := 0; i < len(keyMapArray); i++ {
+			StreamDataToThirdParty(nil, keyMapArray[i], tableName)
+		}
+	}
+	return nil
+}
+
+// BatchConditionalDelete deletes every key whose condition expression holds,
+// in transactions of scanDeleteBatchSize keys each. It returns the error of
+// every key in request order, nil for the deleted ones; a transaction which
+// fails sets the error of each of its keys, and the next batches still run.
+func BatchConditionalDelete(ctx context.Context, tableName string, keyMapArray []map[string]interface{}, condExpressions []string, attrMaps []map[string]interface{}) ([]error, error) {
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil {
+		return nil, err
+	}
+	evals := make([]*models.Eval, len(keyMapArray))
+	for i := range keyMapArray {
+		evals[i], err = utils.CreateConditionExpression(condExpressions[i], attrMaps[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	errs := make([]error, len(keyMapArray))
+	for start := 0; start < len(keyMapArray); start += scanDeleteBatchSize {
+		end := start + scanDeleteBatchSize
+		if end > len(keyMapArray) {
+			end = len(keyMapArray)
+		}
+		// the keys of a batch are deleted per table of their interleaved hierarchy
+		tables, groups := []string{}, map[string][]int{}
+		for i := start; i < end; i++ {
+			table := itemTable(tableConf, keyMapArray[i])
+			if _, ok := groups[table]; !ok {
+				tables = append(tables, table)
+			}
+			groups[table] = append(groups[table], i)
+		}
+		for _, table := range tables {
+			keys := make([]map[string]interface{}, len(groups[table]))
+			tableEvals := make([]*models.Eval, len(groups[table]))
+			for j, i := range groups[table] {
+				keys[j], tableEvals[j] = keyMapArray[i], evals[i]
 			}
-		} else {
-			for i := 0; i < len(keyMapArray); i++ {
-				go StreamDataToThirdParty(nil, keyMapArray[i], tableName)
+			oldRes, _ := batchGetTable(ctx, tableConf, table, keys)
+			keyErrs, err := storage.GetStorageInstance().SpannerConditionalDelete(ctx, table, keys, tableEvals)
+			for j, i := range groups[table] {
+				if err != nil {
+					errs[i] = err
+				} else {
+					errs[i] = keyErrs[j]
+				}
 			}
+			if err != nil {
+				continue
+			}
+			for _, old := range deletedImages(tableConf, oldRes, keys, keyErrs) {
+				StreamDataToThirdParty(old, nil, table)
+			}
+		}
+	}
+	return errs, nil
+}
 
+// deletedImages returns the old images of the deleted keys, the keys without
+// an error, out of the items read before the delete
+func deletedImages(tableConf models.TableConfig, oldRes []map[string]interface{}, keys []map[string]interface{}, errs []error) []map[string]interface{} {
+	images := []map[string]interface{}{}
+	for i, key := range keys {
+		if errs[i] != nil {
+			continue
 		}
-	}()
-	return nil
+		for _, old := range oldRes {
+			if reflect.DeepEqual(old[tableConf.PartitionKey], key[tableConf.PartitionKey]) && reflect.DeepEqual(old[tableConf.SortKey], key[tableConf.SortKey]) {
+				images = append(images, old)
+				break
+			}
+		}
+	}
+	return images
 }
 
 // Scan service
 func Scan(ctx context.Context, scanData models.ScanMeta) (map[string]interface{}, error) {
+	if err := validateSegment(scanData); err != nil {
+		return nil, err
+	}
 	query := models.Query{}
 	query.TableName = scanData.TableName
 	query.Limit = scanData.Limit
@@ -553,13 +1431,106 @@ func Scan(ctx context.Context, scanData models.ScanMeta) (map[string]interface{}
 	query.ExpressionAttributeNames = scanData.ExpressionAttributeNames
 	query.OnlyCount = scanData.OnlyCount
 	query.ProjectionExpression = scanData.ProjectionExpression
+	query.AllowFullScan = scanData.AllowFullScan
 
-	for k, v := range query.ExpressionAttributeNames {
-		query.FilterExp = strings.ReplaceAll(query.FilterExp, k, v)
+	query.FilterExp = replaceAttributeNames(query.FilterExp, query.ExpressionAttributeNames)
+	tableConf, err := config.GetTableConf(query.TableName)
+	if err != nil {
+		return nil, err
 	}
+	keysQuery := query
+	query.ScanKeys = scanKeys(queryKeys(&keysQuery, tableConf))
 
 	rs, _, err := QueryAttributes(ctx, query)
-	return rs, err
+	if err != nil || scanData.TotalSegments == 0 {
+		return rs, err
+	}
+	items, _ := rs["Items"].([]map[string]interface{})
+	items = segmentItems(items, tableConf.PartitionKey, scanData.Segment, scanData.TotalSegments)
+	rs["Items"] = items
+	rs["Count"] = len(items)
+	return rs, nil
+}
+
+// validateSegment checks the Segment and TotalSegments of a parallel Scan
+func validateSegment(scanData models.ScanMeta) error {
+	if scanData.TotalSegments == 0 {
+		if scanData.Segment != 0 {
+			return errors.New("ValidationException", "The TotalSegments parameter is required but was not present in the request when Segment parameter is present").WithParameter("TotalSegments")
+		}
+		return nil
+	}
+	if scanData.TotalSegments < 1 || scanData.TotalSegments > maxTotalSegments {
+		return errors.New("ValidationException", "TotalSegments must be between 1 and", maxTotalSegments).WithParameter("TotalSegments")
+	}
+	if scanData.Segment < 0 || scanData.Segment >= scanData.TotalSegments {
+		return errors.New("ValidationException", "Segment must be greater than or equal to 0 and less than TotalSegments").WithParameter("Segment")
+	}
+	if scanData.OnlyCount {
+		return errors.New("ValidationException", "Select COUNT is not supported with TotalSegments").WithParameter("Select")
+	}
+	return nil
+}
+
+// segmentItems keeps the items of a page whose partition key is assigned to
+// the segment by segmentOf, the assignment reported by SegmentForKey. It is
+// applied after the page is read, like a filter, so the LastEvaluatedKey of
+// the page stays the one of the last item read.
+func segmentItems(items []map[string]interface{}, pKey string, segment, totalSegments int64) []map[string]interface{} {
+	kept := []map[string]interface{}{}
+	for _, item := range items {
+		if segmentOf(item[pKey], totalSegments) == segment {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// scanKeys returns the columns a Scan is ordered by, the keys of the scanned
+// index followed by the table keys, which identify every row
+func scanKeys(tPKey, tSKey, pKey, sKey string) []string {
+	keys := []string{}
+	for _, k := range []string{pKey, sKey, tPKey, tSKey} {
+		if k != "" && !stringInSlice(k, keys) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func stringInSlice(s string, list []string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// startAfterCondition returns the condition selecting the rows which come
+// after startFrom in the order of keys, descending or not, so pages do not
+// shift when rows are inserted or deleted between requests
+func startAfterCondition(keys []string, startFrom, params map[string]interface{}, descending bool) (string, error) {
+	if len(startFrom) == 0 {
+		return "", nil
+	}
+	op := " > @"
+	if descending {
+		op = " < @"
+	}
+	var terms []string
+	prefix := ""
+	for i, k := range keys {
+		v, ok := startFrom[k]
+		if !ok {
+			return "", errors.New("ValidationException", "The provided starting key is invalid: missing key attribute "+k)
+		}
+		param := "startKey" + strconv.Itoa(i+1)
+		params[param] = v
+		terms = append(terms, "("+prefix+k+op+param+")")
+		prefix += k + " = @" + param + " AND "
+	}
+	return "(" + strings.Join(terms, " OR ") + ")", nil
 }
 
 func scanSpanerTable(ctx context.Context, tableName, pKey, sKey string) ([]map[string]interface{}, error) {
@@ -572,7 +1543,7 @@ func scanSpanerTable(ctx context.Context, tableName, pKey, sKey string) ([]map[s
 	query.Limit = originalLimit + 1
 	for {
 		query.StartFrom = startFrom
-		stmt, cols, isCountQuery, offset, _, err := createSpannerQuery(&query, pKey, pKey, sKey)
+		stmt, cols, isCountQuery, offset, _, err := createSpannerQuery(&query, pKey, sKey, pKey, sKey)
 		if err != nil {
 			return nil, err
 		}
@@ -594,6 +1565,161 @@ func scanSpanerTable(ctx context.Context, tableName, pKey, sKey string) ([]map[s
 	return result, nil
 }
 
+// SegmentForKey returns the segment of a partition key value out of
+// totalSegments. The segment is the FNV-1a 64 bit hash of the value's string
+// form modulo totalSegments, so it only changes if totalSegments changes. A
+// Scan with that Segment and TotalSegments is the one returning the item.
+func SegmentForKey(tableName string, keyMap map[string]interface{}, totalSegments int64) (int64, error) {
+	if totalSegments < 1 || totalSegments > maxTotalSegments {
+		return 0, errors.New("ValidationException", "TotalSegments must be between 1 and", maxTotalSegments)
+	}
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil {
+		return 0, err
+	}
+	pValue, ok := keyMap[tableConf.PartitionKey]
+	if !ok {
+		return 0, errors.New("ValidationException", "partition key is missing", tableConf.PartitionKey)
+	}
+	return segmentOf(pValue, totalSegments), nil
+}
+
+// segmentOf returns the segment of a partition key value, the FNV-1a 64 bit
+// hash of its keyString modulo totalSegments
+func segmentOf(pValue interface{}, totalSegments int64) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(keyString(pValue)))
+	return int64(h.Sum64() % uint64(totalSegments))
+}
+
+// keyString returns the string form of a key value. A number has the same
+// form whether it is an int64 read from Spanner or a float64 decoded from a
+// request, e.g. 1000000 for both.
+func keyString(v interface{}) string {
+	if n, ok := numberValue(v); ok {
+		return n.Text('f', -1)
+	}
+	return fmt.Sprint(v)
+}
+
+// PurgeTombstones permanently removes the items of a SoftDelete table which
+// were deleted longer ago than the table's TombstoneRetention
+func PurgeTombstones(ctx context.Context, tableName string) (int64, error) {
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil {
+		return 0, err
+	}
+	if !tableConf.SoftDelete {
+		return 0, errors.New("ValidationException", "soft delete is not enabled for table", tableName)
+	}
+	retention := defaultTombstoneRetention
+	if tableConf.TombstoneRetention != "" {
+		retention, err = time.ParseDuration(tableConf.TombstoneRetention)
+		if err != nil {
+			return 0, errors.New("ValidationException", "invalid TombstoneRetention", tableConf.TombstoneRetention, err)
+		}
+	}
+	return storage.GetStorageInstance().SpannerPurgeTombstones(ctx, tableConf.ActualTable, time.Now().Add(-retention))
+}
+
+// scanDeleteBatchSize is the number of items ScanDelete deletes per transaction
+const scanDeleteBatchSize = 100
+
+// ScanDelete deletes the items of one page of a Scan matching its
+// FilterExpression, in batches of scanDeleteBatchSize items each applied in
+// a transaction. The Scan reads a stale snapshot, so each item is deleted
+// with the FilterExpression as its condition and an item changed since the
+// Scan to no longer match is kept. It returns the number of deleted items and
+// the LastEvaluatedKey to resume from, nil once the table is done.
+func ScanDelete(ctx context.Context, scanData models.ScanMeta) (int, interface{}, error) {
+	tableConf, err := config.GetTableConf(scanData.TableName)
+	if err != nil {
+		return 0, nil, err
+	}
+	scanData.IndexName = ""
+	scanData.OnlyCount = false
+	scanData.ProjectionExpression = tableConf.PartitionKey
+	if tableConf.SortKey != "" {
+		scanData.ProjectionExpression += ", " + tableConf.SortKey
+	}
+	res, err := Scan(ctx, scanData)
+	if err != nil {
+		return 0, nil, err
+	}
+	items, _ := res["Items"].([]map[string]interface{})
+	filter := replaceAttributeNames(scanData.FilterExpression, scanData.ExpressionAttributeNames)
+	deleted := 0
+	for _, batch := range keyBatches(items, tableConf.PartitionKey, tableConf.SortKey, scanDeleteBatchSize) {
+		conditions := make([]string, len(batch))
+		attrMaps := make([]map[string]interface{}, len(batch))
+		for i := range batch {
+			conditions[i], attrMaps[i] = filter, scanData.ExpressionAttributeMap
+		}
+		errs, err := BatchConditionalDelete(ctx, scanData.TableName, batch, conditions, attrMaps)
+		if err != nil {
+			return deleted, scanData.StartFrom, err
+		}
+		count, err := countDeleted(errs)
+		deleted += count
+		if err != nil {
+			return deleted, scanData.StartFrom, err
+		}
+	}
+	return deleted, res["LastEvaluatedKey"], nil
+}
+
+var attributeNameRegexp = regexp.MustCompile(`#[A-Za-z0-9_]+`)
+
+// replaceAttributeNames replaces the ExpressionAttributeNames placeholders of
+// the expression with the attributes they name. Only whole placeholders are
+// replaced, so #st does not touch #status.
+func replaceAttributeNames(expression string, names map[string]string) string {
+	if len(names) == 0 {
+		return expression
+	}
+	return attributeNameRegexp.ReplaceAllStringFunc(expression, func(placeholder string) string {
+		if name, ok := names[placeholder]; ok {
+			return name
+		}
+		return placeholder
+	})
+}
+
+// countDeleted returns the number of keys deleted by a BatchConditionalDelete
+// and the first error other than a failed condition
+func countDeleted(errs []error) (int, error) {
+	deleted := 0
+	for _, err := range errs {
+		if err == nil {
+			deleted++
+		} else if err.Error() != "ConditionalCheckFailedException" {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// keyBatches returns the keys of the items in batches of at most size keys
+func keyBatches(items []map[string]interface{}, pKey, sKey string, size int) [][]map[string]interface{} {
+	batches := [][]map[string]interface{}{}
+	for start := 0; start < len(items); start += size {
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+		batch := make([]map[string]interface{}, 0, end-start)
+		for _, item := range items[start:end] {
+			key := map[string]interface{}{pKey: item[pKey]}
+			if sKey != "" {
+				key[sKey] = item[sKey]
+			}
+			batch = append(batch, key)
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
 // Remove for remove operation in update
 func Remove(ctx context.Context, tableName string, updateAttr models.UpdateAttr, actionValue string, expr *models.UpdateExpressionCondition, oldRes map[string]interface{}) (map[string]interface{}, error) {
 	actionValue = strings.ReplaceAll(actionValue, " ", "")
@@ -602,25 +1728,17 @@ func Remove(ctx context.Context, tableName string, updateAttr models.UpdateAttr,
 	if err != nil {
 		return nil, err
 	}
-	tableName = tableConf.ActualTable
+	tableName = itemTable(tableConf, updateAttr.PrimaryKeyMap)
 	e, err := utils.CreateConditionExpression(updateAttr.ConditionExpression, updateAttr.ExpressionAttributeMap)
 	if err != nil {
 		return nil, err
 	}
-	err = storage.GetStorageInstance().SpannerRemove(ctx, tableName, updateAttr.PrimaryKeyMap, e, expr, colsToRemove)
+	newResp, err := storage.GetStorageInstance().SpannerRemove(ctx, tableName, updateAttr.PrimaryKeyMap, e, expr, colsToRemove)
 	if err != nil {
 		return nil, err
 	}
 	if oldRes == nil {
 		return oldRes, nil
 	}
-	updateResp := map[string]interface{}{}
-	for k, v := range oldRes {
-		updateResp[k] = v
-	}
-
-	for i := 0; i < len(colsToRemove); i++ {
-		delete(updateResp, colsToRemove[i])
-	}
-	return updateResp, nil
+	return newResp, nil
 }
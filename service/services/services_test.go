@@ -15,10 +15,16 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 
 	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
 	"gopkg.in/go-playground/assert.v1"
 )
 
@@ -78,6 +84,13 @@ func Test_getSpannerProjections(t *testing.T) {
 			nil,
 			[]string{},
 		},
+		{
+			"nested path",
+			"#f.name, second.value",
+			"testTable",
+			map[string]string{"#f": "first"},
+			[]string{"first", "second"},
+		},
 		{
 			"wrong table",
 			"first, second, third",
@@ -93,6 +106,280 @@ func Test_getSpannerProjections(t *testing.T) {
 	}
 }
 
+func Test_getSpannerProjectionsOverflow(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{
+		"events": {PartitionKey: "id", ActualTable: "events", OverflowColumn: "extra"},
+	}
+	models.TableColumnMap["events"] = []string{"id", "kind", "extra"}
+	models.TableDDL["events"] = map[string]string{"id": "STRING(MAX)", "kind": "STRING(MAX)", "extra": "BYTES(MAX)"}
+	defer func() {
+		delete(models.TableColumnMap, "events")
+		delete(models.TableDDL, "events")
+	}()
+
+	assert.Equal(t, getSpannerProjections("id, browser, kind", "events", nil), []string{"id", "kind", "browser"})
+
+	query := &models.Query{TableName: "events", ProjectionExpression: "browser"}
+	cols, colStr, _, err := parseSpannerColumns(query, "id", "", "id", "")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, cols, []string{"browser", "id"})
+	assert.Equal(t, colStr, "events.`id`,events.`extra`")
+}
+
+func TestDefaultProjection(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{
+		"documents": {PartitionKey: "id", ExcludeByDefault: []string{"body"}},
+		"docAlias":  {ActualTable: "documents"},
+		"plain":     {PartitionKey: "id"},
+	}
+	models.TableColumnMap["documents"] = []string{"id", "title", "body", "commit_timestamp"}
+	defer delete(models.TableColumnMap, "documents")
+
+	tests := []struct {
+		testName             string
+		tableName            string
+		projectionExpression string
+		want                 string
+	}{
+		{"excluded column", "documents", "", "id,title"},
+		{"alias of the table", "docAlias", "", "id,title"},
+		{"explicit projection", "documents", "id, body", "id, body"},
+		{"no excluded columns", "plain", "", ""},
+		{"unknown table", "unknown", "", ""},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, DefaultProjection(tc.tableName, tc.projectionExpression), tc.want)
+	}
+}
+
+func TestValidateProjection(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{
+		"documents": {PartitionKey: "id", StrictProjection: true},
+		"overflow":  {PartitionKey: "id", StrictProjection: true, OverflowColumn: "extra"},
+		"plain":     {PartitionKey: "id"},
+	}
+	models.TableDDL["documents"] = map[string]string{"id": "STRING(MAX)", "title": "STRING(MAX)", "meta": "BYTES(MAX)"}
+	models.TableDDL["overflow"] = map[string]string{"id": "STRING(MAX)", "extra": "BYTES(MAX)"}
+	models.TableDDL["plain"] = map[string]string{"id": "STRING(MAX)"}
+	defer delete(models.TableDDL, "documents")
+	defer delete(models.TableDDL, "overflow")
+	defer delete(models.TableDDL, "plain")
+
+	tests := []struct {
+		testName             string
+		tableName            string
+		projectionExpression string
+		names                map[string]string
+		wantErr              bool
+	}{
+		{"known attributes", "documents", "id, #t, meta.author", map[string]string{"#t": "title"}, false},
+		{"unknown attribute", "documents", "id, body", nil, true},
+		{"unknown expression attribute name", "documents", "#b", map[string]string{"#b": "body"}, true},
+		{"overflow column", "overflow", "id, body", nil, false},
+		{"omitted by default", "plain", "id, body", nil, false},
+	}
+	for _, tc := range tests {
+		err := ValidateProjection(tc.tableName, tc.projectionExpression, tc.names)
+		assert.Equal(t, err != nil, tc.wantErr)
+		if tc.wantErr {
+			assert.Equal(t, err.(*errors.Error).Parameter, "ProjectionExpression")
+		}
+	}
+}
+
+func TestSelectProjection(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{
+		"employee": {PartitionKey: "emp_id", Indices: map[string]models.TableConfig{
+			"by_age":     {PartitionKey: "age", ProjectionType: "KEYS_ONLY"},
+			"by_address": {PartitionKey: "address", SortKey: "age", ProjectionType: "INCLUDE", NonKeyAttributes: []string{"first_name"}},
+			"by_name":    {PartitionKey: "first_name"},
+		}},
+	}
+
+	tests := []struct {
+		testName             string
+		indexName            string
+		selectValue          string
+		projectionExpression string
+		want                 string
+		wantErr              bool
+	}{
+		{"keys only index", "by_age", "ALL_PROJECTED_ATTRIBUTES", "", "emp_id,age", false},
+		{"include index", "by_address", "all_projected_attributes", "", "emp_id,address,age,first_name", false},
+		{"index projecting all attributes", "by_name", "ALL_PROJECTED_ATTRIBUTES", "", "", false},
+		{"all attributes of a keys only index", "by_age", "ALL_ATTRIBUTES", "", "", false},
+		{"specific attributes", "by_age", "SPECIFIC_ATTRIBUTES", "address", "address", false},
+		{"specific key attributes", "", "SPECIFIC_ATTRIBUTES", "emp_id", "emp_id", false},
+		{"specific attributes without a projection", "", "SPECIFIC_ATTRIBUTES", "", "", true},
+		{"keys only index without a select", "by_age", "", "", "emp_id,age", false},
+		{"index projecting all attributes without a select", "by_name", "", "", "", false},
+		{"keys only index with a projection", "by_age", "", "first_name", "first_name", false},
+		{"projected attributes without an index", "", "ALL_PROJECTED_ATTRIBUTES", "", "", true},
+		{"unknown index", "by_zip", "ALL_PROJECTED_ATTRIBUTES", "", "", true},
+		{"projected attributes with a projection", "by_age", "ALL_PROJECTED_ATTRIBUTES", "address", "", true},
+		{"all attributes with a projection", "by_age", "ALL_ATTRIBUTES", "address", "", true},
+	}
+	for _, tc := range tests {
+		got, err := SelectProjection("employee", tc.indexName, tc.selectValue, tc.projectionExpression)
+		assert.Equal(t, err != nil, tc.wantErr)
+		assert.Equal(t, got, tc.want)
+	}
+}
+
+func Test_projectNestedPaths(t *testing.T) {
+	item := map[string]interface{}{
+		"id": "1",
+		"profile": map[string]interface{}{
+			"email": "a@example.com",
+			"name":  "a",
+			"address": map[string]interface{}{
+				"city": "Pune",
+				"zip":  "411001",
+			},
+		},
+		"age": int64(20),
+	}
+	tests := []struct {
+		testName                 string
+		projectionExpression     string
+		expressionAttributeNames map[string]string
+		want                     map[string]interface{}
+	}{
+		{
+			"no nested path",
+			"id, profile",
+			nil,
+			item,
+		},
+		{
+			"nested path",
+			"profile.email",
+			nil,
+			map[string]interface{}{
+				"id":      "1",
+				"age":     int64(20),
+				"profile": map[string]interface{}{"email": "a@example.com"},
+			},
+		},
+		{
+			"nested paths with expression attribute names",
+			"#p.#e, #p.address.city",
+			map[string]string{"#p": "profile", "#e": "email"},
+			map[string]interface{}{
+				"id":  "1",
+				"age": int64(20),
+				"profile": map[string]interface{}{
+					"email":   "a@example.com",
+					"address": map[string]interface{}{"city": "Pune"},
+				},
+			},
+		},
+		{
+			"overlapping nested paths",
+			"profile.address.city, profile.address",
+			nil,
+			map[string]interface{}{
+				"id":  "1",
+				"age": int64(20),
+				"profile": map[string]interface{}{
+					"address": map[string]interface{}{"city": "Pune", "zip": "411001"},
+				},
+			},
+		},
+		{
+			"missing nested path",
+			"profile.phone",
+			nil,
+			map[string]interface{}{"id": "1", "age": int64(20)},
+		},
+	}
+
+	for _, tc := range tests {
+		got := projectNestedPaths(item, tc.projectionExpression, tc.expressionAttributeNames)
+		assert.Equal(t, got, tc.want)
+	}
+	assert.Equal(t, item["profile"].(map[string]interface{})["address"], map[string]interface{}{"city": "Pune", "zip": "411001"})
+}
+
+func Test_projectListElements(t *testing.T) {
+	tags := []interface{}{"red", "green", "blue"}
+	profile := map[string]interface{}{
+		"addresses": []interface{}{
+			map[string]interface{}{"city": "Pune", "zip": "411001"},
+			map[string]interface{}{"city": "Delhi", "zip": "110001"},
+		},
+	}
+	item := map[string]interface{}{"id": "1", "tags": tags, "profile": profile}
+	tests := []struct {
+		testName                 string
+		projectionExpression     string
+		expressionAttributeNames map[string]string
+		want                     map[string]interface{}
+	}{
+		{
+			"second element",
+			"tags[1]",
+			nil,
+			map[string]interface{}{"id": "1", "profile": profile, "tags": []interface{}{"green"}},
+		},
+		{
+			"elements in index order",
+			"#t[2], #t[0]",
+			map[string]string{"#t": "tags"},
+			map[string]interface{}{"id": "1", "profile": profile, "tags": []interface{}{"red", "blue"}},
+		},
+		{
+			"index out of range",
+			"tags[3]",
+			nil,
+			map[string]interface{}{"id": "1", "profile": profile},
+		},
+		{
+			"element of a nested list",
+			"profile.addresses[1].city",
+			nil,
+			map[string]interface{}{
+				"id":   "1",
+				"tags": tags,
+				"profile": map[string]interface{}{
+					"addresses": []interface{}{map[string]interface{}{"city": "Delhi"}},
+				},
+			},
+		},
+		{
+			"whole list with one of its elements",
+			"tags[1], tags",
+			nil,
+			map[string]interface{}{"id": "1", "profile": profile, "tags": tags},
+		},
+	}
+
+	for _, tc := range tests {
+		got := projectNestedPaths(item, tc.projectionExpression, tc.expressionAttributeNames)
+		assert.Equal(t, got, tc.want)
+	}
+	assert.Equal(t, item["tags"], []interface{}{"red", "green", "blue"})
+	assert.Equal(t, len(profile["addresses"].([]interface{})[1].(map[string]interface{})), 2)
+}
+
+func Test_projectListElementsOfReads(t *testing.T) {
+	defer func() { config.DbConfigMap = map[string]models.TableConfig{} }()
+	config.DbConfigMap = map[string]models.TableConfig{"tagged": {PartitionKey: "id", ActualTable: "tagged"}}
+	fakeSpanner(t)
+	ctx := context.Background()
+	item := map[string]interface{}{"id": "1", "tags": []interface{}{"red", "green", "blue"}}
+	assert.Equal(t, BatchPut(ctx, "tagged", []map[string]interface{}{item}), nil)
+	want := map[string]interface{}{"id": "1", "tags": []interface{}{"green"}}
+
+	items, err := BatchGetWithProjection(ctx, "tagged", []map[string]interface{}{{"id": "1"}}, "id, tags[1]", nil, true)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, items, []map[string]interface{}{want})
+
+	// the rows a Query or Scan reads are projected alike
+	rows := []map[string]interface{}{{"id": "1", "tags": []interface{}{"red", "green", "blue"}}}
+	assert.Equal(t, projectItemPaths(rows, "id, #t[1]", map[string]string{"#t": "tags"}), []map[string]interface{}{want})
+}
+
 func Test_createSpannerQuery(t *testing.T) {
 
 	tests := []struct {
@@ -353,7 +640,7 @@ func Test_createSpannerQuery(t *testing.T) {
 	}
 
 	for _, tc := range tests {
-		got1, got2, got3, got4, _, _ := createSpannerQuery(tc.queryModel, tc.partionkey, tc.primaryKey, tc.secondaryKey)
+		got1, got2, got3, got4, _, _ := createSpannerQuery(tc.queryModel, tc.partionkey, "", tc.primaryKey, tc.secondaryKey)
 
 		assert.Equal(t, got1, tc.want1)
 		assert.Equal(t, got2, tc.want2)
@@ -479,7 +766,7 @@ func Test_parseSpannerColumns(t *testing.T) {
 	}
 
 	for _, tc := range tests {
-		got1, got2, got3, _ := parseSpannerColumns(tc.queryModel, tc.partitionkey, tc.primaryKey, tc.secondaryKey)
+		got1, got2, got3, _ := parseSpannerColumns(tc.queryModel, tc.partitionkey, "", tc.primaryKey, tc.secondaryKey)
 
 		assert.Equal(t, got1, tc.want1)
 		assert.Equal(t, got2, tc.want2)
@@ -641,6 +928,323 @@ func Test_parseSpannerCondition(t *testing.T) {
 	}
 }
 
+func Test_scanKeys(t *testing.T) {
+	tests := []struct {
+		testName string
+		tPKey    string
+		tSKey    string
+		pKey     string
+		sKey     string
+		want     []string
+	}{
+		{"table without sort key", "id", "", "id", "", []string{"id"}},
+		{"table with sort key", "id", "created", "id", "created", []string{"id", "created"}},
+		{"index", "id", "created", "city", "age", []string{"city", "age", "id", "created"}},
+	}
+
+	for _, tc := range tests {
+		got := scanKeys(tc.tPKey, tc.tSKey, tc.pKey, tc.sKey)
+		assert.Equal(t, got, tc.want)
+	}
+}
+
+func Test_startAfterCondition(t *testing.T) {
+	tests := []struct {
+		testName   string
+		keys       []string
+		startFrom  map[string]interface{}
+		want       string
+		wantParams map[string]interface{}
+		wantErr    bool
+	}{
+		{
+			"no start key",
+			[]string{"id"},
+			nil,
+			"",
+			map[string]interface{}{},
+			false,
+		},
+		{
+			"partition key only, offset ignored",
+			[]string{"id"},
+			map[string]interface{}{"id": float64(3), "offset": float64(3)},
+			"((id > @startKey1))",
+			map[string]interface{}{"startKey1": float64(3)},
+			false,
+		},
+		{
+			"partition and sort key",
+			[]string{"id", "created"},
+			map[string]interface{}{"id": float64(3), "created": "2020"},
+			"((id > @startKey1) OR (id = @startKey1 AND created > @startKey2))",
+			map[string]interface{}{"startKey1": float64(3), "startKey2": "2020"},
+			false,
+		},
+		{
+			"missing sort key",
+			[]string{"id", "created"},
+			map[string]interface{}{"id": float64(3)},
+			"",
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range tests {
+		params := map[string]interface{}{}
+		got, err := startAfterCondition(tc.keys, tc.startFrom, params, false)
+		if tc.wantErr {
+			assert.NotEqual(t, err, nil)
+			continue
+		}
+		assert.Equal(t, err, nil)
+		assert.Equal(t, got, tc.want)
+		assert.Equal(t, params, tc.wantParams)
+	}
+}
+
+func Test_createSpannerQueryForScan(t *testing.T) {
+	query := &models.Query{
+		TableName:            "testTable",
+		Limit:                4,
+		ProjectionExpression: "first",
+		StartFrom:            map[string]interface{}{"first": float64(3), "second": "b", "offset": float64(3)},
+		ScanKeys:             []string{"first", "second"},
+	}
+	stmt, cols, _, offset, _, err := createSpannerQuery(query, "first", "", "first", "")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, stmt.SQL, "SELECT testTable.`first`,testTable.`second` FROM testTable WHERE ((first > @startKey1) OR (first = @startKey1 AND second > @startKey2))  ORDER BY first ASC, second ASC  LIMIT 4")
+	assert.Equal(t, cols, []string{"first", "second"})
+	assert.Equal(t, offset, int64(0))
+}
+
+func Test_queryHints(t *testing.T) {
+	saved := config.DbConfigMap
+	defer func() { config.DbConfigMap = saved }()
+	config.DbConfigMap = map[string]models.TableConfig{
+		"hinted": {
+			PartitionKey:   "first",
+			TableHints:     map[string]string{"FORCE_INDEX": "ByFirst", "GROUPBY_SCAN_OPTIMIZATION": "TRUE", "BAD": "x; DROP TABLE"},
+			StatementHints: map[string]string{"USE_ADDITIONAL_PARALLELISM": "TRUE"},
+		},
+	}
+
+	tests := []struct {
+		testName   string
+		queryModel *models.Query
+		want       string
+	}{
+		{"table hints", &models.Query{TableName: "hinted"}, "hinted@{FORCE_INDEX=ByFirst,GROUPBY_SCAN_OPTIMIZATION=TRUE}"},
+		{"index wins", &models.Query{TableName: "hinted", IndexName: "BySecond"}, "hinted@{FORCE_INDEX=BySecond,GROUPBY_SCAN_OPTIMIZATION=TRUE}"},
+		{"no hints", &models.Query{TableName: "plain"}, "plain"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, parseSpannerTableName(tc.queryModel), tc.want)
+	}
+
+	query := &models.Query{TableName: "hinted", ProjectionExpression: "first", Limit: 2}
+	stmt, _, _, _, _, err := createSpannerQuery(query, "first", "", "first", "")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, stmt.SQL, "@{USE_ADDITIONAL_PARALLELISM=TRUE} SELECT hinted.`first` FROM hinted@{FORCE_INDEX=ByFirst,GROUPBY_SCAN_OPTIMIZATION=TRUE}   ORDER BY first DESC  LIMIT 2")
+
+	query = &models.Query{TableName: "hinted", IndexName: "By First}", ProjectionExpression: "first"}
+	_, _, _, _, _, err = createSpannerQuery(query, "first", "", "first", "")
+	assert.NotEqual(t, err, nil)
+
+	query = &models.Query{TableName: "plain", IndexName: "by-first", ProjectionExpression: "first"}
+	stmt, _, _, _, _, err = createSpannerQuery(query, "first", "", "first", "")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(stmt.SQL, "FROM plain@{FORCE_INDEX=by_first}"), true)
+}
+
+func Test_createSpannerQueryDescendingLimit(t *testing.T) {
+	tests := []struct {
+		testName  string
+		ascending bool
+		want      string
+	}{
+		{
+			"ScanIndexForward false",
+			false,
+			"SELECT testTable.`first`,testTable.`second`,testTable.`third`,testTable.`fourth` FROM testTable WHERE second is not null  AND first = @rangeExp1 ORDER BY second DESC  LIMIT 3",
+		},
+		{
+			"ScanIndexForward true",
+			true,
+			"SELECT testTable.`first`,testTable.`second`,testTable.`third`,testTable.`fourth` FROM testTable WHERE second is not null  AND first = @rangeExp1 ORDER BY second ASC  LIMIT 3",
+		},
+	}
+	for _, tc := range tests {
+		// QueryAttributes reads one item past a Limit of 2 to find the next page,
+		// so Spanner returns the top items in the requested order
+		query := &models.Query{
+			TableName:     "testTable",
+			RangeExp:      "first = :v",
+			RangeValMap:   map[string]interface{}{":v": "a"},
+			SortAscending: tc.ascending,
+			Limit:         3,
+		}
+		stmt, _, _, _, _, err := createSpannerQuery(query, "first", "second", "first", "second")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, stmt.SQL, tc.want)
+	}
+}
+
+func Test_lastEvaluatedKey(t *testing.T) {
+	users := []map[string]interface{}{
+		{"user_id": "u1", "created_at": int64(1), "email": "a@example.com"},
+		{"user_id": "u1", "created_at": int64(2), "email": "b@example.com"},
+		{"user_id": "u1", "created_at": int64(3), "email": "c@example.com"},
+		{"user_id": "u2", "created_at": int64(1), "email": "d@example.com"},
+	}
+	tests := []struct {
+		testName   string
+		query      models.Query
+		last       map[string]interface{}
+		nextOffset int64
+		keys       [4]string
+		want       map[string]interface{}
+	}{
+		{
+			"first page of a composite key table",
+			models.Query{TableName: "users"},
+			users[1], 2,
+			[4]string{"user_id", "created_at", "user_id", "created_at"},
+			map[string]interface{}{"user_id": "u1", "created_at": int64(2), "offset": int64(2)},
+		},
+		{
+			"second page of a composite key table",
+			models.Query{TableName: "users"},
+			users[3], 4,
+			[4]string{"user_id", "created_at", "user_id", "created_at"},
+			map[string]interface{}{"user_id": "u2", "created_at": int64(1), "offset": int64(4)},
+		},
+		{
+			"index without a sort key of a composite key table",
+			models.Query{TableName: "users", IndexName: "by_email"},
+			users[2], 2,
+			[4]string{"user_id", "created_at", "email", ""},
+			map[string]interface{}{"email": "c@example.com", "user_id": "u1", "created_at": int64(3), "offset": int64(2)},
+		},
+		{
+			"scan of a composite key table",
+			models.Query{TableName: "users", ScanKeys: []string{"user_id", "created_at"}},
+			users[2], 2,
+			[4]string{"user_id", "created_at", "user_id", "created_at"},
+			map[string]interface{}{"user_id": "u1", "created_at": int64(3)},
+		},
+	}
+	for _, tc := range tests {
+		got := lastEvaluatedKey(&tc.query, tc.last, tc.nextOffset, tc.keys[0], tc.keys[1], tc.keys[2], tc.keys[3])
+		assert.Equal(t, got, tc.want)
+	}
+
+	// an index projection reads both table keys for the LastEvaluatedKey
+	query := &models.Query{TableName: "testTable", IndexName: "by_third", ProjectionExpression: "fourth"}
+	cols, _, _, err := parseSpannerColumns(query, "first", "second", "third", "")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, cols, []string{"fourth", "third", "first", "second"})
+}
+
+func Test_createSpannerQueryOrder(t *testing.T) {
+	tests := []struct {
+		testName string
+		tPKey    string
+		tSKey    string
+		pKey     string
+		sKey     string
+		want     string
+	}{
+		{"table without sort key", "first", "", "first", "", "SELECT testTable.`first`,testTable.`second`,testTable.`third`,testTable.`fourth` FROM testTable   ORDER BY first DESC  LIMIT 5000 "},
+		{"table with sort key", "first", "second", "first", "second", "SELECT testTable.`first`,testTable.`second`,testTable.`third`,testTable.`fourth` FROM testTable WHERE second is not null  ORDER BY second DESC  LIMIT 5000 "},
+		{"index without sort key", "first", "", "second", "", "SELECT testTable.`first`,testTable.`second`,testTable.`third`,testTable.`fourth` FROM testTable   ORDER BY second DESC , first ASC  LIMIT 5000 "},
+		{"index with sort key", "first", "", "second", "third", "SELECT testTable.`first`,testTable.`second`,testTable.`third`,testTable.`fourth` FROM testTable WHERE third is not null  ORDER BY third DESC , first ASC  LIMIT 5000 "},
+		{"index sharing the table sort key", "first", "third", "second", "third", "SELECT testTable.`first`,testTable.`second`,testTable.`third`,testTable.`fourth` FROM testTable WHERE third is not null  ORDER BY third DESC , first ASC  LIMIT 5000 "},
+		{"index of a table with sort key", "first", "fourth", "second", "third", "SELECT testTable.`first`,testTable.`second`,testTable.`third`,testTable.`fourth` FROM testTable WHERE third is not null  ORDER BY third DESC , first ASC , fourth ASC  LIMIT 5000 "},
+	}
+
+	for _, tc := range tests {
+		query := &models.Query{TableName: "testTable", ProjectionExpression: "first, second, third, fourth"}
+		stmt, _, _, _, _, err := createSpannerQuery(query, tc.tPKey, tc.tSKey, tc.pKey, tc.sKey)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, stmt.SQL, tc.want)
+	}
+}
+
+func Test_createWhereClauseBinary(t *testing.T) {
+	params := map[string]interface{}{}
+	where, _ := createWhereClause("WHERE ", "recipients = :v", "filterExp", map[string]interface{}{":v": []byte{1, 2, 3}}, params)
+	assert.Equal(t, where, "WHERE recipients = @filterExp1")
+	assert.Equal(t, params, map[string]interface{}{"filterExp1": []byte(`"AQID"`)})
+}
+
+func Test_createWhereClauseBool(t *testing.T) {
+	params := map[string]interface{}{}
+	where, _ := createWhereClause("WHERE ", "notification_read = :true", "filterExp", map[string]interface{}{":true": true}, params)
+	assert.Equal(t, where, "WHERE notification_read = @filterExp1")
+	assert.Equal(t, params, map[string]interface{}{"filterExp1": true})
+
+	params = map[string]interface{}{}
+	where, _ = createWhereClause("WHERE ", "notification_read = :true AND kind = :t", "filterExp", map[string]interface{}{":true": true, ":t": "alert"}, params)
+	assert.Equal(t, where, "WHERE notification_read = @filterExp1 AND kind = @filterExp2")
+	assert.Equal(t, params, map[string]interface{}{"filterExp1": true, "filterExp2": "alert"})
+}
+
+func Test_validateQueryFilter(t *testing.T) {
+	tests := []struct {
+		testName  string
+		filterExp string
+		pKey      string
+		sKey      string
+		wantErr   bool
+	}{
+		{"no filter", "", "id", "created", false},
+		{"non key attribute", "age > :v AND begins_with(city, :c)", "id", "created", false},
+		{"partition key", "id = :v", "id", "created", true},
+		{"sort key", "age > :v OR attribute_exists(created)", "id", "created", true},
+		{"nested segment named like a key", "info.created = :v", "id", "created", false},
+		{"attribute with a key prefix", "id_card = :v", "id", "", false},
+	}
+
+	for _, tc := range tests {
+		err := validateQueryFilter(tc.filterExp, tc.pKey, tc.sKey)
+		assert.Equal(t, err != nil, tc.wantErr)
+	}
+}
+
+func Test_validateFilterableAttributes(t *testing.T) {
+	tableConf := models.TableConfig{
+		PartitionKey:         "id",
+		SortKey:              "created",
+		Indices:              map[string]models.TableConfig{"byCity": {PartitionKey: "city"}},
+		FilterableAttributes: []string{"status"},
+	}
+	tests := []struct {
+		testName      string
+		tableConf     models.TableConfig
+		filterExp     string
+		allowFullScan bool
+		wantErr       bool
+	}{
+		{"no FilterableAttributes", models.TableConfig{PartitionKey: "id"}, "age > :v", false, false},
+		{"no filter", tableConf, "", false, false},
+		{"filterable attribute", tableConf, "status = :s AND NOT begins_with(city, :c)", false, false},
+		{"keys and functions", tableConf, "attribute_exists(id) OR size(created) > :n", false, false},
+		{"other attribute", tableConf, "status = :s AND age BETWEEN :a AND :b", false, true},
+		{"nested segment", tableConf, "status.age = :v", false, false},
+		{"AllowFullScan", tableConf, "age > :v", true, false},
+		{"legacy NULL condition", tableConf, "status IS NULL", false, false},
+		{"legacy NOT_NULL and BEGINS_WITH conditions", tableConf, "status IS NOT NULL AND STARTS_WITH(city, :c)", false, false},
+		{"legacy NULL condition on other attribute", tableConf, "age IS NULL", false, true},
+	}
+
+	for _, tc := range tests {
+		err := validateFilterableAttributes(tc.tableConf, tc.filterExp, tc.allowFullScan)
+		assert.Equal(t, err != nil, tc.wantErr)
+	}
+}
+
 func Test_parseOffset(t *testing.T) {
 	tests := []struct {
 		testName   string
@@ -693,12 +1297,22 @@ func Test_parseSpannerSorting(t *testing.T) {
 		want         string
 	}{
 		{
-			"empty Query & skey",
+			"no sort key",
 			&models.Query{},
 			false,
+			"first",
 			"",
+			" ORDER BY first DESC ",
+		},
+		{
+			"no sort key and ScanIndexForward",
+			&models.Query{
+				SortAscending: true,
+			},
+			false,
+			"first",
 			"",
-			" ",
+			" ORDER BY first ASC ",
 		},
 		{
 			"empty Query but skey present",
@@ -780,3 +1394,302 @@ func Test_parseLimit(t *testing.T) {
 	}
 
 }
+
+func Test_queryKeys(t *testing.T) {
+	tableConf := models.TableConfig{
+		PartitionKey: "first",
+		SortKey:      "second",
+		ActualTable:  "testTable",
+		Indices: map[string]models.TableConfig{
+			"third-index": {PartitionKey: "third", SortKey: "fourth"},
+		},
+	}
+	tests := []struct {
+		testName  string
+		query     models.Query
+		wantKeys  []string
+		wantIndex string
+	}{
+		{
+			"without index",
+			models.Query{TableName: "testTable"},
+			[]string{"first", "second", "first", "second"},
+			"",
+		},
+		{
+			"with index",
+			models.Query{TableName: "testTable", IndexName: "third-index"},
+			[]string{"first", "second", "third", "fourth"},
+			"third_index",
+		},
+		{
+			"with unknown index",
+			models.Query{TableName: "testTable", IndexName: "unknown"},
+			[]string{"first", "second", "first", "second"},
+			"unknown",
+		},
+	}
+
+	for _, tc := range tests {
+		tPKey, tSKey, pKey, sKey := queryKeys(&tc.query, tableConf)
+		assert.Equal(t, []string{tPKey, tSKey, pKey, sKey}, tc.wantKeys)
+		assert.Equal(t, tc.query.IndexName, tc.wantIndex)
+	}
+}
+
+func Test_sameVersion(t *testing.T) {
+	tests := []struct {
+		testName string
+		stored   interface{}
+		version  interface{}
+		want     bool
+	}{
+		{"int64 and float64", int64(3), float64(3), true},
+		{"different numbers", int64(3), float64(4), false},
+		{"version of a million", int64(1000000), float64(1e6), true},
+		{"large versions", int64(123456789012), float64(123456789013), false},
+		{"strings", "v1", "v1", true},
+		{"missing version", nil, float64(3), false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, sameVersion(tc.stored, tc.version), tc.want)
+	}
+}
+
+func TestSegmentForKey(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{
+		"testTable": {PartitionKey: "first", ActualTable: "testTable"},
+	}
+	tests := []struct {
+		testName      string
+		keyMap        map[string]interface{}
+		totalSegments int64
+		want          int64
+		wantErr       bool
+	}{
+		{"single segment", map[string]interface{}{"first": "abc"}, 1, 0, false},
+		{"stable hash", map[string]interface{}{"first": "abc"}, 4, 3, false},
+		{"zero segments", map[string]interface{}{"first": "abc"}, 0, 0, true},
+		{"missing partition key", map[string]interface{}{"second": "abc"}, 4, 0, true},
+	}
+	for _, tc := range tests {
+		got, err := SegmentForKey("testTable", tc.keyMap, tc.totalSegments)
+		assert.Equal(t, err != nil, tc.wantErr)
+		assert.Equal(t, got, tc.want)
+	}
+	for _, n := range []int64{7, 1000000, 123456789012} {
+		assert.Equal(t, segmentOf(float64(n), 16), segmentOf(n, 16))
+	}
+}
+
+func TestScanSegments(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{
+		"testTable": {PartitionKey: "first", ActualTable: "testTable"},
+	}
+	items := []map[string]interface{}{}
+	for i := 0; i < 50; i++ {
+		items = append(items, map[string]interface{}{"first": "key" + strconv.Itoa(i)})
+		items = append(items, map[string]interface{}{"first": float64(i) + 0.5})
+		items = append(items, map[string]interface{}{"first": int64(i)})
+	}
+	for _, totalSegments := range []int64{1, 3, 7} {
+		returnedBy := map[string][]int64{}
+		for segment := int64(0); segment < totalSegments; segment++ {
+			for _, item := range segmentItems(items, "first", segment, totalSegments) {
+				key := fmt.Sprint(item["first"])
+				returnedBy[key] = append(returnedBy[key], segment)
+			}
+		}
+		for _, item := range items {
+			want, err := SegmentForKey("testTable", item, totalSegments)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, returnedBy[fmt.Sprint(item["first"])], []int64{want})
+		}
+	}
+}
+
+func Test_validateSegment(t *testing.T) {
+	tests := []struct {
+		testName string
+		scanData models.ScanMeta
+		wantErr  bool
+	}{
+		{"no segments", models.ScanMeta{}, false},
+		{"segment", models.ScanMeta{Segment: 2, TotalSegments: 4}, false},
+		{"segment without total", models.ScanMeta{Segment: 2}, true},
+		{"segment out of range", models.ScanMeta{Segment: 4, TotalSegments: 4}, true},
+		{"negative segment", models.ScanMeta{Segment: -1, TotalSegments: 4}, true},
+		{"too many segments", models.ScanMeta{TotalSegments: maxTotalSegments + 1}, true},
+		{"count", models.ScanMeta{TotalSegments: 4, OnlyCount: true}, true},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, validateSegment(tc.scanData) != nil, tc.wantErr)
+	}
+}
+
+func Test_validateKeyCondition(t *testing.T) {
+	tests := []struct {
+		testName string
+		rangeExp string
+		pKey     string
+		sKey     string
+		wantErr  bool
+	}{
+		{"no key condition", "", "emp_id", "", false},
+		{"partition key only table", "emp_id = :v", "emp_id", "", false},
+		{"sort key on a partition key only table", "emp_id = :v AND age > :a", "emp_id", "", true},
+		{"sort key condition", "emp_id = :v AND begins_with(name, :n)", "emp_id", "name", false},
+		{"between on sort key", "emp_id = :v AND name BETWEEN :a AND :b", "emp_id", "name", false},
+		{"missing partition key", "name = :n", "emp_id", "name", true},
+		{"non key attribute", "emp_id = :v AND age = :a", "emp_id", "name", true},
+	}
+	for _, tc := range tests {
+		err := validateKeyCondition(tc.rangeExp, tc.pKey, tc.sKey)
+		assert.Equal(t, err != nil, tc.wantErr)
+	}
+}
+
+func Test_queryPage(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"emp_id": int64(1), "age": int64(10)},
+		{"emp_id": int64(2), "age": int64(20)},
+		{"emp_id": int64(3), "age": int64(30)},
+	}
+	scan := &models.Query{ScanKeys: []string{"emp_id"}}
+	tests := []struct {
+		testName string
+		query    *models.Query
+		resp     []map[string]interface{}
+		limit    int64
+		want     map[string]interface{}
+	}{
+		{
+			"limit equals the remaining items",
+			scan, rows, 3,
+			map[string]interface{}{"Count": 3, "Items": rows, "LastEvaluatedKey": nil},
+		},
+		{
+			"limit below the remaining items",
+			scan, rows, 2,
+			map[string]interface{}{"Count": 2, "Items": rows[:2], "LastEvaluatedKey": map[string]interface{}{"emp_id": int64(2)}},
+		},
+		{
+			"query continues at the offset",
+			&models.Query{}, rows, 2,
+			map[string]interface{}{"Count": 2, "Items": rows[:2], "LastEvaluatedKey": map[string]interface{}{"emp_id": int64(2), "offset": int64(7)}},
+		},
+		{
+			"no items",
+			scan, nil, 3,
+			map[string]interface{}{"Count": 0, "Items": []map[string]interface{}{}, "LastEvaluatedKey": nil},
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, queryPage(tc.query, tc.resp, tc.limit, 5, "emp_id", "", "emp_id", ""), tc.want)
+	}
+}
+
+func Test_keyBatches(t *testing.T) {
+	items := []map[string]interface{}{
+		{"customer_id": "c1", "sk": "ORDER#1", "total": int64(10)},
+		{"customer_id": "c1", "sk": "ORDER#2", "total": int64(20)},
+		{"customer_id": "c2", "sk": "PROFILE", "name": "Marc"},
+	}
+	assert.Equal(t, keyBatches(items, "customer_id", "sk", 2), [][]map[string]interface{}{
+		{{"customer_id": "c1", "sk": "ORDER#1"}, {"customer_id": "c1", "sk": "ORDER#2"}},
+		{{"customer_id": "c2", "sk": "PROFILE"}},
+	})
+	assert.Equal(t, keyBatches(items[2:], "customer_id", "", 2), [][]map[string]interface{}{
+		{{"customer_id": "c2"}},
+	})
+	assert.Equal(t, keyBatches(nil, "customer_id", "sk", 2), [][]map[string]interface{}{})
+}
+
+func Test_deletedImages(t *testing.T) {
+	tableConf := models.TableConfig{PartitionKey: "customer_id", SortKey: "sk"}
+	keys := []map[string]interface{}{
+		{"customer_id": "c1", "sk": "ORDER#1"},
+		{"customer_id": "c1", "sk": "ORDER#2"},
+		{"customer_id": "c2", "sk": "PROFILE"},
+	}
+	oldRes := []map[string]interface{}{
+		{"customer_id": "c2", "sk": "PROFILE", "name": "Marc"},
+		{"customer_id": "c1", "sk": "ORDER#1", "total": int64(10)},
+		{"customer_id": "c1", "sk": "ORDER#2", "total": int64(20)},
+	}
+	errs := []error{nil, errors.New("ConditionalCheckFailedException"), nil}
+	assert.Equal(t, deletedImages(tableConf, oldRes, keys, errs), []map[string]interface{}{oldRes[1], oldRes[0]})
+	assert.Equal(t, deletedImages(tableConf, nil, keys, errs), []map[string]interface{}{})
+}
+
+func Test_replaceAttributeNames(t *testing.T) {
+	tests := []struct {
+		testName   string
+		expression string
+		names      map[string]string
+		want       string
+	}{
+		{"no names", "age > :a", nil, "age > :a"},
+		{"prefix of another name", "#st = :a AND #status = :b", map[string]string{"#st": "street", "#status": "state"}, "street = :a AND state = :b"},
+		{"unknown placeholder", "#st = :a AND #stx = :b", map[string]string{"#st": "street"}, "street = :a AND #stx = :b"},
+	}
+	for _, tc := range tests {
+		// the names are replaced in a random order of the map
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, replaceAttributeNames(tc.expression, tc.names), tc.want)
+		}
+	}
+}
+
+func Test_countDeleted(t *testing.T) {
+	conditionFailed := errors.New("ConditionalCheckFailedException")
+	aborted := errors.New("Aborted")
+	tests := []struct {
+		testName string
+		errs     []error
+		want     int
+		wantErr  error
+	}{
+		{"all deleted", []error{nil, nil}, 2, nil},
+		{"changed since the scan", []error{nil, conditionFailed, nil}, 2, nil},
+		{"failed transaction", []error{nil, aborted, aborted}, 1, aborted},
+	}
+	for _, tc := range tests {
+		deleted, err := countDeleted(tc.errs)
+		assert.Equal(t, deleted, tc.want)
+		assert.Equal(t, err, tc.wantErr)
+	}
+}
+
+func Test_applyDefaults(t *testing.T) {
+	tableConf := models.TableConfig{Defaults: map[string]interface{}{"status": "ACTIVE", "retries": float64(0)}}
+	tests := []struct {
+		testName string
+		item     map[string]interface{}
+		want     map[string]interface{}
+	}{
+		{
+			"absent attributes",
+			map[string]interface{}{"id": "u1"},
+			map[string]interface{}{"id": "u1", "status": "ACTIVE", "retries": float64(0)},
+		},
+		{
+			"provided attribute",
+			map[string]interface{}{"id": "u1", "status": "BLOCKED"},
+			map[string]interface{}{"id": "u1", "status": "BLOCKED", "retries": float64(0)},
+		},
+		{
+			"explicit NULL",
+			map[string]interface{}{"id": "u1", "status": nil},
+			map[string]interface{}{"id": "u1", "status": nil, "retries": float64(0)},
+		},
+	}
+	for _, tc := range tests {
+		applyDefaults(tableConf, tc.item)
+		assert.Equal(t, tc.item, tc.want)
+	}
+
+	item := map[string]interface{}{"id": "u1"}
+	applyDefaults(models.TableConfig{}, item)
+	assert.Equal(t, item, map[string]interface{}{"id": "u1"})
+}
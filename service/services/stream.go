@@ -23,6 +23,7 @@ import (
 	"cloud.google.com/go/pubsub"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
 	uuid "github.com/satori/go.uuid"
 )
@@ -31,6 +32,15 @@ var pubsubClient *pubsub.Client
 var mClients = map[string]*pubsub.Topic{}
 var mux = &sync.Mutex{}
 
+// pendingPublishes tracks the publishes StopStream waits for
+var pendingPublishes sync.WaitGroup
+
+// streamStopped is set by StopStream, after which no publish is started
+var streamStopped bool
+
+const defaultPublishTimeout = 10 * time.Second
+const publishBackoff = 100 * time.Millisecond
+
 // InitStream for initializing the stream
 func InitStream() {
 	var err error
@@ -82,30 +92,139 @@ func StreamDataToThirdParty(oldImage, newImage map[string]interface{}, tableName
 }
 
 func connectors(streamObj *models.StreamDataModel) {
-	go pubsubPublish(streamObj)
+	topic, message, ok := publishMessage(streamObj)
+	if !ok {
+		return
+	}
+	if !startPublish() {
+		logger.LogError(errors.New("StreamStopped", "stream is stopped, dropping event"), topic.ID(), streamObj.EventID)
+		return
+	}
+	// the writes call StreamDataToThirdParty on their own goroutine once
+	// committed, so the message is queued here in the order of the commits
+	result := topic.Publish(context.Background(), message)
+	go func() {
+		defer pendingPublishes.Done()
+		pubsubPublish(topic, message, result, streamObj)
+	}()
 }
 
-func pubsubPublish(streamObj *models.StreamDataModel) {
-	var err error
+// startPublish adds a pending publish unless StopStream has begun
+func startPublish() bool {
+	mux.Lock()
+	defer mux.Unlock()
+	if streamStopped {
+		return false
+	}
+	pendingPublishes.Add(1)
+	return true
+}
+
+// publishMessage returns the topic of the table and the message for streamObj
+func publishMessage(streamObj *models.StreamDataModel) (*pubsub.Topic, *pubsub.Message, bool) {
 	topicName, status := IsPubSubAllowed(streamObj.Table)
 	if !status {
-		return
+		return nil, nil, false
 	}
 	mux.Lock()
-	defer mux.Unlock()
 	topic, ok := mClients[topicName]
 	if !ok {
 		topic = pubsubClient.
 			TopicInProject(topicName, config.ConfigurationMap.GoogleProjectID)
+		topic.EnableMessageOrdering = config.ConfigurationMap.PubSubMessageOrdering
 		mClients[topicName] = topic
 	}
+	mux.Unlock()
 	message := &pubsub.Message{}
+	var err error
 	message.Data, err = json.Marshal(streamObj)
 	if err != nil {
 		logger.LogError(err)
+		return nil, nil, false
+	}
+	if topic.EnableMessageOrdering {
+		message.OrderingKey = orderingKey(streamObj)
 	}
-	_, err = topic.Publish(context.Background(), message).Get(ctx)
+	return topic, message, true
+}
+
+// orderingKey is the partition key of the streamed item, which orders the
+// messages of an item
+func orderingKey(streamObj *models.StreamDataModel) string {
+	tableConf, err := config.GetTableConf(streamObj.Table)
 	if err != nil {
-		logger.LogError(err)
+		return ""
+	}
+	v, ok := streamObj.Keys[tableConf.PartitionKey]
+	if !ok || v == nil {
+		return ""
+	}
+	return keyString(v)
+}
+
+// pubsubPublish waits for the queued result and retries the failed publish.
+// A message with an ordering key is not retried: Pub/Sub pauses the key on the
+// failure and fails the messages queued after it too, which keeps them from
+// being published out of order. The key is resumed once the failure is logged,
+// so the later events of the item are published again.
+func pubsubPublish(topic *pubsub.Topic, message *pubsub.Message, result *pubsub.PublishResult, streamObj *models.StreamDataModel) {
+	var err error
+	timeout := publishTimeout()
+	attempts := config.ConfigurationMap.PubSubPublishRetries + 1
+	if message.OrderingKey != "" {
+		attempts = 1
+	}
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(publishBackoff << uint(i-1))
+			result = topic.Publish(context.Background(), message)
+		}
+		publishCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		_, err = result.Get(publishCtx)
+		cancel()
+		if err == nil {
+			return
+		}
+	}
+	if message.OrderingKey != "" {
+		logger.LogError(err, topic.ID(), streamObj.EventID, "publishing of ordering key "+message.OrderingKey+" failed")
+		topic.ResumePublish(message.OrderingKey)
+		return
+	}
+	logger.LogError(err, topic.ID(), streamObj.EventID)
+}
+
+// publishTimeout is the PubSubPublishTimeout to wait for each publish attempt
+func publishTimeout() time.Duration {
+	timeout, err := time.ParseDuration(config.ConfigurationMap.PubSubPublishTimeout)
+	if err != nil || timeout <= 0 {
+		return defaultPublishTimeout
+	}
+	return timeout
+}
+
+// StopStream stops accepting publishes, waits up to timeout for the pending
+// ones and then flushes and stops every topic, so that shutdown does not hang
+// on Pub/Sub
+func StopStream(timeout time.Duration) error {
+	mux.Lock()
+	streamStopped = true
+	mux.Unlock()
+	done := make(chan struct{})
+	go func() {
+		pendingPublishes.Wait()
+		close(done)
+	}()
+	var err error
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		err = errors.New("DeadlineExceeded", "pending stream publishes were not drained")
+	}
+	mux.Lock()
+	defer mux.Unlock()
+	for _, topic := range mClients {
+		topic.Stop()
 	}
+	return err
 }
@@ -0,0 +1,80 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_publishTimeout(t *testing.T) {
+	defer func() { config.ConfigurationMap.PubSubPublishTimeout = "" }()
+	tests := []struct {
+		testName string
+		timeout  string
+		want     time.Duration
+	}{
+		{"default", "", defaultPublishTimeout},
+		{"configured", "3s", 3 * time.Second},
+		{"invalid", "soon", defaultPublishTimeout},
+	}
+	for _, tc := range tests {
+		config.ConfigurationMap.PubSubPublishTimeout = tc.timeout
+		assert.Equal(t, publishTimeout(), tc.want)
+	}
+}
+
+func TestStopStream(t *testing.T) {
+	defer func() { streamStopped = false }()
+	assert.Equal(t, startPublish(), true)
+	pendingPublishes.Done()
+	assert.Equal(t, StopStream(time.Second), nil)
+	assert.Equal(t, startPublish(), false)
+
+	release := make(chan struct{})
+	pendingPublishes.Add(1)
+	go func() {
+		<-release
+		pendingPublishes.Done()
+	}()
+	assert.NotEqual(t, StopStream(10*time.Millisecond), nil)
+	close(release)
+	assert.Equal(t, StopStream(time.Second), nil)
+}
+
+func Test_orderingKey(t *testing.T) {
+	config.DbConfigMap = map[string]models.TableConfig{
+		"employee": {PartitionKey: "emp_id", SortKey: "age"},
+	}
+	tests := []struct {
+		testName string
+		keys     map[string]interface{}
+		want     string
+	}{
+		{"number partition key", map[string]interface{}{"emp_id": float64(1), "age": float64(10)}, "1"},
+		{"large number partition key", map[string]interface{}{"emp_id": float64(1e6)}, "1000000"},
+		{"number partition key read from spanner", map[string]interface{}{"emp_id": int64(1000000)}, "1000000"},
+		{"string partition key", map[string]interface{}{"emp_id": "e1"}, "e1"},
+		{"missing partition key", map[string]interface{}{"age": float64(10)}, ""},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, orderingKey(&models.StreamDataModel{Table: "employee", Keys: tc.keys}), tc.want)
+	}
+	assert.Equal(t, orderingKey(&models.StreamDataModel{Table: "unknown", Keys: map[string]interface{}{"emp_id": "e1"}}), "")
+}
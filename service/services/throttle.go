@@ -0,0 +1,113 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+)
+
+// burstSeconds is how many seconds of unused capacity a table keeps for
+// bursts, like the 5 minutes of DynamoDB
+const burstSeconds = 300
+
+// tokenBucket holds the capacity units a table may still consume. It is
+// refilled at the provisioned rate up to burstSeconds of it.
+type tokenBucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full bucket for the rate
+func newTokenBucket(rate float64, now time.Time) *tokenBucket {
+	return &tokenBucket{rate: rate, tokens: rate * burstSeconds, last: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.rate*burstSeconds, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// available reports if the bucket has units left at now
+func (b *tokenBucket) available(now time.Time) bool {
+	b.refill(now)
+	return b.tokens > 0
+}
+
+// take consumes the units of an operation which is done. The bucket may go
+// below empty, the next operations are throttled until it is refilled.
+func (b *tokenBucket) take(units float64, now time.Time) {
+	b.refill(now)
+	b.tokens -= units
+}
+
+var (
+	bucketsMu sync.Mutex
+	buckets   = map[string]*tokenBucket{}
+)
+
+// capacityBucket returns the read or write bucket of the table at now, or nil
+// when the table has no such capacity in dynamodb_adapter_config_manager
+func capacityBucket(table string, write bool, now time.Time) *tokenBucket {
+	models.ConfigController.Mux.RLock()
+	rates := models.ConfigController.ReadCapacity
+	key := table + "/read"
+	if write {
+		rates = models.ConfigController.WriteCapacity
+		key = table + "/write"
+	}
+	rate := rates[table]
+	models.ConfigController.Mux.RUnlock()
+	if rate <= 0 {
+		return nil
+	}
+	b, ok := buckets[key]
+	if !ok || b.rate != rate {
+		b = newTokenBucket(rate, now)
+		buckets[key] = b
+	}
+	return b
+}
+
+// CheckCapacity returns a ProvisionedThroughputExceededException when the
+// read or write capacity of the table is used up
+func CheckCapacity(table string, write bool) error {
+	bucketsMu.Lock()
+	defer bucketsMu.Unlock()
+	now := time.Now()
+	b := capacityBucket(table, write, now)
+	if b == nil || b.available(now) {
+		return nil
+	}
+	return errors.New("ProvisionedThroughputExceededException", "The level of configured provisioned throughput for the table was exceeded:", table)
+}
+
+// ConsumeCapacity accounts the estimated capacity units of an operation on
+// the table against its read or write capacity
+func ConsumeCapacity(table string, write bool, units float64) {
+	bucketsMu.Lock()
+	defer bucketsMu.Unlock()
+	now := time.Now()
+	if b := capacityBucket(table, write, now); b != nil {
+		b.take(units, now)
+	}
+}
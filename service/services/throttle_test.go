@@ -0,0 +1,63 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	b := newTokenBucket(10, now)
+	assert.Equal(t, b.tokens, float64(10*burstSeconds))
+
+	// a burst can use up the saved capacity and overdraw it
+	b.take(10*burstSeconds+5, now)
+	assert.Equal(t, b.available(now), false)
+	assert.Equal(t, b.available(now.Add(500*time.Millisecond)), false)
+	assert.Equal(t, b.available(now.Add(time.Second)), true)
+	assert.Equal(t, b.tokens, float64(5))
+
+	// unused capacity is saved up to burstSeconds of it
+	b.refill(now.Add(time.Hour))
+	assert.Equal(t, b.tokens, float64(10*burstSeconds))
+}
+
+func TestCheckCapacity(t *testing.T) {
+	defer func() {
+		models.ConfigController.ReadCapacity = map[string]float64{}
+		models.ConfigController.WriteCapacity = map[string]float64{}
+		buckets = map[string]*tokenBucket{}
+	}()
+	parseConfig("provisioned", "1,1,,,1,2", 0)
+	parseConfig("on_demand", "1,1,,0", 0)
+	assert.Equal(t, models.ConfigController.ReadCapacity["provisioned"], float64(1))
+	assert.Equal(t, models.ConfigController.WriteCapacity["provisioned"], float64(2))
+
+	assert.Equal(t, CheckCapacity("provisioned", false), nil)
+	ConsumeCapacity("provisioned", false, 1000)
+	err := CheckCapacity("provisioned", false)
+	assert.NotEqual(t, err, nil)
+	assert.Equal(t, err.Error(), "ProvisionedThroughputExceededException")
+	// the write capacity is accounted separately
+	assert.Equal(t, CheckCapacity("provisioned", true), nil)
+
+	ConsumeCapacity("on_demand", true, 1000)
+	assert.Equal(t, CheckCapacity("on_demand", true), nil)
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/storage"
+)
+
+// supportedSpannerTypes are the column types which are converted to DynamoDB attributes
+var supportedSpannerTypes = map[string]bool{
+	"STRING(MAX)": true,
+	"BYTES(MAX)":  true,
+	"INT64":       true,
+	"FLOAT64":     true,
+	"BOOL":        true,
+	"JSON":        true,
+}
+
+// ValidateTable checks that the Spanner table, its dynamodb_adapter_table_ddl
+// rows and the key schema of the table config agree with each other
+func ValidateTable(ctx context.Context, tableName string) (models.TableValidation, error) {
+	tableConf, err := config.GetTableConf(tableName)
+	if err != nil {
+		return models.TableValidation{}, err
+	}
+	spannerTable := changeTableNameForSP(tableConf.ActualTable)
+	report := models.TableValidation{TableName: tableName, SpannerTable: spannerTable}
+	if models.SpannerTableMap[spannerTable] == "" {
+		report.Problems = []string{"no Spanner instance is configured for table " + spannerTable}
+		return report, nil
+	}
+	spannerCols, err := storage.GetStorageInstance().SpannerTableColumns(ctx, spannerTable)
+	if err != nil {
+		return models.TableValidation{}, err
+	}
+	report.Problems = append(tableProblems(tableConf, spannerTable, spannerCols, models.TableDDL[spannerTable]), interleavedProblems(tableConf)...)
+	report.Valid = len(report.Problems) == 0
+	return report, nil
+}
+
+// tableProblems compares the Spanner columns of a table with its
+// dynamodb_adapter_table_ddl rows and its key schema
+func tableProblems(tableConf models.TableConfig, spannerTable string, spannerCols, ddl map[string]string) []string {
+	problems := []string{}
+	if len(spannerCols) == 0 {
+		return append(problems, "table "+spannerTable+" does not exist in Spanner")
+	}
+	for _, col := range sortedKeys(spannerCols) {
+		// like the reads, leave out the commit timestamp, which is no attribute
+		if col == "commit_timestamp" {
+			continue
+		}
+		spannerType := spannerCols[col]
+		if !supportedSpannerTypes[spannerType] {
+			problems = append(problems, "column "+col+" has the unsupported Spanner type "+spannerType)
+		}
+		ddlType, ok := ddl[col]
+		if !ok {
+			problems = append(problems, "column "+col+" has no row in dynamodb_adapter_table_ddl")
+		} else if ddlType != spannerType {
+			problems = append(problems, "column "+col+" is "+ddlType+" in dynamodb_adapter_table_ddl but "+spannerType+" in Spanner")
+		}
+	}
+	for _, col := range sortedKeys(ddl) {
+		if _, ok := spannerCols[col]; !ok {
+			problems = append(problems, "column "+col+" of dynamodb_adapter_table_ddl does not exist in Spanner")
+		}
+	}
+
+	if tableConf.PartitionKey == "" {
+		problems = append(problems, "the table config has no partitionKey")
+	}
+	keys := [][2]string{{"partitionKey", tableConf.PartitionKey}, {"sortKey", tableConf.SortKey}}
+	for _, indexName := range sortedIndexNames(tableConf.Indices) {
+		index := tableConf.Indices[indexName]
+		keys = append(keys, [2]string{"partitionKey of index " + indexName, index.PartitionKey}, [2]string{"sortKey of index " + indexName, index.SortKey})
+	}
+	for _, key := range keys {
+		if key[1] == "" {
+			continue
+		}
+		col := key[1]
+		if spannerCol, ok := models.ColumnToOriginalCol[col]; ok {
+			col = spannerCol
+		}
+		if _, ok := spannerCols[col]; !ok {
+			problems = append(problems, "the "+key[0]+" "+key[1]+" is not a column of "+spannerTable)
+		}
+	}
+	if tableConf.OverflowColumn != "" {
+		if spannerType, ok := spannerCols[tableConf.OverflowColumn]; !ok {
+			problems = append(problems, "the OverflowColumn "+tableConf.OverflowColumn+" is not a column of "+spannerTable)
+		} else if spannerType != "BYTES(MAX)" {
+			problems = append(problems, "the OverflowColumn "+tableConf.OverflowColumn+" is "+spannerType+", it has to be BYTES(MAX)")
+		}
+	}
+	for _, attr := range tableConf.ExcludeByDefault {
+		col := attr
+		if spannerCol, ok := models.ColumnToOriginalCol[col]; ok {
+			col = spannerCol
+		}
+		if _, ok := spannerCols[col]; !ok {
+			problems = append(problems, "the ExcludeByDefault column "+attr+" is not a column of "+spannerTable)
+		}
+		for _, key := range keys {
+			if key[1] == attr {
+				problems = append(problems, "the ExcludeByDefault column "+attr+" is the "+key[0])
+			}
+		}
+	}
+	for _, indexName := range sortedIndexNames(tableConf.Indices) {
+		index := tableConf.Indices[indexName]
+		switch index.ProjectionType {
+		case "", "ALL", "KEYS_ONLY", "INCLUDE":
+		default:
+			problems = append(problems, "the ProjectionType "+index.ProjectionType+" of index "+indexName+" is not ALL, KEYS_ONLY or INCLUDE")
+		}
+		for _, attr := range index.NonKeyAttributes {
+			if _, ok := spannerCols[attr]; !ok {
+				problems = append(problems, "the NonKeyAttributes column "+attr+" of index "+indexName+" is not a column of "+spannerTable)
+			}
+		}
+	}
+	return problems
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedIndexNames(indices map[string]models.TableConfig) []string {
+	names := make([]string, 0, len(indices))
+	for k := range indices {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
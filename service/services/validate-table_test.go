@@ -0,0 +1,120 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"testing"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func Test_tableProblems(t *testing.T) {
+	tableConf := models.TableConfig{
+		PartitionKey: "emp_id",
+		SortKey:      "name",
+		Indices: map[string]models.TableConfig{
+			"age-index": {PartitionKey: "age"},
+		},
+	}
+	ddl := map[string]string{"emp_id": "FLOAT64", "name": "STRING(MAX)", "age": "FLOAT64"}
+	tests := []struct {
+		testName    string
+		tableConf   models.TableConfig
+		spannerCols map[string]string
+		ddl         map[string]string
+		want        []string
+	}{
+		{
+			"valid table",
+			tableConf,
+			map[string]string{"emp_id": "FLOAT64", "name": "STRING(MAX)", "age": "FLOAT64"},
+			ddl,
+			[]string{},
+		},
+		{
+			"commit timestamp",
+			tableConf,
+			map[string]string{"emp_id": "FLOAT64", "name": "STRING(MAX)", "age": "FLOAT64", "commit_timestamp": "TIMESTAMP"},
+			ddl,
+			[]string{},
+		},
+		{
+			"missing table",
+			tableConf,
+			map[string]string{},
+			ddl,
+			[]string{"table employee does not exist in Spanner"},
+		},
+		{
+			"column problems",
+			tableConf,
+			map[string]string{"emp_id": "FLOAT64", "name": "STRING(100)", "age": "FLOAT64", "joined": "TIMESTAMP"},
+			ddl,
+			[]string{
+				"column joined has the unsupported Spanner type TIMESTAMP",
+				"column joined has no row in dynamodb_adapter_table_ddl",
+				"column name has the unsupported Spanner type STRING(100)",
+				"column name is STRING(MAX) in dynamodb_adapter_table_ddl but STRING(100) in Spanner",
+			},
+		},
+		{
+			"ddl row without column",
+			tableConf,
+			map[string]string{"emp_id": "FLOAT64", "name": "STRING(MAX)"},
+			ddl,
+			[]string{
+				"column age of dynamodb_adapter_table_ddl does not exist in Spanner",
+				"the partitionKey of index age-index age is not a column of employee",
+			},
+		},
+		{
+			"missing partition key",
+			models.TableConfig{},
+			map[string]string{"emp_id": "FLOAT64"},
+			map[string]string{"emp_id": "FLOAT64"},
+			[]string{"the table config has no partitionKey"},
+		},
+		{
+			"overflow column of the wrong type",
+			models.TableConfig{PartitionKey: "emp_id", OverflowColumn: "extra"},
+			map[string]string{"emp_id": "FLOAT64", "extra": "STRING(MAX)"},
+			map[string]string{"emp_id": "FLOAT64", "extra": "STRING(MAX)"},
+			[]string{"the OverflowColumn extra is STRING(MAX), it has to be BYTES(MAX)"},
+		},
+		{
+			"columns excluded by default",
+			models.TableConfig{PartitionKey: "emp_id", ExcludeByDefault: []string{"emp_id", "resume", "photo"}},
+			map[string]string{"emp_id": "FLOAT64", "resume": "BYTES(MAX)"},
+			map[string]string{"emp_id": "FLOAT64", "resume": "BYTES(MAX)"},
+			[]string{"the ExcludeByDefault column emp_id is the partitionKey", "the ExcludeByDefault column photo is not a column of employee"},
+		},
+		{
+			"index projection",
+			models.TableConfig{PartitionKey: "emp_id", Indices: map[string]models.TableConfig{
+				"by_age": {PartitionKey: "age", ProjectionType: "INCLUDE", NonKeyAttributes: []string{"address", "photo"}},
+				"by_zip": {PartitionKey: "age", ProjectionType: "SOME"},
+			}},
+			map[string]string{"emp_id": "FLOAT64", "age": "FLOAT64", "address": "STRING(MAX)"},
+			map[string]string{"emp_id": "FLOAT64", "age": "FLOAT64", "address": "STRING(MAX)"},
+			[]string{"the NonKeyAttributes column photo of index by_age is not a column of employee", "the ProjectionType SOME of index by_zip is not ALL, KEYS_ONLY or INCLUDE"},
+		},
+	}
+
+	for _, tc := range tests {
+		got := tableProblems(tc.tableConf, "employee", tc.spannerCols, tc.ddl)
+		assert.Equal(t, got, tc.want)
+	}
+}
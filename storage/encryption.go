@@ -0,0 +1,286 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
+	"google.golang.org/api/option"
+	gtransport "google.golang.org/api/transport/grpc"
+	kmspb "google.golang.org/genproto/googleapis/cloud/kms/v1"
+)
+
+const kmsEndpoint = "cloudkms.googleapis.com:443"
+
+// dataKey encrypts the values of the EncryptedAttributes
+var dataKey cipher.AEAD
+
+// encryptedColumns holds the encrypted spanner columns of every spanner table
+var encryptedColumns = map[string]map[string]bool{}
+
+// encryptedKeys holds the key columns of the spanner tables with encrypted
+// columns, whose values are bound to the ciphertexts of their row
+var encryptedKeys = map[string][]string{}
+
+// initEncryption unwraps the data key with KMS when any table has EncryptedAttributes
+func initEncryption() {
+	encryptedColumns = map[string]map[string]bool{}
+	encryptedKeys = map[string][]string{}
+	for tableName := range config.DbConfigMap {
+		tableConf, err := config.GetTableConf(tableName)
+		if err != nil || len(tableConf.EncryptedAttributes) == 0 {
+			continue
+		}
+		cols := map[string]bool{}
+		for _, col := range tableConf.EncryptedAttributes {
+			cols[col] = true
+		}
+		table := changeTableNameForSP(tableConf.ActualTable)
+		encryptedColumns[table] = cols
+		encryptedKeys[table] = []string{tableConf.PartitionKey}
+		if tableConf.SortKey != "" {
+			encryptedKeys[table] = append(encryptedKeys[table], tableConf.SortKey)
+		}
+	}
+	if len(encryptedColumns) == 0 {
+		return
+	}
+	if config.ConfigurationMap.KMSKeyName == "" || config.ConfigurationMap.WrappedDataKey == "" {
+		logger.LogFatal("KMSKeyName and WrappedDataKey are required for EncryptedAttributes")
+	}
+	key, err := unwrapDataKey(context.Background(), config.ConfigurationMap.KMSKeyName, config.ConfigurationMap.WrappedDataKey)
+	if err != nil {
+		logger.LogFatal(err)
+	}
+	if err := setDataKey(key); err != nil {
+		logger.LogFatal(err)
+	}
+}
+
+// unwrapDataKey decrypts the base64 encoded wrapped data key with the KMS key
+func unwrapDataKey(ctx context.Context, keyName, wrappedKey string) ([]byte, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := gtransport.Dial(ctx, option.WithEndpoint(kmsEndpoint), option.WithScopes("https://www.googleapis.com/auth/cloud-platform"))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	resp, err := kmspb.NewKeyManagementServiceClient(conn).Decrypt(ctx, &kmspb.DecryptRequest{Name: keyName, Ciphertext: ciphertext})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Plaintext, nil
+}
+
+// setDataKey builds the AES-GCM cipher for a 16, 24 or 32 byte data key
+func setDataKey(key []byte) error {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	dataKey = aead
+	return nil
+}
+
+// ValidateEncryptedColumns checks that every EncryptedAttributes column is a
+// BYTES(MAX) column of its table, once the DDL of the tables is read
+func ValidateEncryptedColumns() error {
+	for table, cols := range encryptedColumns {
+		for col := range cols {
+			colType, ok := models.TableDDL[table][col]
+			if !ok {
+				return errors.New("EncryptedAttributes column " + table + "." + col + " does not exist")
+			}
+			if colType != "BYTES(MAX)" {
+				return errors.New("EncryptedAttributes column " + table + "." + col + " must be BYTES(MAX), not " + colType)
+			}
+		}
+	}
+	return nil
+}
+
+// isEncryptedColumn checks if the column of the spanner table is encrypted
+func isEncryptedColumn(table, col string) bool {
+	return encryptedColumns[changeTableNameForSP(table)][col]
+}
+
+// withEncryptionKeys adds the key columns of the table missing from cols when
+// the table has encrypted columns, as their values are needed to decrypt them
+func withEncryptionKeys(table string, cols []string) []string {
+	withKeys := cols
+	for _, k := range encryptedKeys[changeTableNameForSP(table)] {
+		if !hasColumn(withKeys, k) {
+			withKeys = append(withKeys[:len(withKeys):len(withKeys)], k)
+		}
+	}
+	return withKeys
+}
+
+// hideEncryptionKeys removes the key columns withEncryptionKeys added to the
+// columns of the row
+func hideEncryptionKeys(table string, row map[string]interface{}, cols []string) {
+	if len(cols) == 0 {
+		return
+	}
+	for _, k := range encryptedKeys[changeTableNameForSP(table)] {
+		if !hasColumn(cols, k) {
+			delete(row, k)
+		}
+	}
+}
+
+func hasColumn(cols []string, col string) bool {
+	for _, c := range cols {
+		if c == col {
+			return true
+		}
+	}
+	return false
+}
+
+// encryptionKey returns the values of the key columns of the item, which
+// identify the row of its encrypted columns
+func encryptionKey(table string, item map[string]interface{}) []interface{} {
+	keys := encryptedKeys[changeTableNameForSP(table)]
+	key := make([]interface{}, len(keys))
+	for i, k := range keys {
+		key[i] = item[k]
+	}
+	return key
+}
+
+// rowEncryptionKey returns the values of the key columns of a row read from Spanner
+func rowEncryptionKey(table string, r *spanner.Row) ([]interface{}, error) {
+	ddl := models.TableDDL[changeTableNameForSP(table)]
+	keys := encryptedKeys[changeTableNameForSP(table)]
+	key := make([]interface{}, len(keys))
+	for i, k := range keys {
+		var err error
+		switch ddl[k] {
+		case "STRING(MAX)":
+			var v string
+			err = r.ColumnByName(k, &v)
+			key[i] = v
+		case "INT64":
+			var v int64
+			err = r.ColumnByName(k, &v)
+			key[i] = v
+		case "FLOAT64":
+			var v float64
+			err = r.ColumnByName(k, &v)
+			key[i] = v
+		case "BYTES(MAX)":
+			var v []byte
+			err = r.ColumnByName(k, &v)
+			key[i] = v
+		default:
+			err = errors.New("ValidationException", "unsupported key column type", ddl[k])
+		}
+		if err != nil {
+			return nil, errors.New("ValidationException", "key column", k, "is needed to decrypt the row:", err)
+		}
+	}
+	return key, nil
+}
+
+// associatedData binds a ciphertext to its table, column and the primary key
+// of its row, so that it can not be copied to another row or column
+func associatedData(table, col string, key []interface{}) []byte {
+	parts := []string{changeTableNameForSP(table), col}
+	for _, v := range key {
+		// numbers of the request and of INT64 columns are written the same
+		switch n := v.(type) {
+		case float64:
+			parts = append(parts, strconv.FormatFloat(n, 'f', -1, 64))
+		case int64:
+			parts = append(parts, strconv.FormatInt(n, 10))
+		default:
+			parts = append(parts, fmt.Sprint(v))
+		}
+	}
+	ba, _ := json.Marshal(parts)
+	return ba
+}
+
+// marshalColumn converts the value of a BYTES(MAX) column to json and
+// encrypts it if the column is encrypted, bound to the key of the row
+func marshalColumn(table, col string, v interface{}, key []interface{}) ([]byte, error) {
+	ba, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.New("ValidationException", err)
+	}
+	if !isEncryptedColumn(table, col) {
+		return ba, nil
+	}
+	if dataKey == nil {
+		return nil, errors.New("ValidationException", "data key is not initialized for", col)
+	}
+	nonce := make([]byte, dataKey.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return dataKey.Seal(nonce, nonce, ba, associatedData(table, col, key)), nil
+}
+
+// decryptRowColumn returns the json stored in a column of the row r
+func decryptRowColumn(table, col string, ba []byte, r *spanner.Row) ([]byte, error) {
+	if !isEncryptedColumn(table, col) || len(ba) == 0 {
+		return ba, nil
+	}
+	key, err := rowEncryptionKey(table, r)
+	if err != nil {
+		return nil, err
+	}
+	return decryptColumn(table, col, ba, key)
+}
+
+// decryptColumn returns the json stored in an encrypted column of the row with key
+func decryptColumn(table, col string, ba []byte, key []interface{}) ([]byte, error) {
+	if !isEncryptedColumn(table, col) || len(ba) == 0 {
+		return ba, nil
+	}
+	if dataKey == nil {
+		return nil, errors.New("ValidationException", "data key is not initialized for", col)
+	}
+	nonceSize := dataKey.NonceSize()
+	if len(ba) < nonceSize {
+		return nil, errors.New("ValidationException", "invalid encrypted value for", col)
+	}
+	plaintext, err := dataKey.Open(nil, ba[:nonceSize], ba[nonceSize:], associatedData(table, col, key))
+	if err != nil {
+		return nil, errors.New("ValidationException", err, col)
+	}
+	return plaintext, nil
+}
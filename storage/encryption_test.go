@@ -0,0 +1,110 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestEncryptedColumnRoundTrip(t *testing.T) {
+	defer func() {
+		encryptedColumns = map[string]map[string]bool{}
+		encryptedKeys = map[string][]string{}
+		dataKey = nil
+	}()
+	encryptedColumns = map[string]map[string]bool{"users": {"ssn": true, "pin": true}}
+	encryptedKeys = map[string][]string{"users": {"user_id"}}
+	key := []interface{}{float64(1)}
+
+	_, err := marshalColumn("users", "ssn", "123-45-6789", key)
+	assert.NotEqual(t, err, nil)
+
+	assert.Equal(t, setDataKey(bytes.Repeat([]byte{1}, 32)), nil)
+
+	plain, err := marshalColumn("users", "name", "john", key)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(plain), `"john"`)
+
+	sealed, err := marshalColumn("users", "ssn", "123-45-6789", key)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, bytes.Contains(sealed, []byte("123-45-6789")), false)
+
+	opened, err := decryptColumn("users", "ssn", sealed, key)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(opened), `"123-45-6789"`)
+
+	// an INT64 key column reads back as int64
+	opened, err = decryptColumn("users", "ssn", sealed, []interface{}{int64(1)})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(opened), `"123-45-6789"`)
+
+	// the ciphertext copied to another row or column does not decrypt
+	_, err = decryptColumn("users", "ssn", sealed, []interface{}{float64(2)})
+	assert.NotEqual(t, err, nil)
+	_, err = decryptColumn("users", "pin", sealed, key)
+	assert.NotEqual(t, err, nil)
+
+	sealed[len(sealed)-1] ^= 1
+	_, err = decryptColumn("users", "ssn", sealed, key)
+	assert.NotEqual(t, err, nil)
+}
+
+func TestEncryptedColumnRead(t *testing.T) {
+	defer func() {
+		encryptedColumns = map[string]map[string]bool{}
+		encryptedKeys = map[string][]string{}
+		dataKey = nil
+		delete(models.TableDDL, "accounts")
+	}()
+	encryptedColumns = map[string]map[string]bool{"accounts": {"ssn": true}}
+	encryptedKeys = map[string][]string{"accounts": {"id"}}
+	models.TableDDL["accounts"] = map[string]string{"id": "INT64", "ssn": "BYTES(MAX)"}
+	assert.Equal(t, setDataKey(bytes.Repeat([]byte{1}, 32)), nil)
+
+	assert.Equal(t, readColumns("accounts", []string{"ssn"}), []string{"ssn", "id"})
+	sealed, err := marshalColumn("accounts", "ssn", "123-45-6789", encryptionKey("accounts", map[string]interface{}{"id": float64(7)}))
+	assert.Equal(t, err, nil)
+	row, err := spanner.NewRow([]string{"ssn", "id"}, []interface{}{sealed, int64(7)})
+	assert.Equal(t, err, nil)
+	got, err := parseRowForNull("accounts", row, models.TableDDL["accounts"], []string{"ssn"})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, got, map[string]interface{}{"ssn": "123-45-6789"})
+
+	row, err = spanner.NewRow([]string{"ssn", "id"}, []interface{}{sealed, int64(8)})
+	assert.Equal(t, err, nil)
+	_, err = parseRowForNull("accounts", row, models.TableDDL["accounts"], []string{"ssn"})
+	assert.NotEqual(t, err, nil)
+}
+
+func TestValidateEncryptedColumns(t *testing.T) {
+	defer func() {
+		encryptedColumns = map[string]map[string]bool{}
+		delete(models.TableDDL, "accounts")
+	}()
+	encryptedColumns = map[string]map[string]bool{"accounts": {"ssn": true}}
+	models.TableDDL["accounts"] = map[string]string{"id": "INT64", "ssn": "BYTES(MAX)"}
+	assert.Equal(t, ValidateEncryptedColumns(), nil)
+
+	models.TableDDL["accounts"]["ssn"] = "STRING(MAX)"
+	assert.NotEqual(t, ValidateEncryptedColumns(), nil)
+
+	delete(models.TableDDL["accounts"], "ssn")
+	assert.NotEqual(t, ValidateEncryptedColumns(), nil)
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"expvar"
+	"sync/atomic"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
+)
+
+const (
+	defaultFailoverCheckInterval = 10 * time.Second
+	defaultFailoverThreshold     = 3
+)
+
+// failedOver is 1 for the instances served by the secondary database
+var failedOver = expvar.NewMap("spanner_failed_over")
+
+// failover switches the clients of a Spanner instance to the secondary
+// database after FailoverThreshold failed health checks of the primary in a
+// row and back to the primary once a health check succeeds
+type failover struct {
+	instance  string
+	primary   *spanner.Client
+	secondary *spanner.Client
+	writable  bool
+	// failures is only used by the health check goroutine
+	failures int
+	active   int32
+}
+
+// initFailover connects every instance to SecondarySpannerDb and starts the
+// health checks of its primary client when a secondary database is configured
+func (s *Storage) initFailover() {
+	path := config.ConfigurationMap.SecondarySpannerDb
+	if path == "" {
+		return
+	}
+	interval, err := time.ParseDuration(config.ConfigurationMap.FailoverCheckInterval)
+	if err != nil || interval <= 0 {
+		interval = defaultFailoverCheckInterval
+	}
+	threshold := config.ConfigurationMap.FailoverThreshold
+	if threshold <= 0 {
+		threshold = defaultFailoverThreshold
+	}
+	for instance, primary := range s.spannerClient {
+		secondary, err := spanner.NewClientWithConfig(context.Background(), path, clientConfig())
+		if err != nil {
+			logger.LogFatal(err)
+		}
+		s.secondary[instance] = secondary
+		f := &failover{instance: instance, primary: primary, secondary: secondary, writable: config.ConfigurationMap.SecondaryWritable}
+		failedOver.Set(instance, new(expvar.Int))
+		s.failover[instance] = f
+		go f.watch(interval, threshold)
+	}
+}
+
+// client returns the client serving reads
+func (f *failover) client() *spanner.Client {
+	if atomic.LoadInt32(&f.active) == 1 {
+		return f.secondary
+	}
+	return f.primary
+}
+
+// writeClient returns the client serving writes, writes fail fast while the
+// primary is down unless the secondary is writable
+func (f *failover) writeClient() (*spanner.Client, error) {
+	if atomic.LoadInt32(&f.active) == 0 {
+		return f.primary, nil
+	}
+	if f.writable {
+		return f.secondary, nil
+	}
+	return nil, errors.New("InternalServerError", "The primary Spanner database of instance "+f.instance+" is unavailable")
+}
+
+func (f *failover) watch(interval time.Duration, threshold int) {
+	for range time.Tick(interval) {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		f.recordCheck(healthCheck(ctx, f.primary), threshold)
+		cancel()
+	}
+}
+
+// recordCheck switches the clients based on the result of a health check of
+// the primary
+func (f *failover) recordCheck(err error, threshold int) {
+	if err == nil {
+		f.failures = 0
+		if atomic.CompareAndSwapInt32(&f.active, 1, 0) {
+			failedOver.Add(f.instance, -1)
+			logger.LogWarn("Primary Spanner database of instance", f.instance, "recovered, switching back")
+		}
+		return
+	}
+	f.failures++
+	if f.failures >= threshold && atomic.CompareAndSwapInt32(&f.active, 0, 1) {
+		failedOver.Add(f.instance, 1)
+		logger.LogWarn("Primary Spanner database of instance", f.instance, "is unavailable, switching to the secondary:", err)
+	}
+}
+
+// healthCheck runs a trivial query on the client
+func healthCheck(ctx context.Context, client *spanner.Client) error {
+	itr := client.Single().Query(ctx, spanner.NewStatement("SELECT 1"))
+	defer itr.Stop()
+	_, err := itr.Next()
+	return err
+}
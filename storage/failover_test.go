@@ -0,0 +1,53 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestFailover(t *testing.T) {
+	primary, secondary := &spanner.Client{}, &spanner.Client{}
+	unavailable := errors.New("unavailable")
+	tests := []struct {
+		testName      string
+		writable      bool
+		checks        []error
+		wantRead      *spanner.Client
+		wantWrite     *spanner.Client
+		wantWriteFail bool
+	}{
+		{"healthy primary", false, []error{nil, nil}, primary, primary, false},
+		{"errors below the threshold", false, []error{unavailable, unavailable, nil, unavailable}, primary, primary, false},
+		{"sustained errors", false, []error{unavailable, unavailable, unavailable}, secondary, nil, true},
+		{"sustained errors with a writable secondary", true, []error{unavailable, unavailable, unavailable}, secondary, secondary, false},
+		{"primary recovered", false, []error{unavailable, unavailable, unavailable, nil}, primary, primary, false},
+	}
+
+	for _, tc := range tests {
+		f := &failover{instance: tc.testName, primary: primary, secondary: secondary, writable: tc.writable}
+		for _, err := range tc.checks {
+			f.recordCheck(err, 3)
+		}
+		assert.Equal(t, f.client() == tc.wantRead, true)
+		client, err := f.writeClient()
+		assert.Equal(t, err != nil, tc.wantWriteFail)
+		assert.Equal(t, client == tc.wantWrite, true)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/utils"
+
+	"cloud.google.com/go/spanner"
+)
+
+// jsonColumnType is the Spanner type of the columns holding DynamoDB maps and
+// lists as JSON documents
+const jsonColumnType = "JSON"
+
+// marshalJSONColumn converts the Map or List of a JSON column to the JSON
+// document written to Spanner. Mutations carry it as a string.
+func marshalJSONColumn(col string, v interface{}) (string, error) {
+	ba, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.New("ValidationException", err, col)
+	}
+	return string(ba), nil
+}
+
+// readJSONColumn returns the value of the JSON column i of the row, and false
+// when it is NULL. Objects are read as maps, arrays as lists and numbers keep
+// the digits float64 cannot hold, see utils.PreciseNumber.
+func readJSONColumn(r *spanner.Row, i int, col string) (interface{}, bool, error) {
+	var gcv spanner.GenericColumnValue
+	if err := r.Column(i, &gcv); err != nil {
+		return nil, false, err
+	}
+	// a NULL has no string value and an empty string is no JSON document
+	s := gcv.Value.GetStringValue()
+	if s == "" {
+		return nil, false, nil
+	}
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, false, errors.New("ValidationException", err, col)
+	}
+	return inferJSONNumbers(v), true, nil
+}
+
+// inferJSONNumbers replaces the json.Number values of a decoded document
+func inferJSONNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		return utils.PreciseNumber(v.String())
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = inferJSONNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = inferJSONNumbers(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,118 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"expvar"
+	"sync"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+)
+
+const (
+	defaultWriteConcurrency = 8
+	// mutationChunkSize keeps every commit well below the Spanner limit of
+	// mutations per commit
+	mutationChunkSize = 1000
+)
+
+var (
+	writeSlots     chan struct{}
+	writeSlotsOnce sync.Once
+
+	writeConcurrency = expvar.NewInt("write_concurrency")
+	writeQueueDepth  = expvar.NewInt("write_queue_depth")
+	writesInFlight   = expvar.NewInt("writes_in_flight")
+)
+
+// writeLimiter returns the slots shared by all the batch writes, sized by WriteConcurrency
+func writeLimiter() chan struct{} {
+	writeSlotsOnce.Do(func() {
+		n := config.ConfigurationMap.WriteConcurrency
+		if n <= 0 {
+			n = defaultWriteConcurrency
+		}
+		writeConcurrency.Set(int64(n))
+		writeSlots = make(chan struct{}, n)
+	})
+	return writeSlots
+}
+
+// acquireWriteSlot waits for a free write slot until the context is done
+func acquireWriteSlot(ctx context.Context) error {
+	slots := writeLimiter()
+	writeQueueDepth.Add(1)
+	defer writeQueueDepth.Add(-1)
+	select {
+	case slots <- struct{}{}:
+		writesInFlight.Add(1)
+		return nil
+	case <-ctx.Done():
+		return errors.New("InternalServerError", ctx.Err())
+	}
+}
+
+func releaseWriteSlot() {
+	writesInFlight.Add(-1)
+	<-writeLimiter()
+}
+
+// chunkMutations splits the mutations into chunks of at most size mutations
+func chunkMutations(ms []*spanner.Mutation, size int) [][]*spanner.Mutation {
+	var chunks [][]*spanner.Mutation
+	for len(ms) > size {
+		chunks = append(chunks, ms[:size])
+		ms = ms[size:]
+	}
+	if len(ms) > 0 {
+		chunks = append(chunks, ms)
+	}
+	return chunks
+}
+
+// applyMutations applies the mutations in chunks. Each chunk takes a write
+// slot, so no more than WriteConcurrency chunks are applied at a time across
+// all requests.
+func (s Storage) applyMutations(ctx context.Context, table string, ms []*spanner.Mutation) error {
+	client, err := s.getWriteClient(table)
+	if err != nil {
+		return err
+	}
+	chunks := chunkMutations(ms, mutationChunkSize)
+	errs := make([]error, len(chunks))
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		if err := acquireWriteSlot(ctx); err != nil {
+			wg.Wait()
+			return err
+		}
+		wg.Add(1)
+		go func(i int, chunk []*spanner.Mutation) {
+			defer wg.Done()
+			defer releaseWriteSlot()
+			_, errs[i] = client.Apply(ctx, chunk)
+		}(i, chunk)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
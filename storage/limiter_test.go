@@ -0,0 +1,65 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestChunkMutations(t *testing.T) {
+	ms := make([]*spanner.Mutation, 5)
+	tests := []struct {
+		testName string
+		ms       []*spanner.Mutation
+		size     int
+		want     []int
+	}{
+		{"no mutations", nil, 2, nil},
+		{"smaller than a chunk", ms[:1], 2, []int{1}},
+		{"exact chunks", ms[:4], 2, []int{2, 2}},
+		{"last chunk is partial", ms, 2, []int{2, 2, 1}},
+	}
+
+	for _, tc := range tests {
+		var got []int
+		for _, chunk := range chunkMutations(tc.ms, tc.size) {
+			got = append(got, len(chunk))
+		}
+		assert.Equal(t, got, tc.want)
+	}
+}
+
+func TestAcquireWriteSlot(t *testing.T) {
+	slots := writeLimiter()
+	for i := 0; i < cap(slots); i++ {
+		assert.Equal(t, acquireWriteSlot(context.Background()), nil)
+	}
+	assert.Equal(t, writesInFlight.Value(), int64(cap(slots)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := acquireWriteSlot(ctx)
+	assert.NotEqual(t, err, nil)
+	assert.Equal(t, writeQueueDepth.Value(), int64(0))
+
+	for i := 0; i < cap(slots); i++ {
+		releaseWriteSlot()
+	}
+	assert.Equal(t, writesInFlight.Value(), int64(0))
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"expvar"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
+)
+
+// defaultSlowQueryThreshold is used when SlowQueryThreshold is not set
+const defaultSlowQueryThreshold = time.Second
+
+var (
+	slowThreshold     time.Duration
+	slowThresholdOnce sync.Once
+
+	// the metrics below are keyed by table/operation
+	operationsTotal    = expvar.NewMap("spanner_operations_total")
+	operationLatencyMs = expvar.NewMap("spanner_operation_latency_ms_total")
+	slowQueryTotal     = expvar.NewMap("slow_query_total")
+
+	// queryPlansTotal is keyed by table/hinted for the queries with table or
+	// statement hints and table/default for the ones left to the optimizer
+	queryPlansTotal = expvar.NewMap("spanner_query_plans_total")
+)
+
+// slowQueryThreshold returns the duration above which a Spanner operation is
+// logged as slow, parsed once from SlowQueryThreshold
+func slowQueryThreshold() time.Duration {
+	slowThresholdOnce.Do(func() {
+		d, err := time.ParseDuration(config.ConfigurationMap.SlowQueryThreshold)
+		if err != nil || d <= 0 {
+			d = defaultSlowQueryThreshold
+		}
+		slowThreshold = d
+	})
+	return slowThreshold
+}
+
+// observe records a Spanner operation on table which started at start. It is
+// meant to be deferred, stmt is nil for operations which do not run SQL.
+func observe(table, operation string, stmt *spanner.Statement, start time.Time) {
+	recordOperation(table, operation, stmt, time.Since(start), slowQueryThreshold())
+}
+
+func recordOperation(table, operation string, stmt *spanner.Statement, d, threshold time.Duration) {
+	label := table + "/" + operation
+	operationsTotal.Add(label, 1)
+	operationLatencyMs.Add(label, d.Milliseconds())
+	if stmt != nil {
+		queryPlansTotal.Add(table+"/"+queryPlan(stmt.SQL), 1)
+	}
+	if d < threshold {
+		return
+	}
+	slowQueryTotal.Add(label, 1)
+	fields := []interface{}{"table", table, "operation", operation, "durationMs", d.Milliseconds()}
+	if stmt != nil {
+		fields = append(fields, "sql", stmt.SQL, "params", redactParams(stmt.Params))
+	}
+	logger.LogWarnw("slow spanner operation", fields...)
+}
+
+// queryPlan returns whether the plan of a query is hinted or chosen by the
+// optimizer
+func queryPlan(sql string) string {
+	if strings.Contains(sql, "@{") {
+		return "hinted"
+	}
+	return "default"
+}
+
+// redactParams returns the names of the query parameters without their
+// values, which may hold item data
+func redactParams(params map[string]interface{}) []string {
+	names := make([]string, 0, len(params))
+	for k := range params {
+		names = append(names, "@"+k+"=<redacted>")
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"expvar"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func counter(m *expvar.Map, key string) int64 {
+	v, ok := m.Get(key).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+	return v.Value()
+}
+
+func TestRecordOperation(t *testing.T) {
+	stmt := &spanner.Statement{SQL: "SELECT * FROM orders WHERE id = @id", Params: map[string]interface{}{"id": "secret"}}
+	tests := []struct {
+		testName  string
+		operation string
+		d         time.Duration
+		wantSlow  int64
+	}{
+		{"fast operation", "get", time.Millisecond, 0},
+		{"slow operation", "query", 2 * time.Second, 1},
+	}
+
+	for _, tc := range tests {
+		// the counters are global, so only their increase is checked
+		operations := counter(operationsTotal, "orders/"+tc.operation)
+		slow := counter(slowQueryTotal, "orders/"+tc.operation)
+		recordOperation("orders", tc.operation, stmt, tc.d, time.Second)
+		assert.Equal(t, counter(operationsTotal, "orders/"+tc.operation)-operations, int64(1))
+		assert.Equal(t, counter(slowQueryTotal, "orders/"+tc.operation)-slow, tc.wantSlow)
+	}
+}
+
+func TestQueryPlans(t *testing.T) {
+	// the counters are global, so only their increase is checked
+	defaultPlans, hintedPlans := counter(queryPlansTotal, "plans/default"), counter(queryPlansTotal, "plans/hinted")
+	recordOperation("plans", "query", &spanner.Statement{SQL: "SELECT * FROM plans"}, time.Millisecond, time.Second)
+	recordOperation("plans", "query", &spanner.Statement{SQL: "SELECT * FROM plans@{FORCE_INDEX=ByDate}"}, time.Millisecond, time.Second)
+	recordOperation("plans", "query", &spanner.Statement{SQL: "@{USE_ADDITIONAL_PARALLELISM=TRUE} SELECT * FROM plans"}, time.Millisecond, time.Second)
+	recordOperation("plans", "get", nil, time.Millisecond, time.Second)
+	assert.Equal(t, counter(queryPlansTotal, "plans/default")-defaultPlans, int64(1))
+	assert.Equal(t, counter(queryPlansTotal, "plans/hinted")-hintedPlans, int64(2))
+}
+
+func TestRedactParams(t *testing.T) {
+	got := redactParams(map[string]interface{}{"b": 1, "a": "secret"})
+	assert.Equal(t, got, []string{"@a=<redacted>", "@b=<redacted>"})
+}
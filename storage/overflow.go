@@ -0,0 +1,200 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+)
+
+// overflowColumns holds the OverflowColumn of every spanner table, which
+// stores the attributes without a column of their own as json
+var overflowColumns = map[string]string{}
+
+// initOverflow reads the OverflowColumn of the tables
+func initOverflow() {
+	overflowColumns = map[string]string{}
+	for tableName := range config.DbConfigMap {
+		tableConf, err := config.GetTableConf(tableName)
+		if err != nil || tableConf.OverflowColumn == "" {
+			continue
+		}
+		overflowColumns[changeTableNameForSP(tableConf.ActualTable)] = tableConf.OverflowColumn
+	}
+}
+
+// overflowColumn returns the overflow column of the spanner table, which is
+// empty when the table has none
+func overflowColumn(table string) string {
+	return overflowColumns[changeTableNameForSP(table)]
+}
+
+// isOverflowAttribute checks if the attribute is stored in the overflow column
+func isOverflowAttribute(table, col string) bool {
+	overflow := overflowColumn(table)
+	if overflow == "" || col == overflow {
+		return false
+	}
+	_, ok := models.TableDDL[changeTableNameForSP(table)][col]
+	return !ok
+}
+
+// readColumns replaces the overflow attributes of cols with the overflow column
+// and adds the key columns needed to decrypt the encrypted columns
+func readColumns(table string, cols []string) []string {
+	cols = withEncryptionKeys(table, cols)
+	overflow := overflowColumn(table)
+	if overflow == "" {
+		return cols
+	}
+	readCols := make([]string, 0, len(cols))
+	needOverflow, hasOverflow := false, false
+	for _, col := range cols {
+		if isOverflowAttribute(table, col) {
+			needOverflow = true
+			continue
+		}
+		if col == overflow {
+			hasOverflow = true
+		}
+		readCols = append(readCols, col)
+	}
+	if needOverflow && !hasOverflow {
+		readCols = append(readCols, overflow)
+	}
+	return readCols
+}
+
+// unpackOverflow moves the attributes of the overflow column into the row.
+// Only the overflow attributes named in cols are kept when cols names any,
+// otherwise all of them are. The columns of the row win over overflow
+// attributes of the same name.
+func unpackOverflow(table string, row map[string]interface{}, cols []string) {
+	overflow := overflowColumn(table)
+	if overflow == "" {
+		return
+	}
+	v, ok := row[overflow]
+	if !ok {
+		return
+	}
+	delete(row, overflow)
+	attrs, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+	var wanted map[string]bool
+	for _, col := range cols {
+		if isOverflowAttribute(table, col) {
+			if wanted == nil {
+				wanted = map[string]bool{}
+			}
+			wanted[col] = true
+		}
+	}
+	for k, v := range attrs {
+		if _, ok := row[k]; ok {
+			continue
+		}
+		if wanted == nil || wanted[k] {
+			row[k] = v
+		}
+	}
+}
+
+// packOverflow moves the overflow attributes of m into the overflow
+// column, on top of the stored ones. A nil attribute removes it.
+func packOverflow(table string, m, stored map[string]interface{}) {
+	overflow := overflowColumn(table)
+	if overflow == "" {
+		return
+	}
+	attrs := map[string]interface{}{}
+	for k, v := range stored {
+		attrs[k] = v
+	}
+	found := false
+	for k, v := range m {
+		if !isOverflowAttribute(table, k) {
+			continue
+		}
+		found = true
+		delete(m, k)
+		if v == nil {
+			delete(attrs, k)
+			continue
+		}
+		attrs[k] = v
+	}
+	if !found && stored != nil {
+		return
+	}
+	if len(attrs) == 0 {
+		m[overflow] = nil
+		return
+	}
+	m[overflow] = attrs
+}
+
+// mergeOverflow packs the overflow attributes of m on top of the overflow
+// attributes stored for the key, leaving the stored ones untouched when m has none.
+// The overflow attributes of a soft deleted item are not merged, readRowForUpdate
+// returns no row for its tombstone.
+func mergeOverflow(ctx context.Context, t *spanner.ReadWriteTransaction, table string, key spanner.Key, m map[string]interface{}) error {
+	overflow := overflowColumn(table)
+	if overflow == "" {
+		return nil
+	}
+	found := false
+	for k := range m {
+		if isOverflowAttribute(table, k) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+	r, err := readRowForUpdate(ctx, t, changeTableNameForSP(table), key, []string{overflow})
+	if err != nil {
+		return err
+	}
+	stored := map[string]interface{}{}
+	if r != nil {
+		var ba []byte
+		if err := r.Column(0, &ba); err != nil {
+			return errors.New("ValidationException", err, overflow)
+		}
+		ba, err = decryptColumn(table, overflow, ba, key)
+		if err != nil {
+			return err
+		}
+		if len(ba) > 0 {
+			if err := json.Unmarshal(ba, &stored); err != nil {
+				return errors.New("ValidationException", err, overflow)
+			}
+			if stored == nil {
+				stored = map[string]interface{}{}
+			}
+		}
+	}
+	packOverflow(table, m, stored)
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestOverflowRoundTrip(t *testing.T) {
+	defer func() {
+		overflowColumns = map[string]string{}
+		delete(models.TableDDL, "events")
+	}()
+	overflowColumns = map[string]string{"events": "extra"}
+	models.TableDDL["events"] = map[string]string{"id": "STRING(MAX)", "kind": "STRING(MAX)", "extra": "BYTES(MAX)"}
+
+	item := map[string]interface{}{"id": "e1", "kind": "click", "browser": "firefox", "tags": []interface{}{"a", "b"}}
+	packOverflow("events", item, nil)
+	assert.Equal(t, item, map[string]interface{}{
+		"id":    "e1",
+		"kind":  "click",
+		"extra": map[string]interface{}{"browser": "firefox", "tags": []interface{}{"a", "b"}},
+	})
+
+	ba, err := marshalColumn("events", "extra", item["extra"], nil)
+	assert.Equal(t, err, nil)
+	row, err := spanner.NewRow([]string{"id", "kind", "extra"}, []interface{}{"e1", "click", ba})
+	assert.Equal(t, err, nil)
+
+	got, err := parseRowForNull("events", row, models.TableDDL["events"], []string{"id", "kind", "extra"})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, got, map[string]interface{}{"id": "e1", "kind": "click", "browser": "firefox", "tags": []interface{}{"a", "b"}})
+
+	projected, err := parseRowForNull("events", row, models.TableDDL["events"], []string{"id", "browser"})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, projected, map[string]interface{}{"id": "e1", "kind": "click", "browser": "firefox"})
+}
+
+func TestPackOverflowMerge(t *testing.T) {
+	defer func() {
+		overflowColumns = map[string]string{}
+		delete(models.TableDDL, "events")
+	}()
+	overflowColumns = map[string]string{"events": "extra"}
+	models.TableDDL["events"] = map[string]string{"id": "STRING(MAX)", "kind": "STRING(MAX)", "extra": "BYTES(MAX)"}
+	stored := map[string]interface{}{"browser": "firefox", "os": "linux"}
+
+	tests := []struct {
+		testName string
+		update   map[string]interface{}
+		want     map[string]interface{}
+	}{
+		{
+			"set an overflow attribute",
+			map[string]interface{}{"id": "e1", "os": "mac"},
+			map[string]interface{}{"id": "e1", "extra": map[string]interface{}{"browser": "firefox", "os": "mac"}},
+		},
+		{
+			"remove an overflow attribute",
+			map[string]interface{}{"id": "e1", "browser": nil},
+			map[string]interface{}{"id": "e1", "extra": map[string]interface{}{"os": "linux"}},
+		},
+		{
+			"only columns",
+			map[string]interface{}{"id": "e1", "kind": "view"},
+			map[string]interface{}{"id": "e1", "kind": "view"},
+		},
+	}
+	for _, tc := range tests {
+		packOverflow("events", tc.update, stored)
+		assert.Equal(t, tc.update, tc.want)
+	}
+}
+
+func TestReadColumns(t *testing.T) {
+	defer func() {
+		overflowColumns = map[string]string{}
+		delete(models.TableDDL, "events")
+	}()
+	models.TableDDL["events"] = map[string]string{"id": "STRING(MAX)", "kind": "STRING(MAX)", "extra": "BYTES(MAX)"}
+	assert.Equal(t, readColumns("events", []string{"id", "browser"}), []string{"id", "browser"})
+
+	overflowColumns = map[string]string{"events": "extra"}
+	tests := []struct {
+		testName string
+		cols     []string
+		want     []string
+	}{
+		{"columns", []string{"id", "kind"}, []string{"id", "kind"}},
+		{"overflow attributes", []string{"id", "browser", "os"}, []string{"id", "extra"}},
+		{"overflow column", []string{"id", "extra", "browser"}, []string{"id", "extra"}},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, readColumns("events", tc.cols), tc.want)
+	}
+}
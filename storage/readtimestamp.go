@@ -0,0 +1,72 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
+
+type readTimestampKey struct{}
+
+// readTimestamp holds the oldest timestamp the reads of a context read at
+type readTimestamp struct {
+	mu sync.Mutex
+	ts time.Time
+}
+
+// WithReadTimestamp returns a context which records the timestamps its reads
+// read at, returned by ReadTimestamp
+func WithReadTimestamp(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readTimestampKey{}, &readTimestamp{})
+}
+
+// ReadTimestamp returns the timestamp the reads of a WithReadTimestamp context
+// read at, the oldest one when there were several, and false when nothing
+// was read
+func ReadTimestamp(ctx context.Context) (time.Time, bool) {
+	rts, ok := ctx.Value(readTimestampKey{}).(*readTimestamp)
+	if !ok {
+		return time.Time{}, false
+	}
+	rts.mu.Lock()
+	defer rts.mu.Unlock()
+	return rts.ts, !rts.ts.IsZero()
+}
+
+// recordReadTimestamp records the timestamp of a read-only transaction which
+// read. Transactions which did not read yet have no timestamp and are skipped.
+func recordReadTimestamp(ctx context.Context, txn *spanner.ReadOnlyTransaction) {
+	if ts, err := txn.Timestamp(); err == nil {
+		AddReadTimestamp(ctx, ts)
+	}
+}
+
+// AddReadTimestamp records a timestamp the reads of a WithReadTimestamp
+// context read at, like the one of a read shared with other requests
+func AddReadTimestamp(ctx context.Context, ts time.Time) {
+	rts, ok := ctx.Value(readTimestampKey{}).(*readTimestamp)
+	if !ok {
+		return
+	}
+	rts.mu.Lock()
+	defer rts.mu.Unlock()
+	if rts.ts.IsZero() || ts.Before(rts.ts) {
+		rts.ts = ts
+	}
+}
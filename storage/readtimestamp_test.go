@@ -0,0 +1,76 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestReadTimestamp(t *testing.T) {
+	newer := time.Date(2020, 10, 1, 12, 0, 10, 0, time.UTC)
+	older := newer.Add(-10 * time.Second)
+
+	ctx := context.Background()
+	AddReadTimestamp(ctx, newer)
+	_, ok := ReadTimestamp(ctx)
+	assert.Equal(t, ok, false)
+
+	ctx = WithReadTimestamp(ctx)
+	_, ok = ReadTimestamp(ctx)
+	assert.Equal(t, ok, false)
+
+	AddReadTimestamp(ctx, newer)
+	AddReadTimestamp(ctx, older)
+	AddReadTimestamp(ctx, newer)
+	ts, ok := ReadTimestamp(ctx)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, ts, older)
+}
+
+func TestStreamRowsReadTimestamp(t *testing.T) {
+	readAt := time.Date(2020, 10, 1, 12, 0, 10, 0, time.UTC)
+	ctx := WithReadTimestamp(context.Background())
+	rows := []*spanner.Row{}
+	for _, id := range []int64{1, 2} {
+		row, err := spanner.NewRow([]string{"id"}, []interface{}{id})
+		assert.Equal(t, err, nil)
+		rows = append(rows, row)
+	}
+	next := func() (*spanner.Row, error) {
+		if len(rows) == 0 {
+			return nil, iterator.Done
+		}
+		row := rows[0]
+		rows = rows[1:]
+		return row, nil
+	}
+	// the first row starts the streamed response, so the timestamp is known
+	streamed := 0
+	err := streamRows("stream", map[string]string{"id": "INT64"}, []string{"id"}, next, func() { AddReadTimestamp(ctx, readAt) }, func(row map[string]interface{}) error {
+		ts, ok := ReadTimestamp(ctx)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, ts, readAt)
+		streamed++
+		return nil
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, streamed, 2)
+}
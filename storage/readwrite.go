@@ -0,0 +1,70 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
+)
+
+// readWritePaths returns the ReadSpannerDb and WriteSpannerDb database paths,
+// which are either both set or both empty
+func readWritePaths(conf *config.Configuration) (string, string, error) {
+	read, write := conf.ReadSpannerDb, conf.WriteSpannerDb
+	if (read == "") != (write == "") {
+		return "", "", errors.New("ReadSpannerDb and WriteSpannerDb have to be configured together")
+	}
+	if read != "" && conf.SecondarySpannerDb != "" {
+		return "", "", errors.New("SecondarySpannerDb can not be used with ReadSpannerDb and WriteSpannerDb")
+	}
+	return read, write, nil
+}
+
+// initReadWriteSplit connects to ReadSpannerDb and WriteSpannerDb when they
+// are configured and fails startup when only one of them is
+func (s *Storage) initReadWriteSplit() {
+	read, write, err := readWritePaths(config.ConfigurationMap)
+	if err != nil {
+		logger.LogFatal(err)
+	}
+	if read == "" {
+		return
+	}
+	s.readClient, err = spanner.NewClientWithConfig(context.Background(), read, clientConfig())
+	if err != nil {
+		logger.LogFatal(err)
+	}
+	s.writeClient, err = spanner.NewClientWithConfig(context.Background(), write, clientConfig())
+	if err != nil {
+		logger.LogFatal(err)
+	}
+}
+
+// ReadClient returns the client of ReadSpannerDb serving Query, Scan, GetItem
+// and BatchGetItem, which is nil when the reads are not split from the writes
+func (s Storage) ReadClient() *spanner.Client {
+	return s.readClient
+}
+
+// WriteClient returns the client of WriteSpannerDb serving the mutations and
+// the reads of their transactions, which is nil when the reads are not split
+// from the writes
+func (s Storage) WriteClient() *spanner.Client {
+	return s.writeClient
+}
@@ -0,0 +1,68 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestReadWritePaths(t *testing.T) {
+	const read, write = "projects/p/instances/read/databases/db", "projects/p/instances/write/databases/db"
+	tests := []struct {
+		testName  string
+		conf      config.Configuration
+		wantRead  string
+		wantWrite string
+		wantErr   bool
+	}{
+		{"no split", config.Configuration{}, "", "", false},
+		{"read and write", config.Configuration{ReadSpannerDb: read, WriteSpannerDb: write}, read, write, false},
+		{"only read", config.Configuration{ReadSpannerDb: read}, "", "", true},
+		{"only write", config.Configuration{WriteSpannerDb: write}, "", "", true},
+		{"with a secondary", config.Configuration{ReadSpannerDb: read, WriteSpannerDb: write, SecondarySpannerDb: read}, "", "", true},
+	}
+	for _, tc := range tests {
+		gotRead, gotWrite, err := readWritePaths(&tc.conf)
+		assert.Equal(t, err != nil, tc.wantErr)
+		assert.Equal(t, gotRead, tc.wantRead)
+		assert.Equal(t, gotWrite, tc.wantWrite)
+	}
+}
+
+func TestReadWriteClients(t *testing.T) {
+	defer delete(models.SpannerTableMap, "employee")
+	models.SpannerTableMap["employee"] = "instance"
+	instance, read, write := &spanner.Client{}, &spanner.Client{}, &spanner.Client{}
+
+	s := Storage{spannerClient: map[string]*spanner.Client{"instance": instance}}
+	client, err := s.getWriteClient("employee")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, s.getSpannerClient("employee") == instance, true)
+	assert.Equal(t, client == instance, true)
+	assert.Equal(t, s.ReadClient() == nil, true)
+
+	s.readClient, s.writeClient = read, write
+	client, err = s.getWriteClient("employee")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, s.getSpannerClient("employee") == read, true)
+	assert.Equal(t, client == write, true)
+	assert.Equal(t, s.ReadClient() == read, true)
+	assert.Equal(t, s.WriteClient() == write, true)
+}
@@ -0,0 +1,90 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
+
+// queryStaleness is the staleness of the reads of queries and scans
+const queryStaleness = 10 * time.Second
+
+type snapshotKey struct{}
+
+type consistentReadsKey struct{}
+
+// WithConsistentReads returns a context whose reads are strongly consistent,
+// instead of reading with the staleness of queries and eventually consistent
+// reads
+func WithConsistentReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, consistentReadsKey{}, true)
+}
+
+// ConsistentReads reports if the reads of the context are strongly consistent
+func ConsistentReads(ctx context.Context) bool {
+	consistent, _ := ctx.Value(consistentReadsKey{}).(bool)
+	return consistent
+}
+
+// queryTimestampBound returns the timestamp bound queries of the context read at
+func queryTimestampBound(ctx context.Context) spanner.TimestampBound {
+	if ConsistentReads(ctx) {
+		return spanner.StrongRead()
+	}
+	return spanner.ExactStaleness(queryStaleness)
+}
+
+// snapshot holds the read-only transactions shared by the queries of a
+// context, one per Spanner client
+type snapshot struct {
+	mu   sync.Mutex
+	txns map[*spanner.Client]*spanner.ReadOnlyTransaction
+}
+
+// WithSnapshot returns a context whose queries read from one read-only
+// transaction per database, so they all see the same snapshot, and the func
+// closing the transactions once the queries are done
+func WithSnapshot(ctx context.Context) (context.Context, func()) {
+	snap := &snapshot{txns: map[*spanner.Client]*spanner.ReadOnlyTransaction{}}
+	return context.WithValue(ctx, snapshotKey{}, snap), func() {
+		snap.mu.Lock()
+		defer snap.mu.Unlock()
+		for _, txn := range snap.txns {
+			txn.Close()
+		}
+	}
+}
+
+// queryTransaction returns the transaction a query of the table reads from,
+// the one of the snapshot of the context or else a single use transaction
+func (s Storage) queryTransaction(ctx context.Context, table string) *spanner.ReadOnlyTransaction {
+	client := s.getSpannerClient(table)
+	snap, ok := ctx.Value(snapshotKey{}).(*snapshot)
+	if !ok {
+		return client.Single().WithTimestampBound(queryTimestampBound(ctx))
+	}
+	snap.mu.Lock()
+	defer snap.mu.Unlock()
+	txn, ok := snap.txns[client]
+	if !ok {
+		txn = client.ReadOnlyTransaction().WithTimestampBound(queryTimestampBound(ctx))
+		snap.txns[client] = txn
+	}
+	return txn
+}
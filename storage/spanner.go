@@ -35,12 +35,15 @@ import (
 	"cloud.google.com/go/spanner"
 	"github.com/ahmetb/go-linq"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
 )
 
 var base64Regexp = regexp.MustCompile("^([A-Za-z0-9+/]{4})*([A-Za-z0-9+/]{3}=|[A-Za-z0-9+/]{2}==)?$")
 
-// SpannerBatchGet - fetch all rows
-func (s Storage) SpannerBatchGet(ctx context.Context, tableName string, pKeys, sKeys []interface{}, projectionCols []string) ([]map[string]interface{}, error) {
+// SpannerBatchGet - fetch all rows, the keys are read together with a single
+// KeySet read in one single use read only transaction
+func (s Storage) SpannerBatchGet(ctx context.Context, tableName string, pKeys, sKeys []interface{}, projectionCols []string, consistentRead bool) ([]map[string]interface{}, error) {
+	defer observe(tableName, "batch_get", nil, time.Now())
 	var keySet []spanner.KeySet
 
 	for i := range pKeys {
@@ -61,9 +64,14 @@ func (s Storage) SpannerBatchGet(ctx context.Context, tableName string, pKeys, s
 	if !ok {
 		return nil, errors.New("ResourceNotFoundException", tableName)
 	}
+	softDelete := config.IsSoftDelete(tableName)
+	if softDelete {
+		projectionCols = withTombstoneColumn(projectionCols)
+	}
 	tableName = changeTableNameForSP(tableName)
-	client := s.getSpannerClient(tableName)
-	itr := client.Single().Read(ctx, tableName, spanner.KeySets(keySet...), projectionCols)
+	txn := s.singleRead(ctx, tableName, consistentRead)
+	defer recordReadTimestamp(ctx, txn)
+	itr := txn.Read(ctx, tableName, spanner.KeySets(keySet...), readColumns(tableName, projectionCols))
 	defer itr.Stop()
 	allRows := []map[string]interface{}{}
 	for {
@@ -74,10 +82,13 @@ func (s Storage) SpannerBatchGet(ctx context.Context, tableName string, pKeys, s
 			}
 			return nil, errors.New("ValidationException", err)
 		}
-		singleRow, err := parseRowForNull(r, colDLL, projectionCols)
+		singleRow, err := parseRowForNull(tableName, r, colDLL, projectionCols)
 		if err != nil {
 			return nil, err
 		}
+		if softDelete && hideTombstone(singleRow) {
+			continue
+		}
 		if singleRow != nil && len(singleRow) > 0 {
 			allRows = append(allRows, singleRow)
 		}
@@ -85,13 +96,23 @@ func (s Storage) SpannerBatchGet(ctx context.Context, tableName string, pKeys, s
 	return allRows, nil
 }
 
-func createRowMap(r *spanner.Row, colDDL map[string]string, cols []string) (map[string]interface{}, error) {
+// singleRead returns a single use read only transaction, which reads with a
+// bounded staleness unless the read or the reads of the context have to be
+// strongly consistent
+func (s Storage) singleRead(ctx context.Context, table string, consistentRead bool) *spanner.ReadOnlyTransaction {
+	txn := s.getSpannerClient(table).Single()
+	if !consistentRead && !ConsistentReads(ctx) {
+		txn = txn.WithTimestampBound(spanner.MaxStaleness(time.Second * 10))
+	}
+	return txn
+}
+
+func createRowMap(table string, r *spanner.Row, colDDL map[string]string, cols []string) (map[string]interface{}, error) {
 	singleRow := make(map[string]interface{})
 	if r == nil {
 		return singleRow, nil
 	}
-	cols = r.ColumnNames()
-	for i, k := range cols {
+	for i, k := range r.ColumnNames() {
 		if k == "" {
 			continue
 		}
@@ -110,7 +131,11 @@ func createRowMap(r *spanner.Row, colDDL map[string]string, cols []string) (map[
 		case "BYTES(MAX)":
 			var s []byte
 			err := r.Column(i, &s)
-			if err == nil {
+			if err == nil && s != nil {
+				s, err = decryptRowColumn(table, k, s, r)
+				if err != nil {
+					return nil, err
+				}
 				var m interface{}
 				json.Unmarshal(s, &m)
 				singleRow[k] = m
@@ -133,19 +158,25 @@ func createRowMap(r *spanner.Row, colDDL map[string]string, cols []string) (map[
 			if err == nil {
 				singleRow[k] = s
 			}
+		case jsonColumnType:
+			v, ok, err := readJSONColumn(r, i, k)
+			if err == nil && ok {
+				singleRow[k] = v
+			}
 		}
 	}
+	hideEncryptionKeys(table, singleRow, cols)
+	unpackOverflow(table, singleRow, cols)
 	return singleRow, nil
 }
 
-func parseRowForNull(r *spanner.Row, colDDL map[string]string, cols []string) (map[string]interface{}, error) {
+func parseRowForNull(table string, r *spanner.Row, colDDL map[string]string, cols []string) (map[string]interface{}, error) {
 	singleRow := make(map[string]interface{})
 	if r == nil {
 		return singleRow, nil
 	}
 
-	cols = r.ColumnNames()
-	for i, k := range cols {
+	for i, k := range r.ColumnNames() {
 		if k == "" || k == "commit_timestamp" {
 			continue
 		}
@@ -175,6 +206,10 @@ func parseRowForNull(r *spanner.Row, colDDL map[string]string, cols []string) (m
 				}
 				return nil, errors.New("ValidationException", err, k)
 			}
+			s, err = decryptRowColumn(table, k, s, r)
+			if err != nil {
+				return nil, err
+			}
 			if len(s) > 0 {
 				var m interface{}
 				err := json.Unmarshal(s, &m)
@@ -258,13 +293,27 @@ func parseRowForNull(r *spanner.Row, colDDL map[string]string, cols []string) (m
 			if !s.IsNull() {
 				singleRow[k] = s.Bool
 			}
+		case jsonColumnType:
+			v, ok, err := readJSONColumn(r, i, k)
+			if err != nil {
+				if strings.Contains(err.Error(), "ambiguous column name") {
+					continue
+				}
+				return nil, errors.New("ValidationException", err, k)
+			}
+			if ok {
+				singleRow[k] = v
+			}
 		}
 	}
+	hideEncryptionKeys(table, singleRow, cols)
+	unpackOverflow(table, singleRow, cols)
 	return singleRow, nil
 }
 
 // SpannerGet - get with spanner
 func (s Storage) SpannerGet(ctx context.Context, tableName string, pKeys, sKeys interface{}, projectionCols []string) (map[string]interface{}, error) {
+	defer observe(tableName, "get", nil, time.Now())
 	key := spanner.Key{}
 	if sKeys == nil {
 		key = spanner.Key{pKeys}
@@ -282,25 +331,40 @@ func (s Storage) SpannerGet(ctx context.Context, tableName string, pKeys, sKeys
 	if !ok {
 		return nil, errors.New("ResourceNotFoundException", tableName)
 	}
+	softDelete := config.IsSoftDelete(tableName)
+	if softDelete {
+		projectionCols = withTombstoneColumn(projectionCols)
+	}
 	tableName = changeTableNameForSP(tableName)
 	client := s.getSpannerClient(tableName)
-	row, err := client.Single().ReadRow(ctx, tableName, key, projectionCols)
+	txn := client.Single()
+	row, err := txn.ReadRow(ctx, tableName, key, readColumns(tableName, projectionCols))
+	recordReadTimestamp(ctx, txn)
 	if err := errors.AssignError(err); err != nil {
 		return nil, errors.New("ResourceNotFoundException", tableName, key, err)
 	}
 
-	return parseRowForNull(row, colDLL, projectionCols)
+	singleRow, err := parseRowForNull(tableName, row, colDLL, projectionCols)
+	if err != nil {
+		return nil, err
+	}
+	if softDelete && hideTombstone(singleRow) {
+		return map[string]interface{}{}, nil
+	}
+	return singleRow, nil
 }
 
 // ExecuteSpannerQuery - this will execute query on spanner database
 func (s Storage) ExecuteSpannerQuery(ctx context.Context, table string, cols []string, isCountQuery bool, stmt spanner.Statement) ([]map[string]interface{}, error) {
+	defer observe(table, "query", &stmt, time.Now())
 	colDLL, ok := models.TableDDL[changeTableNameForSP(table)]
 	if !ok {
 		return nil, errors.New("ResourceNotFoundException", table)
 	}
 	go captureQueryHash(table, stmt.SQL)
-	var itr *spanner.RowIterator
-	itr = s.getSpannerClient(table).Single().WithTimestampBound(spanner.ExactStaleness(time.Second*10)).Query(ctx, stmt)
+	txn := s.queryTransaction(ctx, table)
+	defer recordReadTimestamp(ctx, txn)
+	itr := txn.Query(ctx, stmt)
 	defer itr.Stop()
 	allRows := []map[string]interface{}{}
 	for {
@@ -321,7 +385,7 @@ func (s Storage) ExecuteSpannerQuery(ctx context.Context, table string, cols []s
 			allRows = append(allRows, singleRow)
 			break
 		}
-		singleRow, err := parseRowForNull(r, colDLL, cols)
+		singleRow, err := parseRowForNull(table, r, colDLL, cols)
 		if err != nil {
 			return nil, err
 		}
@@ -330,10 +394,63 @@ func (s Storage) ExecuteSpannerQuery(ctx context.Context, table string, cols []s
 	return allRows, nil
 }
 
+// ExecuteSpannerQueryStream - this will execute query on spanner database and
+// call fn for every row as soon as it is read from the iterator
+func (s Storage) ExecuteSpannerQueryStream(ctx context.Context, table string, cols []string, stmt spanner.Statement, fn func(map[string]interface{}) error) error {
+	defer observe(table, "query", &stmt, time.Now())
+	colDLL, ok := models.TableDDL[changeTableNameForSP(table)]
+	if !ok {
+		return errors.New("ResourceNotFoundException", table)
+	}
+	go captureQueryHash(table, stmt.SQL)
+	txn := s.getSpannerClient(table).Single().WithTimestampBound(queryTimestampBound(ctx))
+	defer recordReadTimestamp(ctx, txn)
+	itr := txn.Query(ctx, stmt)
+	defer itr.Stop()
+	return streamRows(table, colDLL, cols, itr.Next, func() { recordReadTimestamp(ctx, txn) }, fn)
+}
+
+// streamRows calls fn with every row returned by next. The read timestamp is
+// recorded before the first row is handed to fn, since fn starts the response
+// and its headers can not be changed afterwards.
+func streamRows(table string, colDLL map[string]string, cols []string, next func() (*spanner.Row, error), recordTimestamp func(), fn func(map[string]interface{}) error) error {
+	for first := true; ; first = false {
+		r, err := next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return errors.New("ResourceNotFoundException", err)
+		}
+		if first {
+			recordTimestamp()
+		}
+		singleRow, err := parseRowForNull(table, r, colDLL, cols)
+		if err != nil {
+			return err
+		}
+		if err := fn(singleRow); err != nil {
+			return err
+		}
+	}
+}
+
 // SpannerPut - Spanner put insert a single object
-func (s Storage) SpannerPut(ctx context.Context, table string, m map[string]interface{}, eval *models.Eval, expr *models.UpdateExpressionCondition) (map[string]interface{}, error) {
+func (s Storage) SpannerPut(ctx context.Context, table string, m map[string]interface{}, eval *models.Eval, expr *models.UpdateExpressionCondition) (map[string]interface{}, map[string]interface{}, error) {
+	defer observe(table, "put", nil, time.Now())
+	tableConf, err := config.GetTableConf(table)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, _ := primaryKey(tableConf, m)
 	update := map[string]interface{}{}
-	_, err := s.getSpannerClient(table).ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
+	var old map[string]interface{}
+	softDelete := config.IsSoftDelete(table)
+	client, err := s.getWriteClient(table)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = client.ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
 		tmpMap := map[string]interface{}{}
 		for k, v := range m {
 			tmpMap[k] = v
@@ -348,13 +465,62 @@ func (s Storage) SpannerPut(ctx context.Context, table string, m map[string]inte
 			}
 		}
 		table = changeTableNameForSP(table)
+		var err error
+		old, err = readItem(ctx, t, table, key, softDelete)
+		if err != nil {
+			return err
+		}
 		for k, v := range tmpMap {
 			update[k] = v
 		}
-		return s.performPutOperation(ctx, t, table, tmpMap)
+		if err := mergeOverflow(ctx, t, table, key, tmpMap); err != nil {
+			return err
+		}
+		return s.performPutOperation(ctx, t, table, key, tmpMap)
 	})
 
-	return update, err
+	return update, old, err
+}
+
+// mergeItem returns the item after writing update over old, where a nil
+// value removes the attribute
+func mergeItem(old, update map[string]interface{}) map[string]interface{} {
+	item := map[string]interface{}{}
+	for k, v := range old {
+		item[k] = v
+	}
+	for k, v := range update {
+		if v == nil {
+			delete(item, k)
+			continue
+		}
+		item[k] = v
+	}
+	return item
+}
+
+// readItem reads every column of the item stored for key, which is empty when
+// the item does not exist or is soft deleted
+func readItem(ctx context.Context, t *spanner.ReadWriteTransaction, table string, key spanner.Key, softDelete bool) (map[string]interface{}, error) {
+	cols, ok := models.TableColumnMap[table]
+	if !ok {
+		return nil, errors.New("ResourceNotFoundException", table)
+	}
+	if softDelete {
+		cols = withTombstoneColumn(cols)
+	}
+	r, err := readRowForUpdate(ctx, t, table, key, cols)
+	if err != nil {
+		return nil, err
+	}
+	item, err := parseRowForNull(table, r, models.TableDDL[table], cols)
+	if err != nil {
+		return nil, err
+	}
+	if softDelete && hideTombstone(item) {
+		return map[string]interface{}{}, nil
+	}
+	return item, nil
 }
 
 func evaluateConditionalExpression(ctx context.Context, t *spanner.ReadWriteTransaction, table string, m map[string]interface{}, e *models.Eval, expr *models.UpdateExpressionCondition) (bool, error) {
@@ -370,47 +536,48 @@ func evaluateConditionalExpression(ctx context.Context, t *spanner.ReadWriteTran
 	pKey := tableConf.PartitionKey
 	pValue, ok := m[pKey]
 	if !ok {
-		return false, errors.New("ValidationException", pKey)
+		return false, errors.New("ValidationException", "missing key attribute", originalColumn(pKey)).WithParameter(originalColumn(pKey))
 	}
 	var key spanner.Key
 	sKey := tableConf.SortKey
 	if sKey != "" {
 		sValue, ok := m[sKey]
 		if !ok {
-			return false, errors.New("ValidationException", sKey)
+			return false, errors.New("ValidationException", "missing key attribute", originalColumn(sKey)).WithParameter(originalColumn(sKey))
 		}
 		key = spanner.Key{pValue, sValue}
 
 	} else {
 		key = spanner.Key{pValue}
 	}
-	var cols []string
-	if expr != nil {
-		cols = append(e.Cols, expr.Field...)
-		for k := range expr.AddValues {
-			cols = append(e.Cols, k)
-		}
-	} else {
-		cols = e.Cols
+	cols := conditionColumns(table, pKey, sKey, e, expr)
+	r, err := t.ReadRow(ctx, changeTableNameForSP(table), key, readColumns(table, cols))
+	if spanner.ErrCode(err) == codes.Aborted {
+		// the transaction is retried by the client
+		return false, err
 	}
-
-	linq.From(cols).IntersectByT(linq.From(models.TableColumnMap[changeTableNameForSP(table)]), func(str string) string {
-		return str
-	}).ToSlice(&cols)
-	r, err := t.ReadRow(ctx, changeTableNameForSP(table), key, cols)
 	if e := errors.AssignError(err); e != nil {
 		return false, e
 	}
-	rowMap, err := createRowMap(r, colDDL, cols)
+	rowMap, err := createRowMap(table, r, colDDL, cols)
 	if err != nil {
 		return false, err
 	}
+	if config.IsSoftDelete(table) && hideTombstone(rowMap) {
+		rowMap = map[string]interface{}{}
+	}
 	if expr != nil {
+		// the transaction of a retried write evaluates expr again, so its
+		// AddValues stay untouched
+		addValues := make(map[string]float64, len(expr.AddValues))
+		for k, v := range expr.AddValues {
+			addValues[k] = v
+		}
 		for index := 0; index < len(expr.Field); index++ {
 			status := evaluateStatementFromRowMap(expr.Condition[index], expr.Field[index], rowMap)
 			tmp, ok := status.(bool)
 			if !ok || !tmp {
-				if v1, ok := expr.AddValues[expr.Field[index]]; ok {
+				if v1, ok := addValues[expr.Field[index]]; ok {
 
 					tmp, ok := rowMap[expr.Field[index]].(float64)
 					if ok {
@@ -424,7 +591,7 @@ func evaluateConditionalExpression(ctx context.Context, t *spanner.ReadWriteTran
 					delete(m, expr.Field[index])
 				}
 			} else {
-				if v1, ok := expr.AddValues[expr.Field[index]]; ok {
+				if v1, ok := addValues[expr.Field[index]]; ok {
 					tmp, ok := m[expr.Field[index]].(float64)
 					if ok {
 						m[expr.Field[index]] = tmp + v1
@@ -435,9 +602,9 @@ func evaluateConditionalExpression(ctx context.Context, t *spanner.ReadWriteTran
 					}
 				}
 			}
-			delete(expr.AddValues, expr.Field[index])
+			delete(addValues, expr.Field[index])
 		}
-		for k, v := range expr.AddValues {
+		for k, v := range addValues {
 			val, ok := rowMap[k].(float64)
 			if ok {
 				m[k] = val + v
@@ -462,41 +629,95 @@ func evaluateConditionalExpression(ctx context.Context, t *spanner.ReadWriteTran
 	return status, nil
 }
 
+// conditionColumns returns the columns to read for evaluating a condition.
+// The primary key columns are always read, so an empty row map means that no
+// item exists for the full primary key and existence checks on any key
+// attribute behave as "no such item".
+func conditionColumns(table, pKey, sKey string, e *models.Eval, expr *models.UpdateExpressionCondition) []string {
+	cols := []string{pKey}
+	if sKey != "" {
+		cols = append(cols, sKey)
+	}
+	for _, col := range e.Cols {
+		cols = append(cols, topLevelAttribute(col))
+	}
+	if expr != nil {
+		cols = append(cols, expr.Field...)
+		for k := range expr.AddValues {
+			cols = append(cols, k)
+		}
+	}
+	if config.IsSoftDelete(table) {
+		cols = append(cols, models.SoftDeleteColumn)
+	}
+	var result []string
+	linq.From(cols).IntersectByT(linq.From(models.TableColumnMap[changeTableNameForSP(table)]), func(str string) string {
+		return str
+	}).ToSlice(&result)
+	for _, col := range cols {
+		if isOverflowAttribute(table, col) && !linq.From(result).Contains(col) {
+			result = append(result, col)
+		}
+	}
+	return result
+}
+
 func evaluateStatementFromRowMap(conditionalExpression, colName string, rowMap map[string]interface{}) interface{} {
 	if strings.HasPrefix(conditionalExpression, "attribute_not_exists") || strings.HasPrefix(conditionalExpression, "if_not_exists") {
 		if len(rowMap) == 0 {
 			return true
 		}
-		_, ok := rowMap[colName]
-		if ok {
-			return false
-		}
-		return true
+		_, ok := attributeValue(rowMap, colName)
+		return !ok
 	}
 	if strings.HasPrefix(conditionalExpression, "attribute_exists") || strings.HasPrefix(conditionalExpression, "if_exists") {
 		if len(rowMap) == 0 {
 			return false
 		}
-		_, ok := rowMap[colName]
-		if ok {
-			return true
-		}
-		return false
+		_, ok := attributeValue(rowMap, colName)
+		return ok
 	}
-	return rowMap[conditionalExpression]
+	v, _ := attributeValue(rowMap, conditionalExpression)
+	return v
 }
 
-func (s Storage) performPutOperation(ctx context.Context, t *spanner.ReadWriteTransaction, table string, m map[string]interface{}) error {
-	ddl := models.TableDDL[table]
-	for k, v := range m {
-		t, ok := ddl[k]
-		if t == "BYTES(MAX)" && ok {
-			ba, err := json.Marshal(v)
-			if err != nil {
-				return errors.New("ValidationException", err)
+// topLevelAttribute returns the column holding the attribute of a path such
+// as profile.verified or tags[1]
+func topLevelAttribute(path string) string {
+	return utils.AttributePath(path)[0]
+}
+
+// attributeValue returns the value of the attribute path in the row. The
+// elements of a path such as profile.verified or tags[1] are read from the
+// maps and lists decoded from the JSON of the column.
+func attributeValue(rowMap map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = rowMap
+	for _, name := range utils.AttributePath(path) {
+		if index, ok := utils.ListIndex(name); ok {
+			list, ok := value.([]interface{})
+			if !ok || index >= len(list) {
+				return nil, false
 			}
-			m[k] = ba
+			value = list[index]
+			continue
+		}
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
 		}
+		if value, ok = m[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func (s Storage) performPutOperation(ctx context.Context, t *spanner.ReadWriteTransaction, table string, key spanner.Key, m map[string]interface{}) error {
+	if err := marshalColumns(table, m); err != nil {
+		return err
+	}
+	if err := reviveTombstone(ctx, t, table, key, m); err != nil {
+		return err
 	}
 
 	mutation := spanner.InsertOrUpdateMap(table, m)
@@ -508,34 +729,65 @@ func (s Storage) performPutOperation(ctx context.Context, t *spanner.ReadWriteTr
 	return nil
 }
 
+// marshalColumns converts the attributes of m to the types of their columns,
+// marshalling the BYTES, encrypted and JSON columns
+func marshalColumns(table string, m map[string]interface{}) error {
+	ddl := models.TableDDL[table]
+	if err := coerceColumnTypes(ddl, m); err != nil {
+		return err
+	}
+	for k, v := range m {
+		t, ok := ddl[k]
+		if ok && (t == "BYTES(MAX)" || isEncryptedColumn(table, k)) {
+			ba, err := marshalColumn(table, k, v, encryptionKey(table, m))
+			if err != nil {
+				return err
+			}
+			m[k] = ba
+		} else if ok && t == jsonColumnType && v != nil {
+			doc, err := marshalJSONColumn(k, v)
+			if err != nil {
+				return err
+			}
+			m[k] = doc
+		}
+	}
+	return nil
+}
+
 // SpannerBatchPut - this insert or update data in batch
 func (s Storage) SpannerBatchPut(ctx context.Context, table string, m []map[string]interface{}) error {
-	mutations := make([]*spanner.Mutation, len(m))
-	ddl := models.TableDDL[changeTableNameForSP(table)]
+	defer observe(table, "batch_put", nil, time.Now())
+	softDelete := config.IsSoftDelete(table)
+	tableConf, _ := config.GetTableConf(table)
 	table = changeTableNameForSP(table)
-	for i := 0; i < len(m); i++ {
-		for k, v := range m[i] {
-			t, ok := ddl[k]
-			if t == "BYTES(MAX)" && ok {
-				ba, err := json.Marshal(v)
-				if err != nil {
-					return errors.New("ValidationException", err)
-				}
-				m[i][k] = ba
+	var err error
+	if softDelete || overflowColumn(table) != "" {
+		err = s.batchPutInTransaction(ctx, tableConf, table, m)
+	} else {
+		mutations := make([]*spanner.Mutation, len(m))
+		for i := 0; i < len(m); i++ {
+			if err := marshalColumns(table, m[i]); err != nil {
+				return err
 			}
+			mutations[i] = spanner.InsertOrUpdateMap(table, m[i])
 		}
-		mutations[i] = spanner.InsertOrUpdateMap(table, m[i])
+		err = s.applyMutations(ctx, table, mutations)
 	}
-	_, err := s.getSpannerClient(table).Apply(ctx, mutations)
 	if err != nil {
-		return errors.New("ResourceNotFoundException", err.Error())
+		return errors.FromSpanner(err, "batch put on", table, "failed")
 	}
 	return nil
 }
 
 // SpannerDelete - this will delete the data
 func (s Storage) SpannerDelete(ctx context.Context, table string, m map[string]interface{}, eval *models.Eval, expr *models.UpdateExpressionCondition) error {
-	_, err := s.getSpannerClient(table).ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
+	defer observe(table, "delete", nil, time.Now())
+	client, err := s.getWriteClient(table)
+	if err != nil {
+		return err
+	}
+	_, err = client.ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
 		tmpMap := map[string]interface{}{}
 		for k, v := range m {
 			tmpMap[k] = v
@@ -573,7 +825,7 @@ func (s Storage) SpannerDelete(ctx context.Context, table string, m map[string]i
 			key = spanner.Key{pValue}
 		}
 
-		mutation := spanner.Delete(table, key)
+		mutation := deleteMutation(table, tableConf, tmpMap, key)
 		err = t.BufferWrite([]*spanner.Mutation{mutation})
 		if e := errors.AssignError(err); e != nil {
 			return e
@@ -585,6 +837,7 @@ func (s Storage) SpannerDelete(ctx context.Context, table string, m map[string]i
 
 // SpannerBatchDelete - this delete the data in batch
 func (s Storage) SpannerBatchDelete(ctx context.Context, table string, keys []map[string]interface{}) error {
+	defer observe(table, "batch_delete", nil, time.Now())
 	tableConf, err := config.GetTableConf(table)
 	if err != nil {
 		return err
@@ -611,303 +864,472 @@ func (s Storage) SpannerBatchDelete(ctx context.Context, table string, keys []ma
 		} else {
 			key = spanner.Key{pValue}
 		}
-		ms[i] = spanner.Delete(table, key)
+		ms[i] = deleteMutation(table, tableConf, m, key)
 	}
-	_, err = s.getSpannerClient(table).Apply(ctx, ms)
+	err = s.applyMutations(ctx, table, ms)
 	if err != nil {
-		return errors.New("ResourceNotFoundException", err)
+		return errors.FromSpanner(err, "batch delete on", table, "failed")
 	}
 	return nil
 }
 
-// SpannerAdd - Spanner Add functionality like update attribute
+// SpannerConditionalDelete deletes the keys whose condition holds in one
+// read-write transaction. It returns the error of every key, nil for the
+// deleted ones, so a failed condition only skips its own key. The error is
+// the one of the transaction, when it could not be committed.
+func (s Storage) SpannerConditionalDelete(ctx context.Context, table string, keys []map[string]interface{}, evals []*models.Eval) ([]error, error) {
+	defer observe(table, "conditional_delete", nil, time.Now())
+	tableConf, err := config.GetTableConf(table)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.getWriteClient(table)
+	if err != nil {
+		return nil, err
+	}
+	pKey, sKey := tableConf.PartitionKey, tableConf.SortKey
+	var errs []error
+	_, err = client.ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
+		// the results of an aborted attempt are dropped with it
+		errs = make([]error, len(keys))
+		ms := []*spanner.Mutation{}
+		for i, m := range keys {
+			pValue, ok := m[pKey]
+			if !ok {
+				errs[i] = errors.New("ValidationException", "missing key attribute", originalColumn(pKey)).WithParameter(originalColumn(pKey))
+				continue
+			}
+			key := spanner.Key{pValue}
+			if sKey != "" {
+				sValue, ok := m[sKey]
+				if !ok {
+					errs[i] = errors.New("ValidationException", "missing key attribute", originalColumn(sKey)).WithParameter(originalColumn(sKey))
+					continue
+				}
+				key = spanner.Key{pValue, sValue}
+			}
+			if e := evals[i]; e != nil && len(e.Attributes) > 0 {
+				status, err := evaluateConditionalExpression(ctx, t, table, m, e, nil)
+				if spanner.ErrCode(err) == codes.Aborted {
+					return err
+				}
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				if !status {
+					errs[i] = errors.New("ConditionalCheckFailedException", m)
+					continue
+				}
+			}
+			ms = append(ms, deleteMutation(changeTableNameForSP(table), tableConf, m, key))
+		}
+		return t.BufferWrite(ms)
+	})
+	if err != nil {
+		return nil, errors.FromSpanner(err, "conditional delete on", table, "failed")
+	}
+	return errs, nil
+}
+
+// SpannerAdd - Spanner Add functionality like update attribute, which returns
+// the whole item after the update
 func (s Storage) SpannerAdd(ctx context.Context, table string, m map[string]interface{}, eval *models.Eval, expr *models.UpdateExpressionCondition) (map[string]interface{}, error) {
+	defer observe(table, "add", nil, time.Now())
 	tableConf, err := config.GetTableConf(table)
 	if err != nil {
 		return nil, err
 	}
-	colDLL, ok := models.TableDDL[changeTableNameForSP(table)]
-	if !ok {
+	if _, ok := models.TableDDL[changeTableNameForSP(table)]; !ok {
 		return nil, errors.New("ResourceNotFoundException", table)
 	}
-	pKey := tableConf.PartitionKey
-	var pValue interface{}
-	var sValue interface{}
-	sKey := tableConf.SortKey
-
-	cols := []string{}
-	var key spanner.Key
+	key, cols := primaryKey(tableConf, m)
 	var m1 = make(map[string]interface{})
-
 	for k, v := range m {
 		m1[k] = v
-		if k == pKey {
-			pValue = v
-			delete(m, k)
-			continue
-		}
-		if k == sKey {
-			delete(m, k)
-			sValue = v
-			continue
-		}
-		cols = append(cols, k)
-	}
-	if sValue != nil {
-		key = spanner.Key{pValue, sValue}
-	} else {
-		key = spanner.Key{pValue}
 	}
+	delete(m, tableConf.PartitionKey)
+	delete(m, tableConf.SortKey)
+
 	updatedObj := map[string]interface{}{}
-	_, err = s.getSpannerClient(table).ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
+	client, err := s.getWriteClient(table)
+	if err != nil {
+		return nil, err
+	}
+	_, err = client.ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
 		tmpMap := map[string]interface{}{}
 		for k, v := range m1 {
 			tmpMap[k] = v
 		}
 		if len(eval.Attributes) > 0 || expr != nil {
-			status, _ := evaluateConditionalExpression(ctx, t, table, tmpMap, eval, expr)
+			status, err := evaluateConditionalExpression(ctx, t, table, tmpMap, eval, expr)
+			if err != nil {
+				return err
+			}
 			if !status {
 				return errors.New("ConditionalCheckFailedException")
 			}
 		}
 		table = changeTableNameForSP(table)
-
-		r, err := t.ReadRow(ctx, table, key, cols)
-		if err != nil {
-			return errors.New("ResourceNotFoundException", err)
-		}
-		rs, err := parseRowForNull(r, colDLL, cols)
+		old, err := readItem(ctx, t, table, key, tableConf.SoftDelete)
 		if err != nil {
 			return err
 		}
-		for k, v := range tmpMap {
-			v1, ok := rs[k]
-			if ok {
-				switch v1.(type) {
-				case int64:
-					v2, ok := v.(float64)
-					if !ok {
-						strV, ok := v.(string)
-						if !ok {
-							return errors.New("ValidationException", reflect.TypeOf(v).String())
-						}
-						v2, err = strconv.ParseFloat(strV, 64)
-						if err != nil {
-							return errors.New("ValidationException", reflect.TypeOf(v).String())
-						}
-						err = checkInifinty(v2, strV)
-						if err != nil {
-							return err
-						}
-					}
-					tmpMap[k] = v1.(int64) + int64(v2)
-					err = checkInifinty(float64(m[k].(int64)), m)
-					if err != nil {
-						return err
-					}
-				case float64:
-					v2, ok := v.(float64)
-					if !ok {
-						strV, ok := v.(string)
-						if !ok {
-							return errors.New("ValidationException", reflect.TypeOf(v).String())
-						}
-						v2, err = strconv.ParseFloat(strV, 64)
-						if err != nil {
-							return errors.New("ValidationException", reflect.TypeOf(v).String())
-						}
-						err = checkInifinty(v2, strV)
-						if err != nil {
-							return err
-						}
-					}
-					tmpMap[k] = v1.(float64) + v2
-					err = checkInifinty(m[k].(float64), m)
-					if err != nil {
-						return err
-					}
-
-				case []interface{}:
-					var ifaces1 []interface{}
-					ba, ok := v.([]byte)
-					if ok {
-						json.Unmarshal(ba, &ifaces1)
-					} else {
-						ifaces1 = v.([]interface{})
-					}
-					m1 := map[interface{}]struct{}{}
-					ifaces := v1.([]interface{})
-					for i := 0; i < len(ifaces); i++ {
-						m1[ifaces[i]] = struct{}{}
-					}
-					for i := 0; i < len(ifaces1); i++ {
-						m1[ifaces1[i]] = struct{}{}
-					}
-					ifaces = []interface{}{}
-					for k := range m1 {
-						ifaces = append(ifaces, k)
-					}
-					tmpMap[k] = ifaces
-				default:
-					logger.LogDebug(reflect.TypeOf(v).String())
-				}
-			}
-		}
-		tmpMap[pKey] = pValue
-		if sValue != nil {
-			tmpMap[sKey] = sValue
-		}
-		ddl := models.TableDDL[table]
-
-		for k, v := range tmpMap {
-			updatedObj[k] = v
-			t, ok := ddl[k]
-			if t == "BYTES(MAX)" && ok {
-				ba, err := json.Marshal(v)
-				if err != nil {
-					return errors.New("ValidationException", err)
-				}
-				tmpMap[k] = ba
-			}
+		if err := addToRow(ctx, t, table, key, cols, tmpMap); err != nil {
+			return err
 		}
-
-		mutation := spanner.InsertOrUpdateMap(table, tmpMap)
-		err = t.BufferWrite([]*spanner.Mutation{mutation})
-		if err != nil {
-			return errors.New("ResourceNotFoundException", err)
+		updatedObj = mergeItem(old, tmpMap)
+		if err := mergeOverflow(ctx, t, table, key, tmpMap); err != nil {
+			return err
 		}
-		return nil
+		return bufferRow(ctx, t, table, key, tmpMap)
 	})
 	return updatedObj, err
 }
 
 // SpannerDel for delete operation on Spanner
 func (s Storage) SpannerDel(ctx context.Context, table string, m map[string]interface{}, eval *models.Eval, expr *models.UpdateExpressionCondition) error {
+	defer observe(table, "delete_from_set", nil, time.Now())
 	tableConf, err := config.GetTableConf(table)
 	if err != nil {
 		return err
 	}
-	colDLL, ok := models.TableDDL[changeTableNameForSP(table)]
-	if !ok {
+	if _, ok := models.TableDDL[changeTableNameForSP(table)]; !ok {
 		return errors.New("ResourceNotFoundException", table)
 	}
-	pKey := tableConf.PartitionKey
-	var pValue interface{}
-	var sValue interface{}
-	sKey := tableConf.SortKey
-
-	cols := []string{}
-	var key spanner.Key
+	key, cols := primaryKey(tableConf, m)
 	var m1 = make(map[string]interface{})
-
 	for k, v := range m {
 		m1[k] = v
-		if k == pKey {
-			pValue = v
-			delete(m, k)
-			continue
-		}
-		if k == sKey {
-			delete(m, k)
-			sValue = v
-			continue
-		}
-		cols = append(cols, k)
 	}
-	if sValue != nil {
-		key = spanner.Key{pValue, sValue}
-	} else {
-		key = spanner.Key{pValue}
+	delete(m, tableConf.PartitionKey)
+	delete(m, tableConf.SortKey)
+
+	client, err := s.getWriteClient(table)
+	if err != nil {
+		return err
 	}
-	_, err = s.getSpannerClient(table).ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
+	_, err = client.ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
 		tmpMap := map[string]interface{}{}
-		for k, v := range m {
+		for k, v := range m1 {
 			tmpMap[k] = v
 		}
 		if len(eval.Attributes) > 0 || expr != nil {
-			status, _ := evaluateConditionalExpression(ctx, t, table, m1, eval, expr)
+			status, err := evaluateConditionalExpression(ctx, t, table, m1, eval, expr)
+			if err != nil {
+				return err
+			}
 			if !status {
 				return errors.New("ConditionalCheckFailedException")
 			}
 		}
 		table = changeTableNameForSP(table)
-
-		r, err := t.ReadRow(ctx, table, key, cols)
-		if err != nil {
-			return errors.New("ResourceNotFoundException", err)
+		if err := deleteFromRow(ctx, t, table, key, cols, tmpMap); err != nil {
+			return err
 		}
-		rs, err := parseRowForNull(r, colDLL, cols)
-		if err != nil {
+		if err := mergeOverflow(ctx, t, table, key, tmpMap); err != nil {
 			return err
 		}
-		for k, v := range tmpMap {
-			v1, ok := rs[k]
-			if ok {
-				switch v1.(type) {
-				case []interface{}:
-					var ifaces1 []interface{}
-					ba, ok := v.([]byte)
-					if ok {
+		return bufferRow(ctx, t, table, key, tmpMap)
+	})
+	return err
+}
 
-						json.Unmarshal(ba, &ifaces1)
-					} else {
-						ifaces1 = v.([]interface{})
-					}
-					m1 := map[interface{}]struct{}{}
-					ifaces := v1.([]interface{})
-					for i := 0; i < len(ifaces); i++ {
-						m1[reflect.ValueOf(ifaces[i]).Interface()] = struct{}{}
-					}
-					for i := 0; i < len(ifaces1); i++ {
+// primaryKey returns the spanner key of the row and the non key columns of m
+func primaryKey(tableConf models.TableConfig, m map[string]interface{}) (spanner.Key, []string) {
+	cols := []string{}
+	for k := range m {
+		if k == tableConf.PartitionKey || k == tableConf.SortKey {
+			continue
+		}
+		cols = append(cols, k)
+	}
+	if sValue, ok := m[tableConf.SortKey]; ok && sValue != nil {
+		return spanner.Key{m[tableConf.PartitionKey], sValue}, cols
+	}
+	return spanner.Key{m[tableConf.PartitionKey]}, cols
+}
 
-						delete(m1, reflect.ValueOf(ifaces1[i]).Interface())
-					}
-					ifaces = []interface{}{}
-					for k := range m1 {
-						ifaces = append(ifaces, k)
-					}
-					tmpMap[k] = ifaces
-				default:
-					logger.LogDebug(reflect.TypeOf(v).String())
+// coerceColumnTypes checks the values of m against the types of their Spanner
+// columns, which are authoritative. Numbers are converted to int64 for INT64
+// columns when they are integral, BYTES(MAX) columns take any value, JSON
+// columns take maps and lists and every column takes NULL.
+func coerceColumnTypes(ddl map[string]string, m map[string]interface{}) error {
+	for k, v := range m {
+		colType, ok := ddl[k]
+		if !ok || v == nil {
+			continue
+		}
+		got := attributeType(v)
+		if got == "" {
+			continue
+		}
+		var want string
+		switch colType {
+		case "STRING(MAX)":
+			want = "S"
+		case "FLOAT64":
+			want = "N"
+		case "BOOL":
+			want = "BOOL"
+		case jsonColumnType:
+			if got == "M" || got == "L" {
+				continue
+			}
+			want = "M or L"
+		case "INT64":
+			want = "N"
+			if f, ok := v.(float64); ok {
+				if f != math.Trunc(f) || f >= 1<<63 || f < -1<<63 {
+					return errors.New("ValidationException", "One or more parameter values were invalid: Type mismatch for attribute "+originalColumn(k)+": the Spanner column is INT64, which takes integral numbers, got "+strconv.FormatFloat(f, 'f', -1, 64)).WithParameter(originalColumn(k))
 				}
+				m[k] = int64(f)
 			}
+		default:
+			continue
 		}
-		tmpMap[pKey] = pValue
-		if sValue != nil {
-			tmpMap[sKey] = sValue
+		if got != want {
+			return errors.New("ValidationException", "One or more parameter values were invalid: Type mismatch for attribute "+originalColumn(k)+": the Spanner column is "+colType+", which takes "+want+", got "+got).WithParameter(originalColumn(k))
 		}
-		ddl := models.TableDDL[table]
+	}
+	return nil
+}
 
-		for k, v := range tmpMap {
-			t, ok := ddl[k]
-			if t == "BYTES(MAX)" && ok {
-				ba, err := json.Marshal(v)
-				if err != nil {
-					return errors.New("ValidationException", err)
-				}
-				tmpMap[k] = ba
+// attributeType returns the DynamoDB type of a converted attribute value
+func attributeType(v interface{}) string {
+	switch v.(type) {
+	case string:
+		return "S"
+	case float64, int64:
+		return "N"
+	case bool:
+		return "BOOL"
+	case []byte:
+		return "B"
+	case map[string]interface{}:
+		return "M"
+	case []interface{}:
+		return "L"
+	}
+	return ""
+}
+
+// originalColumn returns the DynamoDB attribute name of a Spanner column
+func originalColumn(col string) string {
+	if original, ok := models.OriginalColResponse[col]; ok {
+		return original
+	}
+	return col
+}
+
+// readRowForUpdate reads the row to update, which is nil when the row does not
+// exist yet or is soft deleted so the update inserts it
+func readRowForUpdate(ctx context.Context, t *spanner.ReadWriteTransaction, table string, key spanner.Key, cols []string) (*spanner.Row, error) {
+	readCols := readColumns(table, cols)
+	if hasTombstones(table) {
+		readCols = withTombstoneColumn(readCols)
+	}
+	r, err := t.ReadRow(ctx, table, key, readCols)
+	if err == nil && isTombstone(r) {
+		return nil, nil
+	}
+	if spanner.ErrCode(err) == codes.NotFound {
+		return nil, nil
+	}
+	if spanner.ErrCode(err) == codes.Aborted {
+		// the transaction is retried by the client
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.New("ResourceNotFoundException", err)
+	}
+	return r, nil
+}
+
+// addToRow adds the numbers and sets of m to the values stored in the row
+func addToRow(ctx context.Context, t *spanner.ReadWriteTransaction, table string, key spanner.Key, cols []string, m map[string]interface{}) error {
+	r, err := readRowForUpdate(ctx, t, table, key, cols)
+	if err != nil {
+		return err
+	}
+	rs, err := parseRowForNull(table, r, models.TableDDL[table], cols)
+	if err != nil {
+		return err
+	}
+	for k, v := range m {
+		v1, ok := rs[k]
+		if !ok {
+			continue
+		}
+		switch v1.(type) {
+		case int64:
+			v2, err := addValue(v)
+			if err != nil {
+				return err
+			}
+			m[k] = v1.(int64) + int64(v2)
+			err = checkInifinty(float64(m[k].(int64)), m)
+			if err != nil {
+				return err
+			}
+		case float64:
+			v2, err := addValue(v)
+			if err != nil {
+				return err
+			}
+			m[k] = v1.(float64) + v2
+			err = checkInifinty(m[k].(float64), m)
+			if err != nil {
+				return err
+			}
+		case []interface{}:
+			var ifaces1 []interface{}
+			ba, ok := v.([]byte)
+			if ok {
+				json.Unmarshal(ba, &ifaces1)
+			} else {
+				ifaces1 = v.([]interface{})
 			}
+			m1 := map[interface{}]struct{}{}
+			ifaces := v1.([]interface{})
+			for i := 0; i < len(ifaces); i++ {
+				m1[ifaces[i]] = struct{}{}
+			}
+			for i := 0; i < len(ifaces1); i++ {
+				m1[ifaces1[i]] = struct{}{}
+			}
+			ifaces = []interface{}{}
+			for k := range m1 {
+				ifaces = append(ifaces, k)
+			}
+			m[k] = ifaces
+		default:
+			logger.LogDebug(reflect.TypeOf(v).String())
 		}
-		mutation := spanner.InsertOrUpdateMap(table, tmpMap)
-		err = t.BufferWrite([]*spanner.Mutation{mutation})
-		if err != nil {
-			return errors.New("ResourceNotFoundException", err)
+	}
+	return nil
+}
+
+// addValue returns the number to add, which is either a float or a numeric string
+func addValue(v interface{}) (float64, error) {
+	v2, ok := v.(float64)
+	if ok {
+		return v2, nil
+	}
+	strV, ok := v.(string)
+	if !ok {
+		return 0, errors.New("ValidationException", reflect.TypeOf(v).String())
+	}
+	v2, err := strconv.ParseFloat(strV, 64)
+	if err != nil {
+		return 0, errors.New("ValidationException", reflect.TypeOf(v).String())
+	}
+	return v2, checkInifinty(v2, strV)
+}
+
+// deleteFromRow removes the set elements of m from the sets stored in the row
+func deleteFromRow(ctx context.Context, t *spanner.ReadWriteTransaction, table string, key spanner.Key, cols []string, m map[string]interface{}) error {
+	r, err := readRowForUpdate(ctx, t, table, key, cols)
+	if err != nil {
+		return err
+	}
+	rs, err := parseRowForNull(table, r, models.TableDDL[table], cols)
+	if err != nil {
+		return err
+	}
+	for k, v := range m {
+		v1, ok := rs[k]
+		if !ok {
+			continue
 		}
-		return nil
-	})
-	return err
+		switch v1.(type) {
+		case []interface{}:
+			var ifaces1 []interface{}
+			ba, ok := v.([]byte)
+			if ok {
+				json.Unmarshal(ba, &ifaces1)
+			} else {
+				ifaces1 = v.([]interface{})
+			}
+			m1 := map[interface{}]struct{}{}
+			ifaces := v1.([]interface{})
+			for i := 0; i < len(ifaces); i++ {
+				m1[reflect.ValueOf(ifaces[i]).Interface()] = struct{}{}
+			}
+			for i := 0; i < len(ifaces1); i++ {
+				delete(m1, reflect.ValueOf(ifaces1[i]).Interface())
+			}
+			ifaces = []interface{}{}
+			for k := range m1 {
+				ifaces = append(ifaces, k)
+			}
+			m[k] = ifaces
+		default:
+			logger.LogDebug(reflect.TypeOf(v).String())
+		}
+	}
+	return nil
 }
 
-// SpannerRemove - Spanner Remove functionality like update attribute
-func (s Storage) SpannerRemove(ctx context.Context, table string, m map[string]interface{}, eval *models.Eval, expr *models.UpdateExpressionCondition, colsToRemove []string) error {
+// bufferRow marshals the BYTES(MAX), JSON and encrypted columns of m and buffers
+// the insert or update of the row
+func bufferRow(ctx context.Context, t *spanner.ReadWriteTransaction, table string, key spanner.Key, m map[string]interface{}) error {
+	ddl := models.TableDDL[table]
+	if err := coerceColumnTypes(ddl, m); err != nil {
+		return err
+	}
+	for k, v := range m {
+		colType, ok := ddl[k]
+		if v != nil && ok && (colType == "BYTES(MAX)" || isEncryptedColumn(table, k)) {
+			ba, err := marshalColumn(table, k, v, encryptionKey(table, m))
+			if err != nil {
+				return err
+			}
+			m[k] = ba
+		} else if v != nil && ok && colType == jsonColumnType {
+			doc, err := marshalJSONColumn(k, v)
+			if err != nil {
+				return err
+			}
+			m[k] = doc
+		}
+	}
+	if err := reviveTombstone(ctx, t, table, key, m); err != nil {
+		return err
+	}
+	mutation := spanner.InsertOrUpdateMap(table, m)
+	err := t.BufferWrite([]*spanner.Mutation{mutation})
+	if err != nil {
+		return errors.New("ResourceNotFoundException", err)
+	}
+	return nil
+}
+
+// SpannerRemove - Spanner Remove functionality like update attribute, which
+// returns the whole item after the update
+func (s Storage) SpannerRemove(ctx context.Context, table string, m map[string]interface{}, eval *models.Eval, expr *models.UpdateExpressionCondition, colsToRemove []string) (map[string]interface{}, error) {
+	defer observe(table, "remove", nil, time.Now())
+	tableConf, err := config.GetTableConf(table)
+	if err != nil {
+		return nil, err
+	}
+	key, _ := primaryKey(tableConf, m)
 
-	_, err := s.getSpannerClient(table).ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
+	var updatedObj map[string]interface{}
+	client, err := s.getWriteClient(table)
+	if err != nil {
+		return nil, err
+	}
+	_, err = client.ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
 		tmpMap := map[string]interface{}{}
 		for k, v := range m {
 			tmpMap[k] = v
 		}
 		if len(eval.Attributes) > 0 || expr != nil {
-			status, _ := evaluateConditionalExpression(ctx, t, table, m, eval, expr)
+			status, err := evaluateConditionalExpression(ctx, t, table, m, eval, expr)
+			if err != nil {
+				return err
+			}
 			if !status {
 				return errors.New("ConditionalCheckFailedException")
 			}
@@ -917,16 +1339,275 @@ func (s Storage) SpannerRemove(ctx context.Context, table string, m map[string]i
 			tmpMap[col] = null
 		}
 		table = changeTableNameForSP(table)
-		mutation := spanner.InsertOrUpdateMap(table, tmpMap)
-		err := t.BufferWrite([]*spanner.Mutation{mutation})
+		old, err := readItem(ctx, t, table, key, tableConf.SoftDelete)
 		if err != nil {
-			return errors.New("ResourceNotFoundException", err)
+			return err
 		}
-		return nil
+		updatedObj = mergeItem(old, tmpMap)
+		if err := mergeOverflow(ctx, t, table, key, tmpMap); err != nil {
+			return err
+		}
+		return bufferRow(ctx, t, table, key, tmpMap)
+	})
+	return updatedObj, err
+}
+
+// SpannerUpdate - Spanner update which applies all the actions of an update
+// expression to the row in a single transaction. The actions update distinct
+// attributes, so they are merged into one mutation of the row. The whole item
+// after the update is returned.
+func (s Storage) SpannerUpdate(ctx context.Context, table string, keyMap map[string]interface{}, eval *models.Eval, actions []models.UpdateAction) (map[string]interface{}, error) {
+	defer observe(table, "update", nil, time.Now())
+	tableConf, err := config.GetTableConf(table)
+	if err != nil {
+		return nil, err
+	}
+	spTable := changeTableNameForSP(table)
+	if _, ok := models.TableDDL[spTable]; !ok {
+		return nil, errors.New("ResourceNotFoundException", table)
+	}
+	key, _ := primaryKey(tableConf, keyMap)
+	var updatedObj map[string]interface{}
+	client, err := s.getWriteClient(table)
+	if err != nil {
+		return nil, err
+	}
+	_, err = client.ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
+		old, err := readItem(ctx, t, spTable, key, tableConf.SoftDelete)
+		if err != nil {
+			return err
+		}
+		row := map[string]interface{}{}
+		for k, v := range keyMap {
+			row[k] = v
+		}
+		// the condition is evaluated once against the stored row
+		cond := eval
+		for _, action := range actions {
+			tmpMap := map[string]interface{}{}
+			for k, v := range action.Attributes {
+				tmpMap[k] = v
+			}
+			for k, v := range keyMap {
+				tmpMap[k] = v
+			}
+			if len(cond.Attributes) > 0 || action.Expr != nil {
+				status, err := evaluateConditionalExpression(ctx, t, table, tmpMap, cond, action.Expr)
+				if err != nil {
+					return err
+				}
+				if !status {
+					return errors.New("ConditionalCheckFailedException", eval, action.Expr)
+				}
+			}
+			cond = &models.Eval{}
+			_, cols := primaryKey(tableConf, tmpMap)
+			switch action.Action {
+			case "ADD":
+				if err := addToRow(ctx, t, spTable, key, cols, tmpMap); err != nil {
+					return err
+				}
+			case "DELETE":
+				if err := deleteFromRow(ctx, t, spTable, key, cols, tmpMap); err != nil {
+					return err
+				}
+			case "REMOVE":
+				var null spanner.NullableValue
+				for _, col := range action.ColsToRemove {
+					tmpMap[col] = null
+				}
+			}
+			for k, v := range tmpMap {
+				row[k] = v
+			}
+		}
+		updatedObj = mergeItem(old, row)
+		if err := mergeOverflow(ctx, t, spTable, key, row); err != nil {
+			return err
+		}
+		return bufferRow(ctx, t, spTable, key, row)
+	})
+	return updatedObj, err
+}
+
+// SpannerTableColumns - this returns the spanner type of every column of the table
+// from the information schema, which is empty when the table does not exist
+func (s Storage) SpannerTableColumns(ctx context.Context, table string) (map[string]string, error) {
+	table = changeTableNameForSP(table)
+	stmt := spanner.Statement{
+		SQL:    "SELECT COLUMN_NAME, SPANNER_TYPE FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = '' AND TABLE_NAME = @table",
+		Params: map[string]interface{}{"table": table},
+	}
+	itr := s.getSpannerClient(table).Single().Query(ctx, stmt)
+	defer itr.Stop()
+	cols := map[string]string{}
+	for {
+		r, err := itr.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, errors.New("ResourceNotFoundException", err)
+		}
+		var col, colType string
+		if err := r.Columns(&col, &colType); err != nil {
+			return nil, errors.New("ResourceNotFoundException", err)
+		}
+		cols[col] = colType
+	}
+	return cols, nil
+}
+
+// SpannerPurgeTombstones - this removes soft deleted rows which were deleted before the given time
+func (s Storage) SpannerPurgeTombstones(ctx context.Context, table string, before time.Time) (int64, error) {
+	table = changeTableNameForSP(table)
+	stmt := spanner.Statement{
+		SQL:    "DELETE FROM " + table + " WHERE " + models.SoftDeleteColumn + " = true AND " + models.SoftDeleteTimeColumn + " < @before",
+		Params: map[string]interface{}{"before": before.UnixNano()},
+	}
+	client, err := s.getWriteClient(table)
+	if err != nil {
+		return 0, err
+	}
+	count, err := client.PartitionedUpdate(ctx, stmt)
+	if err != nil {
+		return 0, errors.New("ResourceNotFoundException", err)
+	}
+	return count, nil
+}
+
+// deleteMutation deletes the row, or only marks it deleted for SoftDelete tables
+func deleteMutation(table string, tableConf models.TableConfig, m map[string]interface{}, key spanner.Key) *spanner.Mutation {
+	if !tableConf.SoftDelete {
+		return spanner.Delete(table, key)
+	}
+	tombstone := map[string]interface{}{
+		tableConf.PartitionKey:      m[tableConf.PartitionKey],
+		models.SoftDeleteColumn:     true,
+		models.SoftDeleteTimeColumn: time.Now().UnixNano(),
+	}
+	if tableConf.SortKey != "" {
+		tombstone[tableConf.SortKey] = m[tableConf.SortKey]
+	}
+	return spanner.InsertOrUpdateMap(table, tombstone)
+}
+
+// hasTombstones checks if the rows of the table have the tombstone columns
+func hasTombstones(table string) bool {
+	_, ok := models.TableDDL[table][models.SoftDeleteColumn]
+	return ok
+}
+
+// isTombstone checks if the row read with the tombstone flag is soft deleted
+func isTombstone(r *spanner.Row) bool {
+	var deleted spanner.NullBool
+	if err := r.ColumnByName(models.SoftDeleteColumn, &deleted); err != nil {
+		return false
+	}
+	return deleted.Valid && deleted.Bool
+}
+
+// readTombstone checks if the stored row of the key is soft deleted
+func readTombstone(ctx context.Context, t *spanner.ReadWriteTransaction, table string, key spanner.Key) (bool, error) {
+	if !hasTombstones(table) {
+		return false, nil
+	}
+	r, err := t.ReadRow(ctx, table, key, []string{models.SoftDeleteColumn})
+	if spanner.ErrCode(err) == codes.NotFound {
+		return false, nil
+	}
+	if spanner.ErrCode(err) == codes.Aborted {
+		// the transaction is retried by the client
+		return false, err
+	}
+	if err != nil {
+		return false, errors.New("ResourceNotFoundException", err)
+	}
+	return isTombstone(r), nil
+}
+
+// reviveTombstone brings the stored row of the key back to life when it is
+// soft deleted and written again with m
+func reviveTombstone(ctx context.Context, t *spanner.ReadWriteTransaction, table string, key spanner.Key, m map[string]interface{}) error {
+	deleted, err := readTombstone(ctx, t, table, key)
+	if err != nil || !deleted {
+		return err
+	}
+	clearTombstone(table, m)
+	return nil
+}
+
+// clearTombstone clears the tombstone of a soft deleted row written again with
+// m. The columns m leaves out are nulled, so that the attributes of the deleted
+// item do not come back with the new one. The columns which are no attributes,
+// the ones parseRowForNull skips, are left to Spanner.
+func clearTombstone(table string, m map[string]interface{}) {
+	for col := range models.TableDDL[table] {
+		if col == "" || col == "commit_timestamp" {
+			continue
+		}
+		if _, ok := m[col]; !ok {
+			m[col] = nil
+		}
+	}
+	m[models.SoftDeleteColumn] = false
+	m[models.SoftDeleteTimeColumn] = spanner.NullInt64{}
+}
+
+// batchPutInTransaction writes the rows of a SoftDelete table or of a table
+// with an OverflowColumn in one transaction, which merges the overflow
+// attributes of the rows with the stored ones like a put does and clears the
+// tombstones of the rows written over soft deleted items
+func (s Storage) batchPutInTransaction(ctx context.Context, tableConf models.TableConfig, table string, rows []map[string]interface{}) error {
+	client, err := s.getWriteClient(table)
+	if err != nil {
+		return err
+	}
+	_, err = client.ReadWriteTransaction(ctx, func(ctx context.Context, t *spanner.ReadWriteTransaction) error {
+		mutations := make([]*spanner.Mutation, len(rows))
+		for i, row := range rows {
+			tmpMap := map[string]interface{}{}
+			for k, v := range row {
+				tmpMap[k] = v
+			}
+			key, _ := primaryKey(tableConf, tmpMap)
+			if err := mergeOverflow(ctx, t, table, key, tmpMap); err != nil {
+				return err
+			}
+			if err := marshalColumns(table, tmpMap); err != nil {
+				return err
+			}
+			if err := reviveTombstone(ctx, t, table, key, tmpMap); err != nil {
+				return err
+			}
+			mutations[i] = spanner.InsertOrUpdateMap(table, tmpMap)
+		}
+		return t.BufferWrite(mutations)
 	})
 	return err
 }
 
+// withTombstoneColumn adds the tombstone flag to the columns to read
+func withTombstoneColumn(cols []string) []string {
+	for _, col := range cols {
+		if col == models.SoftDeleteColumn {
+			return cols
+		}
+	}
+	withFlag := make([]string, 0, len(cols)+1)
+	withFlag = append(withFlag, cols...)
+	return append(withFlag, models.SoftDeleteColumn)
+}
+
+// hideTombstone removes the tombstone columns from the row and reports
+// whether the row has been soft deleted
+func hideTombstone(row map[string]interface{}) bool {
+	deleted, _ := row[models.SoftDeleteColumn].(bool)
+	delete(row, models.SoftDeleteColumn)
+	delete(row, models.SoftDeleteTimeColumn)
+	return deleted
+}
+
 func changeTableNameForSP(tableName string) string {
 	tableName = strings.ReplaceAll(tableName, "-", "_")
 	return tableName
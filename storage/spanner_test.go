@@ -0,0 +1,494 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/spannertest"
+	"cloud.google.com/go/spanner/spansql"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/models"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/errors"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/utils"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func init() {
+	models.TableColumnMap["users"] = []string{"user_id", "created_at", "name", "age"}
+}
+
+func TestConditionColumns(t *testing.T) {
+	tests := []struct {
+		testName string
+		eval     *models.Eval
+		expr     *models.UpdateExpressionCondition
+		want     []string
+	}{
+		{
+			"condition on sort key only",
+			&models.Eval{Cols: []string{"created_at"}},
+			nil,
+			[]string{"user_id", "created_at"},
+		},
+		{
+			"condition on unknown column",
+			&models.Eval{Cols: []string{"unknown"}},
+			nil,
+			[]string{"user_id", "created_at"},
+		},
+		{
+			"condition with update expression",
+			&models.Eval{Cols: []string{"name"}},
+			&models.UpdateExpressionCondition{Field: []string{"age"}},
+			[]string{"user_id", "created_at", "name", "age"},
+		},
+		{
+			"condition on nested attribute",
+			&models.Eval{Cols: []string{"name.first", "age[0]"}},
+			nil,
+			[]string{"user_id", "created_at", "name", "age"},
+		},
+	}
+
+	for _, tc := range tests {
+		got := conditionColumns("users", "user_id", "created_at", tc.eval, tc.expr)
+		assert.Equal(t, got, tc.want)
+	}
+}
+
+func TestEvaluateStatementFromRowMap(t *testing.T) {
+	existingRow := map[string]interface{}{"user_id": "u1", "created_at": "2020-01-01"}
+	nestedRow := map[string]interface{}{"user_id": "u1", "profile": map[string]interface{}{"verified": true, "tags": []interface{}{"a", "b"}}}
+	tests := []struct {
+		testName  string
+		condition string
+		col       string
+		rowMap    map[string]interface{}
+		want      interface{}
+	}{
+		{"insert if absent on composite key with no item", "attribute_not_exists(created_at)", "created_at", map[string]interface{}{}, true},
+		{"insert if absent on composite key with existing item", "attribute_not_exists(created_at)", "created_at", existingRow, false},
+		{"partition key existence with existing item", "attribute_exists(user_id)", "user_id", existingRow, true},
+		{"partition key existence with no item", "attribute_exists(user_id)", "user_id", map[string]interface{}{}, false},
+		{"non key attribute absent on existing item", "attribute_not_exists(name)", "name", existingRow, true},
+		{"nested attribute", "profile.verified", "profile.verified", nestedRow, true},
+		{"list element of nested attribute", "profile.tags[1]", "profile.tags[1]", nestedRow, "b"},
+		{"missing nested attribute", "profile.age", "profile.age", nestedRow, nil},
+		{"list element out of range", "profile.tags[2]", "profile.tags[2]", nestedRow, nil},
+		{"nested attribute exists", "attribute_exists(profile.verified)", "profile.verified", nestedRow, true},
+		{"nested attribute of a scalar", "attribute_exists(user_id.verified)", "user_id.verified", nestedRow, false},
+		{"nested attribute absent", "attribute_not_exists(profile.age)", "profile.age", nestedRow, true},
+	}
+
+	for _, tc := range tests {
+		got := evaluateStatementFromRowMap(tc.condition, tc.col, tc.rowMap)
+		assert.Equal(t, got, tc.want)
+	}
+}
+
+func TestNestedCondition(t *testing.T) {
+	tests := []struct {
+		testName string
+		rowMap   map[string]interface{}
+		want     bool
+	}{
+		{"verified", map[string]interface{}{"user_id": "u1", "profile": map[string]interface{}{"verified": true}}, true},
+		{"not verified", map[string]interface{}{"user_id": "u1", "profile": map[string]interface{}{"verified": false}}, false},
+		{"no profile", map[string]interface{}{"user_id": "u1"}, false},
+	}
+	for _, tc := range tests {
+		e, err := utils.CreateConditionExpression("profile.verified = :true", map[string]interface{}{":true": true})
+		assert.Equal(t, err, nil)
+		assert.Equal(t, e.Cols, []string{"profile.verified"})
+		for i := range e.Attributes {
+			e.ValueMap[e.Tokens[i]] = evaluateStatementFromRowMap(e.Attributes[i], e.Cols[i], tc.rowMap)
+		}
+		ok, err := utils.EvaluateExpression(e)
+		assert.Equal(t, ok, tc.want)
+		assert.Equal(t, err != nil, !tc.want)
+	}
+}
+
+func TestSortKeyCondition(t *testing.T) {
+	ddl := map[string]string{"user_id": "STRING(MAX)", "created_at": "STRING(MAX)", "name": "STRING(MAX)"}
+	tests := []struct {
+		testName string
+		now      string
+		row      []interface{}
+		want     bool
+	}{
+		{"newer write", "2020-06-01", []interface{}{"u1", "2020-01-01"}, true},
+		{"out of order write", "2019-06-01", []interface{}{"u1", "2020-01-01"}, false},
+		{"same timestamp", "2020-01-01", []interface{}{"u1", "2020-01-01"}, false},
+		{"no item", "2020-06-01", nil, false},
+	}
+	for _, tc := range tests {
+		e, err := utils.CreateConditionExpression("created_at < :now", map[string]interface{}{":now": tc.now})
+		assert.Equal(t, err, nil)
+		cols := conditionColumns("users", "user_id", "created_at", e, nil)
+		assert.Equal(t, cols, []string{"user_id", "created_at"})
+		var row *spanner.Row
+		if tc.row != nil {
+			row, err = spanner.NewRow(cols, tc.row)
+			assert.Equal(t, err, nil)
+		}
+		rowMap, err := createRowMap("users", row, ddl, cols)
+		assert.Equal(t, err, nil)
+		for i := range e.Attributes {
+			e.ValueMap[e.Tokens[i]] = evaluateStatementFromRowMap(e.Attributes[i], e.Cols[i], rowMap)
+		}
+		ok, _ := utils.EvaluateExpression(e)
+		assert.Equal(t, ok, tc.want)
+	}
+}
+
+func TestAllowedStatusCondition(t *testing.T) {
+	ddl := map[string]string{"order_id": "STRING(MAX)", "status": "STRING(MAX)"}
+	models.TableColumnMap["orders"] = []string{"order_id", "status"}
+	defer delete(models.TableColumnMap, "orders")
+	values := map[string]interface{}{":new": "NEW", ":paid": "PAID"}
+	tests := []struct {
+		testName string
+		row      []interface{}
+		want     bool
+	}{
+		{"allowed status", []interface{}{"o1", "PAID"}, true},
+		{"other status", []interface{}{"o1", "SHIPPED"}, false},
+		{"no item", nil, false},
+	}
+	for _, tc := range tests {
+		e, err := utils.CreateConditionExpression("status IN (:new, :paid)", values)
+		assert.Equal(t, err, nil)
+		cols := conditionColumns("orders", "order_id", "", e, nil)
+		assert.Equal(t, cols, []string{"order_id", "status"})
+		var row *spanner.Row
+		if tc.row != nil {
+			row, err = spanner.NewRow(cols, tc.row)
+			assert.Equal(t, err, nil)
+		}
+		rowMap, err := createRowMap("orders", row, ddl, cols)
+		assert.Equal(t, err, nil)
+		for i := range e.Attributes {
+			e.ValueMap[e.Tokens[i]] = evaluateStatementFromRowMap(e.Attributes[i], e.Cols[i], rowMap)
+		}
+		ok, _ := utils.EvaluateExpression(e)
+		assert.Equal(t, ok, tc.want)
+	}
+}
+
+func TestCoerceColumnTypes(t *testing.T) {
+	ddl := map[string]string{
+		"name":    "STRING(MAX)",
+		"age":     "INT64",
+		"score":   "FLOAT64",
+		"active":  "BOOL",
+		"profile": "BYTES(MAX)",
+	}
+	tests := []struct {
+		testName string
+		m        map[string]interface{}
+		want     map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			"matching types",
+			map[string]interface{}{"name": "a", "score": 1.5, "active": true, "profile": map[string]interface{}{"a": "b"}},
+			map[string]interface{}{"name": "a", "score": 1.5, "active": true, "profile": map[string]interface{}{"a": "b"}},
+			false,
+		},
+		{
+			"integral number for INT64 column",
+			map[string]interface{}{"age": float64(10)},
+			map[string]interface{}{"age": int64(10)},
+			false,
+		},
+		{
+			"NULL for any column",
+			map[string]interface{}{"name": nil, "age": nil},
+			map[string]interface{}{"name": nil, "age": nil},
+			false,
+		},
+		{
+			"string for INT64 column",
+			map[string]interface{}{"age": "10"},
+			nil,
+			true,
+		},
+		{
+			"fraction for INT64 column",
+			map[string]interface{}{"age": 10.5},
+			nil,
+			true,
+		},
+		{
+			"2^63 for INT64 column",
+			map[string]interface{}{"age": float64(1 << 63)},
+			nil,
+			true,
+		},
+		{
+			"-2^63 for INT64 column",
+			map[string]interface{}{"age": float64(-1 << 63)},
+			map[string]interface{}{"age": int64(-1 << 63)},
+			false,
+		},
+		{
+			"number for STRING column",
+			map[string]interface{}{"name": float64(1)},
+			nil,
+			true,
+		},
+		{
+			"list for BOOL column",
+			map[string]interface{}{"active": []interface{}{true}},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range tests {
+		err := coerceColumnTypes(ddl, tc.m)
+		if tc.wantErr {
+			assert.NotEqual(t, err, nil)
+			continue
+		}
+		assert.Equal(t, err, nil)
+		assert.Equal(t, tc.m, tc.want)
+	}
+}
+
+func TestMissingKeyParameter(t *testing.T) {
+	defer func() { config.DbConfigMap = map[string]models.TableConfig{} }()
+	defer delete(models.OriginalColResponse, "emp_id")
+	config.DbConfigMap = map[string]models.TableConfig{"renamed": {PartitionKey: "emp_id"}}
+	models.TableDDL["renamed"] = map[string]string{"emp_id": "STRING(MAX)"}
+	models.OriginalColResponse["emp_id"] = "emp-id"
+
+	_, err := evaluateConditionalExpression(context.Background(), nil, "renamed", map[string]interface{}{}, &models.Eval{}, nil)
+	assert.Equal(t, err.(*errors.Error).Parameter, "emp-id")
+}
+
+func TestCreateRowMapBinary(t *testing.T) {
+	ddl := map[string]string{"id": "STRING(MAX)", "recipients": "BYTES(MAX)"}
+	tests := []struct {
+		testName   string
+		recipients []byte
+		wantExists bool
+		want       interface{}
+	}{
+		{"binary value", []byte(`"AQID"`), true, "AQID"},
+		{"NULL value", nil, false, nil},
+	}
+
+	for _, tc := range tests {
+		row, err := spanner.NewRow([]string{"id", "recipients"}, []interface{}{"n1", tc.recipients})
+		assert.Equal(t, err, nil)
+		rowMap, err := createRowMap("orion_notification", row, ddl, nil)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, evaluateStatementFromRowMap("attribute_exists(recipients)", "recipients", rowMap), tc.wantExists)
+		assert.Equal(t, rowMap["recipients"], tc.want)
+	}
+}
+
+func TestCreateRowMapBool(t *testing.T) {
+	ddl := map[string]string{"id": "STRING(MAX)", "notification_read": "BOOL"}
+	tests := []struct {
+		testName   string
+		read       spanner.NullBool
+		wantExists bool
+		want       interface{}
+	}{
+		{"true value", spanner.NullBool{Bool: true, Valid: true}, true, true},
+		{"false value", spanner.NullBool{Bool: false, Valid: true}, true, false},
+		{"NULL value", spanner.NullBool{}, false, nil},
+	}
+
+	for _, tc := range tests {
+		row, err := spanner.NewRow([]string{"id", "notification_read"}, []interface{}{"n1", tc.read})
+		assert.Equal(t, err, nil)
+		rowMap, err := createRowMap("orion_notification", row, ddl, nil)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, evaluateStatementFromRowMap("attribute_exists(notification_read)", "notification_read", rowMap), tc.wantExists)
+		assert.Equal(t, rowMap["notification_read"], tc.want)
+	}
+}
+
+func TestMergeItem(t *testing.T) {
+	old := map[string]interface{}{"emp_id": float64(1), "first_name": "Marc", "age": float64(10)}
+	tests := []struct {
+		testName string
+		old      map[string]interface{}
+		update   map[string]interface{}
+		want     map[string]interface{}
+	}{
+		{
+			"update an existing item",
+			old,
+			map[string]interface{}{"emp_id": float64(1), "age": float64(11)},
+			map[string]interface{}{"emp_id": float64(1), "first_name": "Marc", "age": float64(11)},
+		},
+		{
+			"remove an attribute",
+			old,
+			map[string]interface{}{"emp_id": float64(1), "first_name": nil},
+			map[string]interface{}{"emp_id": float64(1), "age": float64(10)},
+		},
+		{
+			"upsert a new item",
+			map[string]interface{}{},
+			map[string]interface{}{"emp_id": float64(100), "first_name": "Ria"},
+			map[string]interface{}{"emp_id": float64(100), "first_name": "Ria"},
+		},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, mergeItem(tc.old, tc.update), tc.want)
+	}
+	assert.Equal(t, old, map[string]interface{}{"emp_id": float64(1), "first_name": "Marc", "age": float64(10)})
+}
+
+func TestQueryTimestampBound(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, queryTimestampBound(ctx), spanner.ExactStaleness(queryStaleness))
+	assert.Equal(t, queryTimestampBound(WithConsistentReads(ctx)), spanner.StrongRead())
+}
+
+// fakeSpanner returns a Storage whose clients use an in-memory Spanner with
+// the tables of ddl
+func fakeSpanner(t *testing.T, ddl string) (Storage, func()) {
+	srv, err := spannertest.NewServer("localhost:0")
+	assert.Equal(t, err, nil)
+	srv.SetLogger(func(string, ...interface{}) {})
+	parsed, err := spansql.ParseDDL("test", ddl)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, srv.UpdateDDL(parsed), nil)
+	conn, err := grpc.Dial(srv.Addr, grpc.WithInsecure())
+	assert.Equal(t, err, nil)
+	client, err := spanner.NewClient(context.Background(), "projects/test/instances/test/databases/test", option.WithGRPCConn(conn))
+	assert.Equal(t, err, nil)
+	s := Storage{spannerClient: map[string]*spanner.Client{"test": client}, failover: map[string]*failover{}}
+	return s, func() {
+		client.Close()
+		srv.Close()
+	}
+}
+
+func TestWriteSoftDeletedItemAgain(t *testing.T) {
+	s, stop := fakeSpanner(t, `CREATE TABLE notes (
+		id STRING(MAX) NOT NULL,
+		a STRING(MAX),
+		b STRING(MAX),
+		extra BYTES(MAX),
+		commit_timestamp TIMESTAMP OPTIONS (allow_commit_timestamp = true),
+		dynamodb_adapter_deleted BOOL,
+		dynamodb_adapter_deleted_at INT64,
+	) PRIMARY KEY (id)`)
+	defer stop()
+	defer func() { config.DbConfigMap = map[string]models.TableConfig{} }()
+	config.DbConfigMap = map[string]models.TableConfig{"notes": {PartitionKey: "id", SoftDelete: true, OverflowColumn: "extra"}}
+	models.SpannerTableMap["notes"] = "test"
+	models.TableDDL["notes"] = map[string]string{"id": "STRING(MAX)", "a": "STRING(MAX)", "b": "STRING(MAX)", "extra": "BYTES(MAX)", "commit_timestamp": "TIMESTAMP", models.SoftDeleteColumn: "BOOL", models.SoftDeleteTimeColumn: "INT64"}
+	models.TableColumnMap["notes"] = []string{"id", "a", "b", "extra"}
+	initOverflow()
+	defer initOverflow()
+	ctx := context.Background()
+	get := func() map[string]interface{} {
+		item, err := s.SpannerGet(ctx, "notes", "1", nil, nil)
+		assert.Equal(t, err, nil)
+		return item
+	}
+	del := func() {
+		assert.Equal(t, s.SpannerDelete(ctx, "notes", map[string]interface{}{"id": "1"}, &models.Eval{}, nil), nil)
+		assert.Equal(t, get(), map[string]interface{}{})
+	}
+	put := func(m map[string]interface{}) {
+		_, _, err := s.SpannerPut(ctx, "notes", m, &models.Eval{}, nil)
+		assert.Equal(t, err, nil)
+	}
+
+	put(map[string]interface{}{"id": "1", "a": "x", "b": "y", "big": "old"})
+	assert.Equal(t, get(), map[string]interface{}{"id": "1", "a": "x", "b": "y", "big": "old"})
+	del()
+	put(map[string]interface{}{"id": "1", "a": "z", "other": "new"})
+	assert.Equal(t, get(), map[string]interface{}{"id": "1", "a": "z", "other": "new"})
+	put(map[string]interface{}{"id": "1", "b": "y"})
+	assert.Equal(t, get(), map[string]interface{}{"id": "1", "a": "z", "b": "y", "other": "new"})
+
+	del()
+	revived := map[string]interface{}{"id": "1"}
+	clearTombstone("notes", revived)
+	assert.Equal(t, revived, map[string]interface{}{"id": "1", "a": nil, "b": nil, "extra": nil, models.SoftDeleteColumn: false, models.SoftDeleteTimeColumn: spanner.NullInt64{}})
+	assert.Equal(t, s.SpannerBatchPut(ctx, "notes", []map[string]interface{}{{"id": "1", "b": "w"}}), nil)
+	assert.Equal(t, get(), map[string]interface{}{"id": "1", "b": "w"})
+}
+
+func TestBatchPutKeepsOverflow(t *testing.T) {
+	s, stop := fakeSpanner(t, "CREATE TABLE events (id STRING(MAX) NOT NULL, a STRING(MAX), extra BYTES(MAX)) PRIMARY KEY (id)")
+	defer stop()
+	defer func() { config.DbConfigMap = map[string]models.TableConfig{} }()
+	config.DbConfigMap = map[string]models.TableConfig{"events": {PartitionKey: "id", OverflowColumn: "extra"}}
+	models.SpannerTableMap["events"] = "test"
+	models.TableDDL["events"] = map[string]string{"id": "STRING(MAX)", "a": "STRING(MAX)", "extra": "BYTES(MAX)"}
+	models.TableColumnMap["events"] = []string{"id", "a", "extra"}
+	initOverflow()
+	defer initOverflow()
+	ctx := context.Background()
+	get := func() map[string]interface{} {
+		item, err := s.SpannerGet(ctx, "events", "1", nil, nil)
+		assert.Equal(t, err, nil)
+		return item
+	}
+
+	_, _, err := s.SpannerPut(ctx, "events", map[string]interface{}{"id": "1", "a": "x", "big": "old"}, &models.Eval{}, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, s.SpannerBatchPut(ctx, "events", []map[string]interface{}{{"id": "1", "a": "y"}}), nil)
+	assert.Equal(t, get(), map[string]interface{}{"id": "1", "a": "y", "big": "old"})
+	assert.Equal(t, s.SpannerBatchPut(ctx, "events", []map[string]interface{}{{"id": "1", "more": "new"}}), nil)
+	assert.Equal(t, get(), map[string]interface{}{"id": "1", "a": "y", "big": "old", "more": "new"})
+}
+
+func TestEvaluateConditionalExpressionAgain(t *testing.T) {
+	s, stop := fakeSpanner(t, "CREATE TABLE counters (id STRING(MAX) NOT NULL, n FLOAT64) PRIMARY KEY (id)")
+	defer stop()
+	defer func() { config.DbConfigMap = map[string]models.TableConfig{} }()
+	config.DbConfigMap = map[string]models.TableConfig{"counters": {PartitionKey: "id"}}
+	models.SpannerTableMap["counters"] = "test"
+	models.TableDDL["counters"] = map[string]string{"id": "STRING(MAX)", "n": "FLOAT64"}
+	models.TableColumnMap["counters"] = []string{"id", "n"}
+	ctx := context.Background()
+	_, err := s.spannerClient["test"].Apply(ctx, []*spanner.Mutation{spanner.InsertMap("counters", map[string]interface{}{"id": "1", "n": float64(1)})})
+	assert.Equal(t, err, nil)
+
+	// SET n = if_not_exists(n, :zero) + :two
+	expr := &models.UpdateExpressionCondition{Field: []string{"n"}, Condition: []string{"if_not_exists(n)"}, AddValues: map[string]float64{"n": 2}}
+	_, err = s.spannerClient["test"].ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		// a transaction retried on Aborted evaluates the expression again
+		for attempt := 0; attempt < 2; attempt++ {
+			m := map[string]interface{}{"id": "1", "n": float64(0)}
+			status, err := evaluateConditionalExpression(ctx, txn, "counters", m, &models.Eval{}, expr)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, status, true)
+			assert.Equal(t, m, map[string]interface{}{"id": "1", "n": float64(3)})
+		}
+		return nil
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, expr.AddValues, map[string]float64{"n": 2})
+}
@@ -18,10 +18,8 @@ package storage
 import (
 	"context"
 	"os"
-	"os/signal"
 	"strings"
 	"sync"
-	"syscall"
 
 	"cloud.google.com/go/spanner"
 	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
@@ -53,13 +51,17 @@ func init() {
 // Storage object for intracting with storage package
 type Storage struct {
 	spannerClient map[string]*spanner.Client
+	secondary     map[string]*spanner.Client
+	failover      map[string]*failover
+	readClient    *spanner.Client
+	writeClient   *spanner.Client
 }
 
 // storage - global instance of storage
 var storage *Storage
 
 func initSpannerDriver(instance string, m map[string]*gjson.Result) *spanner.Client {
-	conf := spanner.ClientConfig{}
+	conf := clientConfig()
 
 	str := "projects/" + config.ConfigurationMap.GoogleProjectID + "/instances/" + instance + "/databases/" + config.ConfigurationMap.SpannerDb
 	Client, err := spanner.NewClientWithConfig(context.Background(), str, conf)
@@ -74,23 +76,33 @@ func InitializeDriver() {
 
 	storage = new(Storage)
 	storage.spannerClient = make(map[string]*spanner.Client)
+	storage.secondary = make(map[string]*spanner.Client)
+	storage.failover = make(map[string]*failover)
+	storage.initReadWriteSplit()
 	config := map[string]*gjson.Result{}
 	for _, v := range models.SpannerTableMap {
-		if _, ok := storage.spannerClient[v]; !ok {
+		if _, ok := storage.spannerClient[v]; !ok && storage.readClient == nil {
 			storage.spannerClient[v] = initSpannerDriver(v, config)
 		}
 	}
+	storage.initFailover()
+	initEncryption()
+	initOverflow()
 }
 
-// Close - This gracefully returns the session pool objects, when driver gets exit signal
+// Close - This gracefully returns the session pool objects, on the shutdown of the driver
 func (s Storage) Close() {
-	shutdown := make(chan os.Signal, 1)
-	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL)
-	<-shutdown
 	logger.LogDebug("Connection Shutdown start")
 	for _, v := range s.spannerClient {
 		v.Close()
 	}
+	for _, v := range s.secondary {
+		v.Close()
+	}
+	if s.readClient != nil {
+		s.readClient.Close()
+		s.writeClient.Close()
+	}
 	logger.LogDebug("Connection shutted down")
 }
 
@@ -107,6 +119,29 @@ func GetStorageInstance() *Storage {
 	return storage
 }
 
+// getSpannerClient returns the client serving the reads of the table, which
+// is ReadSpannerDb when configured or the secondary database while the
+// primary is failed over
 func (s Storage) getSpannerClient(tableName string) *spanner.Client {
-	return s.spannerClient[models.SpannerTableMap[changeTableNameForSP(tableName)]]
+	if s.readClient != nil {
+		return s.readClient
+	}
+	instance := models.SpannerTableMap[changeTableNameForSP(tableName)]
+	if f, ok := s.failover[instance]; ok {
+		return f.client()
+	}
+	return s.spannerClient[instance]
+}
+
+// getWriteClient returns the client serving the writes of the table, which
+// is WriteSpannerDb when configured
+func (s Storage) getWriteClient(tableName string) (*spanner.Client, error) {
+	if s.writeClient != nil {
+		return s.writeClient, nil
+	}
+	instance := models.SpannerTableMap[changeTableNameForSP(tableName)]
+	if f, ok := s.failover[instance]; ok {
+		return f.writeClient()
+	}
+	return s.spannerClient[instance], nil
 }
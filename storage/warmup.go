@@ -0,0 +1,113 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/pkg/logger"
+	"google.golang.org/api/iterator"
+	sppb "google.golang.org/genproto/googleapis/spanner/v1"
+)
+
+// defaultWarmUpReads is the number of warm-up reads of a client when the
+// session pool has no SessionPoolMinOpened
+const defaultWarmUpReads = 25
+
+// warmUpTimeout bounds the warm-up so that a slow Spanner does not hold the
+// startup
+const warmUpTimeout = 30 * time.Second
+
+// clientConfig is the config of every Spanner client of the adapter. The
+// OptimizerVersion is the default of the queries of the clients, the
+// SPANNER_OPTIMIZER_VERSION environment variable still overrides it.
+func clientConfig() spanner.ClientConfig {
+	conf := spanner.ClientConfig{}
+	if config.ConfigurationMap.SessionPoolMinOpened > 0 {
+		conf.MinOpened = uint64(config.ConfigurationMap.SessionPoolMinOpened)
+	}
+	if v := config.ConfigurationMap.OptimizerVersion; v != "" {
+		conf.QueryOptions = spanner.QueryOptions{Options: &sppb.ExecuteSqlRequest_QueryOptions{OptimizerVersion: v}}
+	}
+	return conf
+}
+
+// warmUpReads is the number of concurrent reads filling the session pool of
+// a client up to its SessionPoolMinOpened
+func warmUpReads() int {
+	if config.ConfigurationMap.SessionPoolMinOpened > 0 {
+		return config.ConfigurationMap.SessionPoolMinOpened
+	}
+	return defaultWarmUpReads
+}
+
+// clients returns every distinct Spanner client of the storage
+func (s Storage) clients() []*spanner.Client {
+	seen := map[*spanner.Client]bool{}
+	var clients []*spanner.Client
+	add := func(c *spanner.Client) {
+		if c != nil && !seen[c] {
+			seen[c] = true
+			clients = append(clients, c)
+		}
+	}
+	add(s.readClient)
+	add(s.writeClient)
+	for _, c := range s.spannerClient {
+		add(c)
+	}
+	for _, c := range s.secondary {
+		add(c)
+	}
+	return clients
+}
+
+// WarmUp opens the sessions of the pools before the first requests by
+// running concurrent SELECT 1 reads on every client, so the requests right
+// after a deploy do not wait for the sessions to be created. Failed reads
+// are logged, the adapter starts anyway.
+func (s Storage) WarmUp(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, warmUpTimeout)
+	defer cancel()
+	start := time.Now()
+	reads := warmUpReads()
+	var wg sync.WaitGroup
+	for _, client := range s.clients() {
+		for i := 0; i < reads; i++ {
+			wg.Add(1)
+			go func(client *spanner.Client) {
+				defer wg.Done()
+				iter := client.Single().Query(ctx, spanner.NewStatement("SELECT 1"))
+				defer iter.Stop()
+				for {
+					_, err := iter.Next()
+					if err == iterator.Done {
+						return
+					}
+					if err != nil {
+						logger.LogError("warm-up read failed", err)
+						return
+					}
+				}
+			}(client)
+		}
+	}
+	wg.Wait()
+	logger.LogInfo("warmed up the session pools with", reads, "reads per client in", time.Since(start))
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/cloudspannerecosystem/dynamodb-adapter/config"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestWarmUpReads(t *testing.T) {
+	defer func() { config.ConfigurationMap.SessionPoolMinOpened = 0 }()
+	tests := []struct {
+		testName      string
+		minOpened     int
+		wantReads     int
+		wantMinOpened uint64
+	}{
+		{"no MinOpened", 0, defaultWarmUpReads, 0},
+		{"MinOpened", 50, 50, 50},
+	}
+	for _, tc := range tests {
+		config.ConfigurationMap.SessionPoolMinOpened = tc.minOpened
+		assert.Equal(t, warmUpReads(), tc.wantReads)
+		assert.Equal(t, clientConfig().MinOpened, tc.wantMinOpened)
+	}
+}
+
+func TestClientConfigOptimizerVersion(t *testing.T) {
+	defer func() { config.ConfigurationMap.OptimizerVersion = "" }()
+	assert.Equal(t, clientConfig().QueryOptions.Options, nil)
+	config.ConfigurationMap.OptimizerVersion = "2"
+	assert.Equal(t, clientConfig().QueryOptions.Options.GetOptimizerVersion(), "2")
+}
+
+func TestStorageClients(t *testing.T) {
+	primary, secondaryA, secondaryB := &spanner.Client{}, &spanner.Client{}, &spanner.Client{}
+	s := Storage{spannerClient: map[string]*spanner.Client{"a": primary, "b": primary}, secondary: map[string]*spanner.Client{"a": secondaryA, "b": secondaryB}}
+	assert.Equal(t, len(s.clients()), 3)
+	assert.Equal(t, len(Storage{}.clients()), 0)
+}
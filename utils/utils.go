@@ -15,7 +15,11 @@
 package utils
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
 	"regexp"
 	"strconv"
 	"strings"
@@ -52,6 +56,128 @@ func GetStringInBetween(str string, start string, end string) (result string) {
 	return str[s:e]
 }
 
+var (
+	conditionFunctionCallRegexp = regexp.MustCompile(`\b(begins_with|contains)\(\s*([^\s,()]+)\s*,\s*([^\s,()]+)\s*\)`)
+	conditionFunctionRegexp     = regexp.MustCompile(`^(begins_with|contains)\(([^,]+),(:[A-Za-z0-9_]+)\)$`)
+	conditionSizeCallRegexp     = regexp.MustCompile(`\bsize\(\s*([^\s,()]+)\s*\)`)
+	conditionSizeRegexp         = regexp.MustCompile(`^size\(([^,()]+)\)$`)
+	conditionInCallRegexp       = regexp.MustCompile(`(?i)\s+IN\s*\(\s*(:[A-Za-z0-9_]+(?:\s*,\s*:[A-Za-z0-9_]+)*)\s*\)`)
+	conditionValueListRegexp    = regexp.MustCompile(`^\((:[A-Za-z0-9_]+(?:,:[A-Za-z0-9_]+)*)\)$`)
+	conditionListSpaceRegexp    = regexp.MustCompile(`\s*,\s*`)
+	sizeNotEqualRegexp          = regexp.MustCompile(`fn_size\((TOKEN\d+)\) != (\S+)`)
+	notEqualSizeRegexp          = regexp.MustCompile(`(\S+) != fn_size\((TOKEN\d+)\)`)
+)
+
+// conditionFunctions are the functions of a condition, which take the
+// stored attribute and the value of the expression. Their names are prefixed
+// as contains is an operator of expr.
+var conditionFunctions = map[string]interface{}{
+	"fn_begins_with": func(attr, value interface{}) bool {
+		s, ok := attr.(string)
+		prefix, ok1 := value.(string)
+		return ok && ok1 && strings.HasPrefix(s, prefix)
+	},
+	"fn_contains": func(attr, value interface{}) bool {
+		switch a := attr.(type) {
+		case string:
+			sub, ok := value.(string)
+			return ok && strings.Contains(a, sub)
+		case []interface{}:
+			for _, v := range a {
+				if equalValues(v, value) {
+					return true
+				}
+			}
+		case []string:
+			for _, v := range a {
+				if v == value {
+					return true
+				}
+			}
+		case []float64:
+			for _, v := range a {
+				if equalValues(v, value) {
+					return true
+				}
+			}
+		}
+		return false
+	},
+	// fn_size is the length of a string in bytes or the number of elements
+	// of a list or map. It is NaN for a missing attribute or a number, so
+	// that comparing it fails.
+	"fn_size": func(attr interface{}) float64 {
+		if size, ok := attributeSize(attr); ok {
+			return size
+		}
+		return math.NaN()
+	},
+	// fn_size_ne compares the size of the attribute with <>, which fails
+	// like the other comparisons for a missing attribute or a number
+	"fn_size_ne": func(attr, value interface{}) bool {
+		size, ok := attributeSize(attr)
+		return ok && !equalValues(size, value)
+	},
+}
+
+// attributeSize returns the size of the attribute for size(), false when the
+// attribute has none
+func attributeSize(attr interface{}) (float64, bool) {
+	switch a := attr.(type) {
+	case string:
+		return float64(len(a)), true
+	case []byte:
+		return float64(len(a)), true
+	case []interface{}:
+		return float64(len(a)), true
+	case []string:
+		return float64(len(a)), true
+	case []float64:
+		return float64(len(a)), true
+	case map[string]interface{}:
+		return float64(len(a)), true
+	}
+	return 0, false
+}
+
+// equalValues compares two attribute values, numbers by their value
+func equalValues(a, b interface{}) bool {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		return ok && x == y
+	}
+	return a == b
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// conditionValue returns the literal of an expression attribute value
+func conditionValue(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return "\"" + value + "\""
+	case float64:
+		return fmt.Sprintf("%f", value)
+	case int64:
+		return fmt.Sprintf("%d", value)
+	case []byte:
+		// binary values are compared in their base64 form,
+		// which is how they are stored in BYTES(MAX) columns
+		return "\"" + base64.StdEncoding.EncodeToString(value) + "\""
+	}
+	return fmt.Sprint(v)
+}
+
 // CreateConditionExpression - create evelute condition from condition
 func CreateConditionExpression(condtionExpression string, expressionAttr map[string]interface{}) (*models.Eval, error) {
 	if condtionExpression == "" {
@@ -61,6 +187,13 @@ func CreateConditionExpression(condtionExpression string, expressionAttr map[str
 	condtionExpression = strings.TrimSpace(condtionExpression)
 	condtionExpression = strings.ReplaceAll(condtionExpression, "( ", "(")
 	condtionExpression = strings.ReplaceAll(condtionExpression, " )", ")")
+	condtionExpression = conditionFunctionCallRegexp.ReplaceAllString(condtionExpression, "$1($2,$3)")
+	condtionExpression = conditionSizeCallRegexp.ReplaceAllString(condtionExpression, "size($1)")
+	// the value list of IN is one token, so that it stays the right operand
+	condtionExpression = conditionInCallRegexp.ReplaceAllStringFunc(condtionExpression, func(m string) string {
+		list := conditionInCallRegexp.FindStringSubmatch(m)[1]
+		return " IN (" + conditionListSpaceRegexp.ReplaceAllString(list, ",") + ")"
+	})
 	tokens := strings.Split(condtionExpression, " ")
 	sb := strings.Builder{}
 	evalTokens := []string{}
@@ -69,23 +202,44 @@ func CreateConditionExpression(condtionExpression string, expressionAttr map[str
 	var err error
 	for i := 0; i < len(tokens); i++ {
 		if i%2 == 0 {
+			if m := conditionFunctionRegexp.FindStringSubmatch(tokens[i]); m != nil {
+				v, ok := expressionAttr[m[3]]
+				if !ok {
+					return nil, errors.New("ResourceNotFoundException", expressionAttr, m[3])
+				}
+				t := "TOKEN" + strconv.Itoa(i)
+				sb.WriteString("fn_" + m[1] + "(" + t + ", " + conditionValue(v) + ") ")
+				evalTokens = append(evalTokens, m[2])
+				cols = append(cols, m[2])
+				ts = append(ts, t)
+				continue
+			}
+			if m := conditionSizeRegexp.FindStringSubmatch(tokens[i]); m != nil {
+				t := "TOKEN" + strconv.Itoa(i)
+				sb.WriteString("fn_size(" + t + ") ")
+				evalTokens = append(evalTokens, m[1])
+				cols = append(cols, m[1])
+				ts = append(ts, t)
+				continue
+			}
+			if m := conditionValueListRegexp.FindStringSubmatch(tokens[i]); m != nil {
+				values := []string{}
+				for _, name := range strings.Split(m[1], ",") {
+					v, ok := expressionAttr[name]
+					if !ok {
+						return nil, errors.New("ResourceNotFoundException", expressionAttr, name)
+					}
+					values = append(values, conditionValue(v))
+				}
+				sb.WriteString("[" + strings.Join(values, ", ") + "] ")
+				continue
+			}
 			if strings.Contains(tokens[i], ":") {
 				v, ok := expressionAttr[tokens[i]]
 				if !ok {
 					return nil, errors.New("ResourceNotFoundException", expressionAttr, tokens[i])
 				}
-				str := fmt.Sprint(v)
-				_, ok = v.(string)
-				if ok {
-					str = "\"" + str + "\""
-				}
-				switch v.(type) {
-				case float64:
-					str = fmt.Sprintf("%f", v)
-				case int64:
-					str = fmt.Sprintf("%d", v)
-				}
-				sb.WriteString(str)
+				sb.WriteString(conditionValue(v))
 				sb.WriteString(" ")
 				continue
 			}
@@ -109,6 +263,10 @@ func CreateConditionExpression(condtionExpression string, expressionAttr map[str
 	str = strings.ReplaceAll(str, " and ", " && ")
 	str = strings.ReplaceAll(str, " AND ", " && ")
 	str = strings.ReplaceAll(str, " <> ", " != ")
+	// NaN != value holds, so <> on a size is a function of its own
+	str = sizeNotEqualRegexp.ReplaceAllString(str, "fn_size_ne($1, $2)")
+	str = notEqualSizeRegexp.ReplaceAllString(str, "fn_size_ne($2, $1)")
+	str = strings.ReplaceAll(str, " IN [", " in [")
 
 	e.Cond, err = expr.Compile(str)
 	if err != nil {
@@ -130,7 +288,14 @@ func EvaluateExpression(expression *models.Eval) (bool, error) {
 		return false, nil
 	}
 
-	val, err := expr.Run(expression.Cond, expression.ValueMap)
+	env := make(map[string]interface{}, len(expression.ValueMap)+len(conditionFunctions))
+	for k, v := range conditionFunctions {
+		env[k] = v
+	}
+	for k, v := range expression.ValueMap {
+		env[k] = v
+	}
+	val, err := expr.Run(expression.Cond, env)
 	if err != nil {
 		return false, errors.New("ConditionalCheckFailedException", err.Error())
 	}
@@ -168,3 +333,124 @@ func ParseBeginsWith(rangeExpression string) (string, string, string) {
 
 	return "", "", rangeExpression
 }
+
+var keywordRegexp = regexp.MustCompile(`(?i)(^|[^#:\w.])(and|or|not|between|in|set|remove|add|delete)\b`)
+var functionRegexp = regexp.MustCompile(`(?i)(^|[^#:\w.])(attribute_exists|attribute_not_exists|attribute_type|begins_with|contains|size|if_not_exists|list_append)\s*\(`)
+
+// NormalizeKeywords uppercases the keywords and lowercases the function names
+// of an expression. Attribute names and values are always #name or :value
+// placeholders when they clash with a keyword, so they are left untouched.
+func NormalizeKeywords(expression string) string {
+	expression = keywordRegexp.ReplaceAllStringFunc(expression, func(m string) string {
+		i := len(m) - len(strings.TrimLeftFunc(m, isNotLetter))
+		return m[:i] + strings.ToUpper(m[i:])
+	})
+	return functionRegexp.ReplaceAllStringFunc(expression, func(m string) string {
+		i := len(m) - len(strings.TrimLeftFunc(m, isNotLetter))
+		return m[:i] + strings.ToLower(m[i:])
+	})
+}
+
+func isNotLetter(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+}
+
+// PreciseNumber returns the float64 of a number, or the number as a
+// json.Number when float64 does not hold its decimal value, e.g. an integer
+// above 2^53, so that it is encoded back without losing digits
+func PreciseNumber(n string) interface{} {
+	f, err := strconv.ParseFloat(n, 64)
+	if err != nil {
+		return json.Number(n)
+	}
+	exact, _, err := big.ParseFloat(n, 10, 256, big.ToNearestEven)
+	if err != nil {
+		return f
+	}
+	shortest, _, _ := big.ParseFloat(strconv.FormatFloat(f, 'g', -1, 64), 10, 256, big.ToNearestEven)
+	if exact.Cmp(shortest) != 0 {
+		return json.Number(n)
+	}
+	return f
+}
+
+// canonicalNumberRegexp matches a decimal number with an optional exponent
+var canonicalNumberRegexp = regexp.MustCompile(`^([+-]?)(\d*)(?:\.(\d*))?(?:[eE]([+-]?\d+))?$`)
+
+// maxNumberExponent bounds the exponents CanonicalNumber expands, DynamoDB
+// numbers range from 1E-130 to 1E+126
+const maxNumberExponent = 200
+
+// CanonicalNumber returns a number the way DynamoDB formats it, without an
+// exponent, leading zeros or trailing zeros of the fraction, e.g. 10 for
+// 10.0 and 2.5 for 2.50. Strings which are not numbers are returned as is.
+func CanonicalNumber(n string) string {
+	m := canonicalNumberRegexp.FindStringSubmatch(strings.TrimSpace(n))
+	if m == nil || m[2]+m[3] == "" {
+		return n
+	}
+	exp := 0
+	if m[4] != "" {
+		var err error
+		exp, err = strconv.Atoi(m[4])
+		if err != nil || exp > maxNumberExponent || exp < -maxNumberExponent {
+			return n
+		}
+	}
+	// the number is digits * 10^exp
+	digits := strings.TrimLeft(m[2]+m[3], "0")
+	exp -= len(m[3])
+	if digits == "" {
+		return "0"
+	}
+	trimmed := strings.TrimRight(digits, "0")
+	exp += len(digits) - len(trimmed)
+	digits = trimmed
+	sign := ""
+	if m[1] == "-" {
+		sign = "-"
+	}
+	if exp >= 0 {
+		return sign + digits + strings.Repeat("0", exp)
+	}
+	point := len(digits) + exp
+	if point > 0 {
+		return sign + digits[:point] + "." + digits[point:]
+	}
+	return sign + "0." + strings.Repeat("0", -point) + digits
+}
+
+// AttributePath splits a document path like profile.email or tags[1] into its
+// attribute names and list indices. A list index is kept in its brackets,
+// e.g. [1], and is read with ListIndex.
+func AttributePath(path string) []string {
+	elements := []string{}
+	for _, name := range strings.Split(strings.TrimSpace(path), ".") {
+		name = strings.TrimSpace(name)
+		indices := ""
+		if i := strings.Index(name, "["); i > 0 {
+			name, indices = name[:i], name[i:]
+		}
+		elements = append(elements, name)
+		for indices != "" {
+			end := strings.Index(indices, "]")
+			if !strings.HasPrefix(indices, "[") || end < 0 {
+				// a malformed index is kept as an element no value has
+				elements = append(elements, indices)
+				break
+			}
+			elements = append(elements, indices[:end+1])
+			indices = indices[end+1:]
+		}
+	}
+	return elements
+}
+
+// ListIndex returns the index of a path element like [1]
+func ListIndex(element string) (int, bool) {
+	if !strings.HasPrefix(element, "[") || !strings.HasSuffix(element, "]") {
+		return 0, false
+	}
+	i, err := strconv.Atoi(element[1 : len(element)-1])
+	return i, err == nil && i >= 0
+}
@@ -15,6 +15,7 @@
 package utils
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/antonmedv/expr"
@@ -96,6 +97,110 @@ func TestCreateConditionExpression(t *testing.T) {
 	}
 }
 
+func TestBinaryCondition(t *testing.T) {
+	tests := []struct {
+		testName string
+		stored   interface{}
+		want     bool
+	}{
+		{"same bytes", "AQID", true},
+		{"different bytes", "AQIE", false},
+	}
+
+	for _, tc := range tests {
+		e, err := CreateConditionExpression("version = :v AND attribute_exists(recipients)", map[string]interface{}{":v": []byte{1, 2, 3}})
+		assert.Equal(t, err, nil)
+		e.ValueMap[e.Tokens[0]] = tc.stored
+		e.ValueMap[e.Tokens[1]] = true
+		got, _ := EvaluateExpression(e)
+		assert.Equal(t, got, tc.want)
+	}
+}
+
+func TestBoolCondition(t *testing.T) {
+	tests := []struct {
+		testName string
+		stored   interface{}
+		want     bool
+	}{
+		{"same value", true, true},
+		{"different value", false, false},
+		{"missing attribute", nil, false},
+	}
+
+	for _, tc := range tests {
+		e, err := CreateConditionExpression("notification_read = :true", map[string]interface{}{":true": true})
+		assert.Equal(t, err, nil)
+		e.ValueMap[e.Tokens[0]] = tc.stored
+		got, _ := EvaluateExpression(e)
+		assert.Equal(t, got, tc.want)
+	}
+}
+
+func TestConditionFunctions(t *testing.T) {
+	tests := []struct {
+		testName  string
+		condition string
+		value     interface{}
+		stored    interface{}
+		want      bool
+	}{
+		{"begins_with matching", "begins_with(sk, :p)", "ord#", "ord#2020", true},
+		{"begins_with not matching", "begins_with( sk , :p )", "inv#", "ord#2020", false},
+		{"begins_with on a missing attribute", "begins_with(sk, :p)", "ord#", nil, false},
+		{"contains a substring", "contains(sk, :p)", "2020", "ord#2020", true},
+		{"contains a list element", "contains(tags, :p)", "b", []interface{}{"a", "b"}, true},
+		{"contains a number", "contains(tags, :p)", float64(2), []interface{}{int64(1), int64(2)}, true},
+		{"does not contain", "contains(tags, :p)", "c", []string{"a", "b"}, false},
+		{"size within the bound", "size(description) <= :p", float64(10), "short", true},
+		{"size over the bound", "size( description ) <= :p", float64(10), "a much longer description", false},
+		{"size of a list", "size(tags) = :p", int64(2), []interface{}{"a", "b"}, true},
+		{"size of a missing attribute", "size(description) <= :p", float64(10), nil, false},
+		{"size of a number", "size(description) <= :p", float64(10), float64(1), false},
+		{"size not equal", "size(tags) <> :p", int64(3), []interface{}{"a", "b"}, true},
+		{"size equal with <>", "size(tags) <> :p", int64(2), []interface{}{"a", "b"}, false},
+		{"size of a missing attribute not equal", "size(description) <> :p", float64(3), nil, false},
+		{"value not equal to the size of a missing attribute", ":p <> size(description)", float64(3), nil, false},
+	}
+
+	for _, tc := range tests {
+		e, err := CreateConditionExpression(tc.condition+" AND attribute_exists(id)", map[string]interface{}{":p": tc.value})
+		assert.Equal(t, err, nil)
+		e.ValueMap[e.Tokens[0]] = tc.stored
+		e.ValueMap[e.Tokens[1]] = true
+		got, _ := EvaluateExpression(e)
+		assert.Equal(t, got, tc.want)
+	}
+}
+
+func TestInCondition(t *testing.T) {
+	values := map[string]interface{}{":a": "active", ":b": "pending", ":one": float64(1), ":two": float64(2)}
+	tests := []struct {
+		testName  string
+		condition string
+		stored    interface{}
+		want      bool
+	}{
+		{"value in the list", "status IN (:a, :b)", "pending", true},
+		{"value not in the list", "status IN (:a, :b)", "closed", false},
+		{"missing attribute", "status IN (:a,:b)", nil, false},
+		{"lowercase keyword", "status in ( :a )", "active", true},
+		{"number in the list", "priority IN (:one, :two)", int64(2), true},
+	}
+
+	for _, tc := range tests {
+		e, err := CreateConditionExpression(tc.condition+" AND attribute_exists(id)", values)
+		assert.Equal(t, err, nil)
+		e.ValueMap[e.Tokens[0]] = tc.stored
+		e.ValueMap[e.Tokens[1]] = true
+		got, _ := EvaluateExpression(e)
+		assert.Equal(t, got, tc.want)
+	}
+
+	_, err := CreateConditionExpression("status IN (:a, :missing)", values)
+	assert.NotEqual(t, err, nil)
+}
+
 func TestEvaluateExpression(t *testing.T) {
 	cond1, _ := expr.Compile(`TOKEN0 > "20" && TOKEN4 `)
 	tests := []struct {
@@ -212,3 +317,87 @@ func TestParseBeginsWith(t *testing.T) {
 		assert.Equal(t, third, tc.want["third"])
 	}
 }
+
+func TestNormalizeKeywords(t *testing.T) {
+	tests := []struct {
+		testName   string
+		expression string
+		want       string
+	}{
+		{"empty", "", ""},
+		{"keywords", "age between :a and :b or not #in", "age BETWEEN :a AND :b OR NOT #in"},
+		{"mixed case function", "Begins_With(name, :prefix) AND attribute_EXISTS (age)", "begins_with(name, :prefix) AND attribute_exists (age)"},
+		{"attribute names stay", "#and = :or AND brand = :v", "#and = :or AND brand = :v"},
+		{"update clauses", "set age = :a remove city", "SET age = :a REMOVE city"},
+		{"attribute named like a function", "size = :s", "size = :s"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, NormalizeKeywords(tc.expression), tc.want)
+	}
+}
+
+func TestCanonicalNumber(t *testing.T) {
+	tests := []struct {
+		testName string
+		n        string
+		want     string
+	}{
+		{"integer", "10", "10"},
+		{"integral fraction", "10.0", "10"},
+		{"trailing zeros", "2.50", "2.5"},
+		{"leading zeros", "007.25", "7.25"},
+		{"small fraction", ".05", "0.05"},
+		{"negative", "-0.500", "-0.5"},
+		{"zero", "-0.00", "0"},
+		{"positive exponent", "1.5e3", "1500"},
+		{"negative exponent", "25E-4", "0.0025"},
+		{"many digits", "12345678901234567890.10", "12345678901234567890.1"},
+		{"not a number", "abc", "abc"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, CanonicalNumber(tc.n), tc.want)
+	}
+}
+
+func TestPreciseNumber(t *testing.T) {
+	tests := []struct {
+		testName string
+		n        string
+		want     interface{}
+	}{
+		{"integer", "42", float64(42)},
+		{"fraction", "0.1", 0.1},
+		{"trailing zero", "1.50", 1.5},
+		{"exponent", "1e3", float64(1000)},
+		{"integer above 2^53", "9007199254740993", json.Number("9007199254740993")},
+		{"too many digits", "3.14159265358979323846264338327950288", json.Number("3.14159265358979323846264338327950288")},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, PreciseNumber(tc.n), tc.want)
+	}
+}
+
+func TestAttributePath(t *testing.T) {
+	tests := []struct {
+		testName string
+		path     string
+		want     []string
+	}{
+		{"attribute", "age", []string{"age"}},
+		{"nested map", " profile.email ", []string{"profile", "email"}},
+		{"list element", "tags[1]", []string{"tags", "[1]"}},
+		{"nested lists", "matrix[0][2].x", []string{"matrix", "[0]", "[2]", "x"}},
+		{"malformed index", "tags[1", []string{"tags", "[1"}},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, AttributePath(tc.path), tc.want)
+	}
+
+	i, ok := ListIndex("[2]")
+	assert.Equal(t, i, 2)
+	assert.Equal(t, ok, true)
+	_, ok = ListIndex("[1")
+	assert.Equal(t, ok, false)
+	_, ok = ListIndex("[-1]")
+	assert.Equal(t, ok, false)
+}